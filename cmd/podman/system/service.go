@@ -16,6 +16,8 @@ import (
 	"github.com/containers/podman/v3/pkg/rootless"
 	"github.com/containers/podman/v3/pkg/systemd"
 	"github.com/containers/podman/v3/pkg/util"
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -38,7 +40,13 @@ Enable a listening service for API access to Podman commands.
 	}
 
 	srvArgs = struct {
-		Timeout int64
+		Timeout           int64
+		PullMaxDownloads  int
+		PullBandwidth     string
+		RecordFailures    int
+		ReadHeaderTimeout time.Duration
+		IdleTimeout       time.Duration
+		WriteTimeout      time.Duration
 	}{}
 )
 
@@ -55,6 +63,30 @@ func init() {
 	flags.Int64VarP(&srvArgs.Timeout, timeFlagName, "t", 5, "Time until the service session expires in seconds.  Use 0 to disable the timeout")
 	_ = srvCmd.RegisterFlagCompletionFunc(timeFlagName, completion.AutocompleteNone)
 
+	pullMaxDownloadsFlagName := "pull-max-downloads"
+	flags.IntVar(&srvArgs.PullMaxDownloads, pullMaxDownloadsFlagName, 0, "Maximum number of concurrent layer downloads of all pulls, 0 for no limit")
+	_ = srvCmd.RegisterFlagCompletionFunc(pullMaxDownloadsFlagName, completion.AutocompleteNone)
+
+	pullBandwidthFlagName := "pull-bandwidth"
+	flags.StringVar(&srvArgs.PullBandwidth, pullBandwidthFlagName, "", "Bandwidth per second shared by all pulls (format: <number>[<unit>], where unit = b, k, m or g), no limit if not set")
+	_ = srvCmd.RegisterFlagCompletionFunc(pullBandwidthFlagName, completion.AutocompleteNone)
+
+	recordFailuresFlagName := "record-failures"
+	flags.IntVar(&srvArgs.RecordFailures, recordFailuresFlagName, 0, "Number of failed create, start and stop requests of containers kept to be replayed, 0 to disable recording")
+	_ = srvCmd.RegisterFlagCompletionFunc(recordFailuresFlagName, completion.AutocompleteNone)
+
+	readHeaderTimeoutFlagName := "read-header-timeout"
+	flags.DurationVar(&srvArgs.ReadHeaderTimeout, readHeaderTimeoutFlagName, 20*time.Second, "Time allowed to read the headers of a request, 0 for no limit")
+	_ = srvCmd.RegisterFlagCompletionFunc(readHeaderTimeoutFlagName, completion.AutocompleteNone)
+
+	idleTimeoutFlagName := "idle-timeout"
+	flags.DurationVar(&srvArgs.IdleTimeout, idleTimeoutFlagName, 0, "Time a keep-alive connection waits for the next request, 0 for no limit (default twice the session time)")
+	_ = srvCmd.RegisterFlagCompletionFunc(idleTimeoutFlagName, completion.AutocompleteNone)
+
+	writeTimeoutFlagName := "write-timeout"
+	flags.DurationVar(&srvArgs.WriteTimeout, writeTimeoutFlagName, 0, "Time allowed to handle a request and write the response, streaming and attach requests are exempt, 0 for no limit")
+	_ = srvCmd.RegisterFlagCompletionFunc(writeTimeoutFlagName, completion.AutocompleteNone)
+
 	flags.SetNormalizeFunc(aliasTimeoutFlag)
 }
 
@@ -95,6 +127,37 @@ func service(cmd *cobra.Command, args []string) error {
 	}
 
 	opts.Timeout = time.Duration(srvArgs.Timeout) * time.Second
+	if srvArgs.PullMaxDownloads < 0 {
+		return errors.New("--pull-max-downloads must not be negative")
+	}
+	opts.PullMaxDownloads = srvArgs.PullMaxDownloads
+	if srvArgs.RecordFailures < 0 {
+		return errors.New("--record-failures must not be negative")
+	}
+	opts.RecordFailures = srvArgs.RecordFailures
+	for _, timeout := range []struct {
+		name  string
+		value time.Duration
+		opt   **time.Duration
+	}{
+		{"read-header-timeout", srvArgs.ReadHeaderTimeout, &opts.ReadHeaderTimeout},
+		{"idle-timeout", srvArgs.IdleTimeout, &opts.IdleTimeout},
+		{"write-timeout", srvArgs.WriteTimeout, &opts.WriteTimeout},
+	} {
+		if !cmd.Flags().Changed(timeout.name) {
+			continue
+		}
+		if timeout.value < 0 {
+			return errors.Errorf("--%s must not be negative", timeout.name)
+		}
+		value := timeout.value
+		*timeout.opt = &value
+	}
+	if srvArgs.PullBandwidth != "" {
+		if opts.PullBandwidth, err = units.RAMInBytes(srvArgs.PullBandwidth); err != nil || opts.PullBandwidth < 0 {
+			return errors.Errorf("invalid --pull-bandwidth %q", srvArgs.PullBandwidth)
+		}
+	}
 	return restService(opts, cmd.Flags(), registry.PodmanConfig())
 }
 
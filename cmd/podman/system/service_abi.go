@@ -51,10 +51,22 @@ func restService(opts entities.ServiceOptions, flags *pflag.FlagSet, cfg *entiti
 	}
 
 	infra.StartWatcher(rt)
-	server, err := api.NewServerWithSettings(rt, opts.Timeout, listener)
+	rt.ImageRuntime().PullLimiter.SetLimits(opts.PullMaxDownloads, opts.PullBandwidth)
+	timeouts := api.DefaultTimeouts(opts.Timeout)
+	if opts.ReadHeaderTimeout != nil {
+		timeouts.ReadHeaderTimeout = *opts.ReadHeaderTimeout
+	}
+	if opts.IdleTimeout != nil {
+		timeouts.IdleTimeout = *opts.IdleTimeout
+	}
+	if opts.WriteTimeout != nil {
+		timeouts.WriteTimeout = *opts.WriteTimeout
+	}
+	server, err := api.NewServerWithTimeouts(rt, opts.Timeout, listener, timeouts)
 	if err != nil {
 		return err
 	}
+	server.RecordFailures(opts.RecordFailures)
 	defer func() {
 		if err := server.Shutdown(); err != nil {
 			logrus.Warnf("Error when stopping API service: %s", err)
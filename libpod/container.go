@@ -144,6 +144,9 @@ type Container struct {
 
 	// This is true if a container is restored from a checkpoint.
 	restoreFromCheckpoint bool
+
+	// startTiming collects the timing of the start in progress.
+	startTiming *define.ContainerStartTiming
 }
 
 // ContainerState contains the current state of the container
@@ -204,6 +207,16 @@ type ContainerState struct {
 	// restart policy. This is NOT incremented by normal container restarts
 	// (only by restart policy).
 	RestartCount uint `json:"restartCount,omitempty"`
+	// RestartAt is the time the restart of a failed container delayed by
+	// its restart backoff is due at. It is zero unless a restart is
+	// pending.
+	RestartAt time.Time `json:"restartAt,omitempty"`
+	// UnpauseAt is the time a container paused with PauseUntil is to be
+	// unpaused at. It is zero unless the container is paused until then.
+	UnpauseAt time.Time `json:"unpauseAt,omitempty"`
+	// StartTiming is the time spent in the phases of the last successful
+	// start of the container.
+	StartTiming *define.ContainerStartTiming `json:"startTiming,omitempty"`
 
 	// ExtensionStageHooks holds hooks which will be executed by libpod
 	// and not delegated to the OCI runtime.
@@ -577,6 +590,16 @@ func (c *Container) RestartRetries() uint {
 	return c.config.RestartRetries
 }
 
+// RestartBackoff returns the backoff of restarts after failures, nil if
+// restarts are not delayed.
+func (c *Container) RestartBackoff() *ContainerRestartBackoff {
+	if c.config.RestartBackoff == nil {
+		return nil
+	}
+	backoff := *c.config.RestartBackoff
+	return &backoff
+}
+
 // LogDriver returns the log driver for this container
 func (c *Container) LogDriver() string {
 	return c.config.LogDriver
@@ -890,6 +913,58 @@ func (c *Container) StoppedByUser() (bool, error) {
 	return c.state.StoppedByUser, nil
 }
 
+// RestartCount returns the number of times the container was restarted by
+// its restart policy since it was last started.
+func (c *Container) RestartCount() (uint, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return 0, err
+		}
+	}
+
+	return c.state.RestartCount, nil
+}
+
+// UnpauseAt returns the time the container is to be unpaused at, if it was
+// paused with PauseUntil. It is zero otherwise.
+func (c *Container) UnpauseAt() (time.Time, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	if c.state.State != define.ContainerStatePaused {
+		return time.Time{}, nil
+	}
+	return c.state.UnpauseAt, nil
+}
+
+// StartTiming returns the time spent in the phases of the last successful
+// start of the container, or nil if it was never started.
+func (c *Container) StartTiming() (*define.ContainerStartTiming, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.state.StartTiming == nil {
+		return nil, nil
+	}
+	timing := *c.state.StartTiming
+	return &timing, nil
+}
+
 // Misc Accessors
 // Most will require locking
 
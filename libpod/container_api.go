@@ -2,6 +2,7 @@ package libpod
 
 import (
 	"context"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
@@ -412,6 +413,34 @@ func (c *Container) Pause() error {
 	return c.pause()
 }
 
+// PauseUntil pauses a container and records the time it is to be unpaused
+// at. Libpod does not unpause the container by itself, this is left to the
+// caller, e.g. the API service.
+func (c *Container) PauseUntil(deadline time.Time) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	if c.state.State == define.ContainerStatePaused {
+		return errors.Wrapf(define.ErrCtrStateInvalid, "%q is already paused", c.ID())
+	}
+	if c.state.State != define.ContainerStateRunning {
+		return errors.Wrapf(define.ErrCtrStateInvalid, "%q is not running, can't pause", c.state.State)
+	}
+	defer c.newContainerEvent(events.Pause)
+	c.state.UnpauseAt = deadline
+	if err := c.pause(); err != nil {
+		c.state.UnpauseAt = time.Time{}
+		return err
+	}
+	return nil
+}
+
 // Unpause unpauses a container
 func (c *Container) Unpause() error {
 	if !c.batched {
@@ -446,8 +475,28 @@ func (c *Container) Export(path string) error {
 		return errors.Wrapf(define.ErrCtrStateInvalid, "cannot mount container %s as it is being removed", c.ID())
 	}
 
+	outFile, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "error creating file %q", path)
+	}
+	defer outFile.Close()
+
 	defer c.newContainerEvent(events.Mount)
-	return c.export(path)
+	return c.export(outFile)
+}
+
+// ExportTo writes a tar archive of a container's root filesystem to out.
+// The container is only locked to mount and unmount its root filesystem, so
+// a slow writer does not block other operations on the container.
+func (c *Container) ExportTo(out io.Writer) error {
+	mountPoint, err := c.mountForExport()
+	if err != nil {
+		return err
+	}
+	defer c.unmountAfterExport()
+
+	defer c.newContainerEvent(events.Mount)
+	return exportMountPoint(c.ID(), mountPoint, out)
 }
 
 // AddArtifact creates and writes to an artifact file for the container
@@ -605,6 +654,15 @@ func (c *Container) WaitForConditionWithInterval(ctx context.Context, waitTimeou
 // Cleanup unmounts all mount points in container and cleans up container storage
 // It also cleans up the network stack
 func (c *Container) Cleanup(ctx context.Context) error {
+	// A restart delayed by the restart backoff is scheduled once the
+	// container is cleaned up and unlocked.
+	var restartDelay time.Duration
+	defer func() {
+		if restartDelay > 0 {
+			c.scheduleRestart(restartDelay)
+		}
+	}()
+
 	if !c.batched {
 		c.lock.Lock()
 		defer c.lock.Unlock()
@@ -622,10 +680,11 @@ func (c *Container) Cleanup(ctx context.Context) error {
 	// Handle restart policy.
 	// Returns a bool indicating whether we actually restarted.
 	// If we did, don't proceed to cleanup - just exit.
-	didRestart, err := c.handleRestartPolicy(ctx)
+	didRestart, delay, err := c.handleRestartPolicy(ctx)
 	if err != nil {
 		return err
 	}
+	restartDelay = delay
 	if didRestart {
 		return nil
 	}
@@ -332,6 +332,10 @@ type ContainerMiscConfig struct {
 	// restart the container. Used only if RestartPolicy is set to
 	// "on-failure".
 	RestartRetries uint `json:"restart_retries,omitempty"`
+	// RestartBackoff, if set, delays restarts after non-zero exit codes
+	// exponentially, so crash-looping containers do not restart
+	// continuously.
+	RestartBackoff *ContainerRestartBackoff `json:"restart_backoff,omitempty"`
 	// TODO log options for log drivers
 	// PostConfigureNetNS needed when a user namespace is created by an OCI runtime
 	// if the network namespace is created before the user namespace it will be
@@ -365,3 +369,26 @@ type ContainerMiscConfig struct {
 	// Umask is the umask inside the container.
 	Umask string `json:"umask,omitempty"`
 }
+
+// ContainerRestartBackoff configures the delay of restarts after a container
+// exited with a non-zero exit code. The first restart is delayed by
+// InitialDelay, each further one by Multiplier times the previous delay, up to
+// MaxDelay.
+type ContainerRestartBackoff struct {
+	InitialDelay time.Duration `json:"initialDelay"`
+	MaxDelay     time.Duration `json:"maxDelay"`
+	Multiplier   float64       `json:"multiplier"`
+}
+
+// Delay returns the delay of the restart following the given number of
+// restarts.
+func (b *ContainerRestartBackoff) Delay(restarts uint) time.Duration {
+	delay := float64(b.InitialDelay)
+	for i := uint(0); i < restarts && delay < float64(b.MaxDelay); i++ {
+		delay *= b.Multiplier
+	}
+	if delay > float64(b.MaxDelay) {
+		return b.MaxDelay
+	}
+	return time.Duration(delay)
+}
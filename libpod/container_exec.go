@@ -581,33 +581,39 @@ func (c *Container) ExecCleanup(sessionID string) error {
 		return errors.Wrapf(define.ErrNoSuchExecSession, "container %s has no exec session with ID %s", c.ID(), sessionID)
 	}
 
-	if session.State == define.ExecStateRunning {
-		// Check if the exec session is still running.
-		alive, err := c.ociRuntime.ExecUpdateStatus(c, session.ID())
-		if err != nil {
-			return err
-		}
+	// Check if the exec session is still running.
+	alive, err := c.syncExecSessionStatus(session)
+	if err != nil {
+		return err
+	}
+	if alive {
+		return errors.Wrapf(define.ErrExecSessionStateInvalid, "cannot clean up container %s exec session %s as it is running", c.ID(), session.ID())
+	}
 
-		if alive {
-			return errors.Wrapf(define.ErrExecSessionStateInvalid, "cannot clean up container %s exec session %s as it is running", c.ID(), session.ID())
-		}
+	logrus.Infof("Cleaning up container %s exec session %s", c.ID(), session.ID())
 
-		exitCode, err := c.readExecExitCode(session.ID())
-		if err != nil {
-			return err
-		}
-		session.ExitCode = exitCode
-		session.PID = 0
-		session.State = define.ExecStateStopped
+	return c.cleanupExecBundle(session.ID())
+}
 
-		if err := c.save(); err != nil {
+// ExecSyncStatus checks whether a running exec session in the container
+// exited, and records its exit code.  Sessions started detached are running
+// in the state until then.
+func (c *Container) ExecSyncStatus(sessionID string) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
 			return err
 		}
 	}
 
-	logrus.Infof("Cleaning up container %s exec session %s", c.ID(), session.ID())
-
-	return c.cleanupExecBundle(session.ID())
+	session, ok := c.state.ExecSessions[sessionID]
+	if !ok {
+		return errors.Wrapf(define.ErrNoSuchExecSession, "container %s has no exec session with ID %s", c.ID(), sessionID)
+	}
+	_, err := c.syncExecSessionStatus(session)
+	return err
 }
 
 // ExecRemove removes an exec session in the container.
@@ -830,6 +836,27 @@ func (c *Container) readExecExitCode(sessionID string) (int, error) {
 	return ecInt, nil
 }
 
+// syncExecSessionStatus marks a running exec session as stopped if its
+// process exited, and records its exit code.  It returns whether the session
+// is still running.  The container lock must be held.
+func (c *Container) syncExecSessionStatus(session *ExecSession) (bool, error) {
+	if session.State != define.ExecStateRunning {
+		return false, nil
+	}
+	alive, err := c.ociRuntime.ExecUpdateStatus(c, session.ID())
+	if err != nil || alive {
+		return alive, err
+	}
+	exitCode, err := c.readExecExitCode(session.ID())
+	if err != nil {
+		return false, err
+	}
+	session.ExitCode = exitCode
+	session.PID = 0
+	session.State = define.ExecStateStopped
+	return false, c.save()
+}
+
 // getExecSessionPID gets the PID of an active exec session
 func (c *Container) getExecSessionPID(sessionID string) (int, error) {
 	session, ok := c.state.ExecSessions[sessionID]
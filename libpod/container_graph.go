@@ -281,3 +281,63 @@ func startNode(ctx context.Context, node *containerNode, setError bool, ctrError
 		startNode(ctx, successor, ctrErrored, ctrErrors, ctrsVisited, restart)
 	}
 }
+
+// DependencyTypes gets the containers this container depends upon, mapped to
+// the reasons it depends on them: the name of a shared namespace ("net",
+// "ipc", ...), "pod" for the infra container of its pod, "volumes-from", or
+// "generic" for any other dependency.
+func (c *Container) DependencyTypes() map[string][]string {
+	deps := make(map[string][]string)
+	add := func(id, depType string) {
+		if id != "" {
+			deps[id] = append(deps[id], depType)
+		}
+	}
+
+	add(c.config.CgroupNsCtr, "cgroup")
+	add(c.config.IPCNsCtr, "ipc")
+	add(c.config.MountNsCtr, "mnt")
+	add(c.config.NetNsCtr, "net")
+	add(c.config.PIDNsCtr, "pid")
+	add(c.config.UserNsCtr, "user")
+	add(c.config.UTSNsCtr, "uts")
+	for _, id := range c.config.Dependencies {
+		add(id, "generic")
+	}
+
+	if c.config.Pod != "" {
+		pod, err := c.runtime.state.Pod(c.config.Pod)
+		if err != nil {
+			logrus.Debugf("Unable to retrieve pod %s of container %s: %v", c.config.Pod, c.ID(), err)
+		} else if infraID, err := pod.InfraContainerID(); err == nil && infraID != "" && infraID != c.ID() {
+			add(infraID, "pod")
+		}
+	}
+
+	// Volumes from other containers are resolved at creation time and only
+	// recorded in the spec annotations.
+	if c.config.Spec != nil && c.config.Spec.Annotations != nil {
+		if volumesFrom, ok := c.config.Spec.Annotations[define.InspectAnnotationVolumesFrom]; ok && volumesFrom != "" {
+			for _, vf := range strings.Split(volumesFrom, ",") {
+				name := strings.SplitN(vf, ":", 2)[0]
+				ctr, err := c.runtime.LookupContainer(name)
+				if err != nil {
+					logrus.Debugf("Unable to look up volumes-from container %s of container %s: %v", name, c.ID(), err)
+					continue
+				}
+				add(ctr.ID(), "volumes-from")
+			}
+		}
+	}
+
+	return deps
+}
+
+// Dependents returns the IDs of the containers that depend upon this
+// container.
+func (c *Container) Dependents() ([]string, error) {
+	if !c.valid {
+		return nil, define.ErrCtrRemoved
+	}
+	return c.runtime.state.ContainerInUse(c)
+}
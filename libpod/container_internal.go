@@ -245,23 +245,42 @@ func (c *Container) shouldRestart() bool {
 // Handle container restart policy.
 // This is called when a container has exited, and was not explicitly stopped by
 // an API call to stop the container or pod it is in.
-func (c *Container) handleRestartPolicy(ctx context.Context) (_ bool, retErr error) {
+// If the restart is delayed by the restart backoff, it is recorded and the
+// delay returned, for the caller to schedule the restart once it released
+// the container.
+func (c *Container) handleRestartPolicy(ctx context.Context) (_ bool, _ time.Duration, retErr error) {
 	if !c.shouldRestart() {
-		return false, nil
+		return false, 0, nil
+	}
+
+	if c.config.RestartBackoff != nil && c.state.ExitCode != 0 {
+		if c.state.RestartAt.IsZero() {
+			delay := c.config.RestartBackoff.Delay(c.state.RestartCount)
+			logrus.Debugf("Delaying restart %d of container %s by %s", c.state.RestartCount+1, c.ID(), delay)
+			c.state.RestartAt = time.Now().Add(delay)
+			if err := c.save(); err != nil {
+				return false, 0, err
+			}
+			return false, delay, nil
+		}
+		if time.Now().Before(c.state.RestartAt) {
+			return false, 0, nil
+		}
+		c.state.RestartAt = time.Time{}
 	}
 	logrus.Debugf("Restarting container %s due to restart policy %s", c.ID(), c.config.RestartPolicy)
 
 	// Need to check if dependencies are alive.
 	if err := c.checkDependenciesAndHandleError(); err != nil {
-		return false, err
+		return false, 0, err
 	}
 
 	// Is the container running again?
 	// If so, we don't have to do anything
 	if c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
-		return false, nil
+		return false, 0, nil
 	} else if c.state.State == define.ContainerStateUnknown {
-		return false, errors.Wrapf(define.ErrInternal, "invalid container state encountered in restart attempt!")
+		return false, 0, errors.Wrapf(define.ErrInternal, "invalid container state encountered in restart attempt!")
 	}
 
 	c.newContainerEvent(events.Restart)
@@ -270,7 +289,7 @@ func (c *Container) handleRestartPolicy(ctx context.Context) (_ bool, retErr err
 	c.state.RestartCount++
 	logrus.Debugf("Container %s now on retry %d", c.ID(), c.state.RestartCount)
 	if err := c.save(); err != nil {
-		return false, err
+		return false, 0, err
 	}
 
 	defer func() {
@@ -281,24 +300,51 @@ func (c *Container) handleRestartPolicy(ctx context.Context) (_ bool, retErr err
 		}
 	}()
 	if err := c.prepare(); err != nil {
-		return false, err
+		return false, 0, err
 	}
 
 	if c.state.State == define.ContainerStateStopped {
 		// Reinitialize the container if we need to
 		if err := c.reinit(ctx, true); err != nil {
-			return false, err
+			return false, 0, err
 		}
 	} else if c.ensureState(define.ContainerStateConfigured, define.ContainerStateExited) {
 		// Initialize the container
 		if err := c.init(ctx, true); err != nil {
-			return false, err
+			return false, 0, err
 		}
 	}
 	if err := c.start(); err != nil {
-		return false, err
+		return false, 0, err
 	}
-	return true, nil
+	return true, 0, nil
+}
+
+// scheduleRestart runs the exit command of the container again once the
+// restart backoff passed, which restarts it.  A transient systemd timer or a
+// detached process is used if possible, so the restart does not depend on
+// the calling process, usually a short-lived podman container cleanup.
+func (c *Container) scheduleRestart(delay time.Duration) {
+	err := c.createRestartTimer(delay)
+	if err == nil {
+		return
+	}
+	logrus.Debugf("Unable to create systemd timer to restart container %s: %v", c.ID(), err)
+	if err = c.startDelayedRestart(delay); err == nil {
+		return
+	}
+	logrus.Debugf("Unable to delay restart of container %s in a detached process, restarting it from this process: %v", c.ID(), err)
+	id := c.ID()
+	runtime := c.runtime
+	time.AfterFunc(delay, func() {
+		ctr, err := runtime.LookupContainer(id)
+		if err != nil {
+			return
+		}
+		if err := ctr.Cleanup(context.Background()); err != nil {
+			logrus.Errorf("Unable to restart container %s: %v", id, err)
+		}
+	})
 }
 
 // Ensure that the container is in a specific state or state.
@@ -529,6 +575,9 @@ func (c *Container) teardownStorage() error {
 		return errors.Wrapf(err, "failed to cleanup container %s storage", c.ID())
 	}
 
+	// The graph driver only removes the link to a migrated layer.
+	migrated := c.migratedLayerDir()
+
 	if err := c.runtime.storageService.DeleteContainer(c.ID()); err != nil {
 		// If the container has already been removed, warn but do not
 		// error - we wanted it gone, it is already gone.
@@ -542,6 +591,12 @@ func (c *Container) teardownStorage() error {
 		return errors.Wrapf(err, "error removing container %s root filesystem", c.ID())
 	}
 
+	if migrated != "" {
+		if err := os.RemoveAll(migrated); err != nil {
+			logrus.Errorf("Unable to remove migrated layer %s of container %s: %v", migrated, c.ID(), err)
+		}
+	}
+
 	return nil
 }
 
@@ -562,6 +617,7 @@ func resetState(state *ContainerState) {
 	state.StoppedByUser = false
 	state.RestartPolicyMatch = false
 	state.RestartCount = 0
+	state.RestartAt = time.Time{}
 }
 
 // Refresh refreshes the container's state after a restart.
@@ -722,7 +778,7 @@ func (c *Container) removeConmonFiles() error {
 	return nil
 }
 
-func (c *Container) export(path string) error {
+func (c *Container) export(out io.Writer) error {
 	mountPoint := c.state.Mountpoint
 	if !c.state.Mounted {
 		containerMount, err := c.runtime.store.Mount(c.ID(), c.config.MountLabel)
@@ -737,18 +793,61 @@ func (c *Container) export(path string) error {
 		}()
 	}
 
-	input, err := archive.Tar(mountPoint, archive.Uncompressed)
+	return exportMountPoint(c.ID(), mountPoint, out)
+}
+
+// mountForExport locks the container to mount its root filesystem for
+// ExportTo.  The mount is taken even if the container is mounted already, so
+// it stays mounted when the container stops while the archive is written.
+func (c *Container) mountForExport() (string, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return "", err
+		}
+	}
+
+	if c.state.State == define.ContainerStateRemoving {
+		return "", errors.Wrapf(define.ErrCtrStateInvalid, "cannot mount container %s as it is being removed", c.ID())
+	}
+
+	mountPoint, err := c.runtime.store.Mount(c.ID(), c.config.MountLabel)
 	if err != nil {
-		return errors.Wrapf(err, "error reading container directory %q", c.ID())
+		return "", errors.Wrapf(err, "error mounting container %q", c.ID())
 	}
+	return mountPoint, nil
+}
 
-	outFile, err := os.Create(path)
+// unmountAfterExport releases the mount taken by mountForExport.
+func (c *Container) unmountAfterExport() {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			logrus.Debugf("Not unmounting container %s after export: %v", c.ID(), err)
+			return
+		}
+	}
+
+	if _, err := c.runtime.store.Unmount(c.ID(), false); err != nil {
+		logrus.Errorf("error unmounting container %q: %v", c.ID(), err)
+	}
+}
+
+// exportMountPoint writes a tar archive of the root filesystem of the
+// container mounted at mountPoint to out.
+func exportMountPoint(id, mountPoint string, out io.Writer) error {
+	input, err := archive.Tar(mountPoint, archive.Uncompressed)
 	if err != nil {
-		return errors.Wrapf(err, "error creating file %q", path)
+		return errors.Wrapf(err, "error reading container directory %q", id)
 	}
-	defer outFile.Close()
 
-	_, err = io.Copy(outFile, input)
+	defer input.Close()
+
+	_, err = io.Copy(out, input)
 	return err
 }
 
@@ -800,12 +899,14 @@ func (c *Container) prepareToStart(ctx context.Context, recursive bool) (retErr
 
 	defer func() {
 		if retErr != nil {
+			c.startTiming = nil
 			if err := c.cleanup(ctx); err != nil {
 				logrus.Errorf("error cleaning up container %s: %v", c.ID(), err)
 			}
 		}
 	}()
 
+	c.startTiming = &define.ContainerStartTiming{Started: time.Now()}
 	if err := c.prepare(); err != nil {
 		return err
 	}
@@ -1046,6 +1147,7 @@ func (c *Container) init(ctx context.Context, retainRetries bool) error {
 	}
 
 	// Generate the OCI newSpec
+	prepareStart := time.Now()
 	newSpec, err := c.generateSpec(ctx)
 	if err != nil {
 		return err
@@ -1063,6 +1165,10 @@ func (c *Container) init(ctx context.Context, retainRetries bool) error {
 	}
 
 	// With the spec complete, do an OCI create
+	createStart := time.Now()
+	if c.startTiming != nil {
+		c.startTiming.RootfsPrepare = createStart.Sub(prepareStart)
+	}
 	if err := c.ociRuntime.CreateContainer(c, nil); err != nil {
 		// Fedora 31 is carrying a patch to display improved error
 		// messages to better handle the V2 transition. This is NOT
@@ -1075,6 +1181,9 @@ func (c *Container) init(ctx context.Context, retainRetries bool) error {
 	}
 
 	logrus.Debugf("Created container %s in OCI runtime", c.ID())
+	if c.startTiming != nil {
+		c.startTiming.RuntimeCreate = time.Since(createStart)
+	}
 
 	// Remove any exec sessions leftover from a potential prior run.
 	if len(c.state.ExecSessions) > 0 {
@@ -1089,6 +1198,7 @@ func (c *Container) init(ctx context.Context, retainRetries bool) error {
 	c.state.State = define.ContainerStateCreated
 	c.state.StoppedByUser = false
 	c.state.RestartPolicyMatch = false
+	c.state.RestartAt = time.Time{}
 
 	if !retainRetries {
 		c.state.RestartCount = 0
@@ -1226,12 +1336,23 @@ func (c *Container) start() error {
 		logrus.Debugf("Starting container %s with command %v", c.ID(), c.config.Spec.Process.Args)
 	}
 
+	runtimeStart := time.Now()
+	if c.startTiming == nil {
+		// The container was initialized before.
+		c.startTiming = &define.ContainerStartTiming{Started: runtimeStart}
+	}
+	defer func() {
+		c.startTiming = nil
+	}()
 	if err := c.ociRuntime.StartContainer(c); err != nil {
 		return err
 	}
 	logrus.Debugf("Started container %s", c.ID())
 
 	c.state.State = define.ContainerStateRunning
+	c.startTiming.RuntimeStart = time.Since(runtimeStart)
+	c.startTiming.Total = time.Since(c.startTiming.Started)
+	c.state.StartTiming = c.startTiming
 
 	if c.config.SdNotifyMode != define.SdNotifyModeIgnore {
 		payload := fmt.Sprintf("MAINPID=%d", c.state.ConmonPID)
@@ -1409,6 +1530,7 @@ func (c *Container) unpause() error {
 	logrus.Debugf("Unpaused container %s", c.ID())
 
 	c.state.State = define.ContainerStateRunning
+	c.state.UnpauseAt = time.Time{}
 
 	return c.save()
 }
@@ -2034,6 +2156,41 @@ func (c *Container) saveSpec(spec *spec.Spec) error {
 	return nil
 }
 
+// updateSpecResources sets the resource limits in update on the runtime spec
+// of the container, which inspect reports while the container runs.
+func (c *Container) updateSpecResources(update *spec.LinuxResources) error {
+	if c.state.ConfigPath == "" {
+		return nil
+	}
+	content, err := ioutil.ReadFile(c.state.ConfigPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "error reading runtime spec for container %s", c.ID())
+	}
+	stateSpec := new(spec.Spec)
+	if err := json.Unmarshal(content, stateSpec); err != nil {
+		return errors.Wrapf(err, "error unmarshalling runtime spec for container %s", c.ID())
+	}
+	if stateSpec.Linux == nil {
+		return nil
+	}
+	if stateSpec.Linux.Resources == nil {
+		stateSpec.Linux.Resources = new(spec.LinuxResources)
+	}
+	mergeLinuxResources(stateSpec.Linux.Resources, update)
+
+	fileJSON, err := json.Marshal(stateSpec)
+	if err != nil {
+		return errors.Wrapf(err, "error exporting runtime spec for container %s to JSON", c.ID())
+	}
+	if err := ioutil.WriteFile(c.state.ConfigPath, fileJSON, 0644); err != nil {
+		return errors.Wrapf(err, "error writing runtime spec JSON for container %s to disk", c.ID())
+	}
+	return nil
+}
+
 // Warning: precreate hooks may alter 'config' in place.
 func (c *Container) setupOCIHooks(ctx context.Context, config *spec.Spec) (map[string][]spec.Hook, error) {
 	allHooks := make(map[string][]spec.Hook)
@@ -10,6 +10,7 @@ import (
 	"math"
 	"net"
 	"os"
+	"os/exec"
 	"os/user"
 	"path"
 	"path/filepath"
@@ -39,6 +40,7 @@ import (
 	"github.com/containers/podman/v3/pkg/lookup"
 	"github.com/containers/podman/v3/pkg/resolvconf"
 	"github.com/containers/podman/v3/pkg/rootless"
+	"github.com/containers/podman/v3/pkg/systemd"
 	"github.com/containers/podman/v3/pkg/util"
 	"github.com/containers/podman/v3/utils"
 	"github.com/containers/podman/v3/version"
@@ -63,6 +65,16 @@ func (c *Container) mountSHM(shmOptions string) error {
 	return nil
 }
 
+// remountSHM changes the size of the mounted shm tmpfs.  Shrinking it below
+// what is used fails.
+func (c *Container) remountSHM(size int64) error {
+	if err := unix.Mount("shm", c.config.ShmDir, "tmpfs", unix.MS_REMOUNT|unix.MS_NOEXEC|unix.MS_NOSUID|unix.MS_NODEV,
+		fmt.Sprintf("size=%d", size)); err != nil {
+		return errors.Wrapf(define.ErrCtrStateInvalid, "failed to resize shm tmpfs %q: %v", c.config.ShmDir, err)
+	}
+	return nil
+}
+
 func (c *Container) unmountSHM(mount string) error {
 	if err := unix.Unmount(mount, 0); err != nil {
 		if err != syscall.EINVAL && err != syscall.ENOENT {
@@ -88,8 +100,13 @@ func (c *Container) prepare() error {
 
 	wg.Add(2)
 
+	var networkSetup, imageMount time.Duration
 	go func() {
 		defer wg.Done()
+		networkStart := time.Now()
+		defer func() {
+			networkSetup = time.Since(networkStart)
+		}()
 		// Set up network namespace if not already set up
 		noNetNS := c.state.NetNS == nil
 		if c.config.CreateNetNS && noNetNS && !c.config.PostConfigureNetNS {
@@ -122,7 +139,9 @@ func (c *Container) prepare() error {
 	// Mount storage if not mounted
 	go func() {
 		defer wg.Done()
+		mountStart := time.Now()
 		mountPoint, mountStorageErr = c.mountStorage()
+		imageMount = time.Since(mountStart)
 
 		if mountStorageErr != nil {
 			return
@@ -139,6 +158,10 @@ func (c *Container) prepare() error {
 	}()
 
 	wg.Wait()
+	if c.startTiming != nil {
+		c.startTiming.NetworkSetup = networkSetup
+		c.startTiming.ImageMount = imageMount
+	}
 
 	var createErr error
 	if createNetNSErr != nil {
@@ -2311,3 +2334,57 @@ func (c *Container) createSecretMountDir() error {
 
 	return err
 }
+
+// createRestartTimer creates a transient systemd timer running the exit
+// command of the container after delay, restarting it.
+func (c *Container) createRestartTimer(delay time.Duration) error {
+	if len(c.config.ExitCommand) == 0 {
+		return errors.Errorf("container %s has no exit command", c.ID())
+	}
+	var cmd = []string{}
+	if rootless.IsRootless() {
+		cmd = append(cmd, "--user")
+	}
+	path := os.Getenv("PATH")
+	if path != "" {
+		cmd = append(cmd, "--setenv=PATH="+path)
+	}
+	// Round up, the restart must not be attempted before it is due.
+	onActive := (delay + time.Microsecond - 1) / time.Microsecond
+	cmd = append(cmd, fmt.Sprintf("--on-active=%dus", onActive), "--timer-property=AccuracySec=1s")
+	cmd = append(cmd, c.config.ExitCommand...)
+	cmd = append(cmd, c.ID())
+
+	conn, err := systemd.ConnectToDBUS()
+	if err != nil {
+		return errors.Wrapf(err, "unable to get systemd connection to delay restart")
+	}
+	conn.Close()
+	logrus.Debugf("creating systemd-transient files: %s %s", "systemd-run", cmd)
+	systemdRun := exec.Command("systemd-run", cmd...)
+	if output, err := systemdRun.CombinedOutput(); err != nil {
+		return errors.Errorf("%s", output)
+	}
+	return nil
+}
+
+// startDelayedRestart runs the exit command of the container after delay in
+// a process of its own session, outliving the calling process.
+func (c *Container) startDelayedRestart(delay time.Duration) error {
+	if len(c.config.ExitCommand) == 0 {
+		return errors.Errorf("container %s has no exit command", c.ID())
+	}
+	seconds := strconv.FormatInt(int64(math.Ceil(delay.Seconds())), 10)
+	args := append([]string{"-c", `sleep "$0" && exec "$@"`, seconds}, c.config.ExitCommand...)
+	args = append(args, c.ID())
+	cmd := exec.Command("sh", args...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	// Reaps it if this process is still running by then.
+	go func() {
+		_ = cmd.Wait()
+	}()
+	return nil
+}
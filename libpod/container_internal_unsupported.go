@@ -4,6 +4,7 @@ package libpod
 
 import (
 	"context"
+	"time"
 
 	"github.com/containers/podman/v3/libpod/define"
 	"github.com/containers/podman/v3/pkg/lookup"
@@ -14,6 +15,10 @@ func (c *Container) mountSHM(shmOptions string) error {
 	return define.ErrNotImplemented
 }
 
+func (c *Container) remountSHM(size int64) error {
+	return define.ErrNotImplemented
+}
+
 func (c *Container) unmountSHM(mount string) error {
 	return define.ErrNotImplemented
 }
@@ -57,3 +62,11 @@ func (c *Container) reloadNetwork() error {
 func (c *Container) getUserOverrides() *lookup.Overrides {
 	return nil
 }
+
+func (c *Container) createRestartTimer(delay time.Duration) error {
+	return define.ErrNotImplemented
+}
+
+func (c *Container) startDelayedRestart(delay time.Duration) error {
+	return define.ErrNotImplemented
+}
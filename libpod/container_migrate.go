@@ -0,0 +1,204 @@
+package libpod
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/storage"
+	"github.com/containers/storage/drivers/copy"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// MigrateContainerStorage moves the writable layer of a stopped container to
+// the graphroot target, laid out as in a graphroot so target can also be an
+// additional image store.  The layer stays known to the store of the
+// runtime, its directory there is replaced by a link to the new location.
+// Migrating to the graphroot of the runtime moves the layer back.
+func (r *Runtime) MigrateContainerStorage(ctx context.Context, ctr *Container, target string) error {
+	ctr.lock.Lock()
+	defer ctr.lock.Unlock()
+
+	if err := ctr.syncContainer(); err != nil {
+		return err
+	}
+
+	if !filepath.IsAbs(target) {
+		return errors.Wrapf(define.ErrInvalidArg, "graphroot %q must be an absolute path", target)
+	}
+	if ctr.ensureState(define.ContainerStateRunning, define.ContainerStatePaused, define.ContainerStateStopping) {
+		return errors.Wrapf(define.ErrCtrStateInvalid, "container %s must be stopped to migrate its storage", ctr.ID())
+	}
+	if ctr.state.Mounted {
+		return errors.Wrapf(define.ErrCtrStateInvalid, "container %s must be unmounted to migrate its storage", ctr.ID())
+	}
+
+	storageCtr, err := r.store.Container(ctr.ID())
+	if err != nil {
+		return errors.Wrapf(err, "error looking up storage of container %s", ctr.ID())
+	}
+	layerDir, err := r.containerLayerDir(r.store.GraphRoot(), storageCtr.LayerID)
+	if err != nil {
+		return err
+	}
+	targetDir, err := r.containerLayerDir(filepath.Clean(target), storageCtr.LayerID)
+	if err != nil {
+		return err
+	}
+
+	// Hold the layer store lock, so the layer cannot be mounted by another
+	// tool while it moves.
+	layers, err := r.layerStore()
+	if err != nil {
+		return err
+	}
+	layers.Lock()
+	defer layers.Unlock()
+	if modified, err := layers.Modified(); modified || err != nil {
+		if err := layers.Load(); err != nil {
+			return errors.Wrapf(err, "error loading layers")
+		}
+	}
+	mounts, err := layers.Mounted(storageCtr.LayerID)
+	if err != nil {
+		return errors.Wrapf(err, "error checking mounts of container %s", ctr.ID())
+	}
+	if mounts > 0 {
+		return errors.Wrapf(define.ErrCtrStateInvalid, "container %s is mounted %d times, unmount it to migrate its storage", ctr.ID(), mounts)
+	}
+
+	return migrateLayerDir(layerDir, targetDir)
+}
+
+// layerStore returns the layer store of the runtime, to lock it.
+func (r *Runtime) layerStore() (storage.LayerStore, error) {
+	s, ok := r.store.(interface {
+		LayerStore() (storage.LayerStore, error)
+	})
+	if !ok {
+		return nil, errors.Wrapf(define.ErrNotImplemented, "store does not provide its layer store")
+	}
+	return s.LayerStore()
+}
+
+// containerLayerDir returns the directory the graph driver of the runtime
+// keeps the layer in, below graphRoot.
+func (r *Runtime) containerLayerDir(graphRoot, layerID string) (string, error) {
+	driver := r.store.GraphDriverName()
+	switch driver {
+	case "overlay", "overlay2":
+		return filepath.Join(graphRoot, driver, layerID), nil
+	case "vfs":
+		return filepath.Join(graphRoot, driver, "dir", layerID), nil
+	}
+	return "", errors.Wrapf(define.ErrNotImplemented, "migrating container storage of the %s driver", driver)
+}
+
+// migrateLayerDir moves the layer directory linked from layerDir, or at
+// layerDir if it was never migrated, to targetDir and links it from
+// layerDir.  On failure everything is rolled back.
+func migrateLayerDir(layerDir, targetDir string) error {
+	current, err := filepath.EvalSymlinks(layerDir)
+	if err != nil {
+		return errors.Wrapf(err, "error resolving layer directory %s", layerDir)
+	}
+	if current == targetDir {
+		return errors.Wrapf(define.ErrInvalidArg, "layer is stored in %s already", targetDir)
+	}
+	if _, err := os.Lstat(targetDir); err == nil && targetDir != layerDir {
+		return errors.Wrapf(define.ErrInvalidArg, "%s exists already", targetDir)
+	}
+	if err := os.MkdirAll(filepath.Dir(targetDir), 0700); err != nil {
+		return errors.Wrapf(err, "error creating %s", filepath.Dir(targetDir))
+	}
+
+	// Detach the layer from layerDir, undo puts it back.
+	src := current
+	var undo func() error
+	if current == layerDir {
+		src = layerDir + ".migrating"
+		if err := os.Rename(layerDir, src); err != nil {
+			return errors.Wrapf(err, "error moving layer directory %s aside", layerDir)
+		}
+		undo = func() error { return os.Rename(src, layerDir) }
+	} else {
+		if err := os.Remove(layerDir); err != nil {
+			return errors.Wrapf(err, "error removing link %s", layerDir)
+		}
+		undo = func() error { return os.Symlink(current, layerDir) }
+	}
+	rollback := func(cause error) error {
+		if err := undo(); err != nil {
+			logrus.Errorf("Unable to restore layer directory %s: %v", layerDir, err)
+		}
+		return cause
+	}
+
+	copied, err := moveLayerDir(src, targetDir)
+	if err != nil {
+		return rollback(err)
+	}
+	if targetDir != layerDir {
+		if err := os.Symlink(targetDir, layerDir); err != nil {
+			if copied {
+				err2 := os.RemoveAll(targetDir)
+				if err2 != nil {
+					logrus.Errorf("Unable to remove copy of layer %s: %v", targetDir, err2)
+				}
+			} else if err2 := os.Rename(targetDir, src); err2 != nil {
+				logrus.Errorf("Unable to move layer %s back: %v", targetDir, err2)
+			}
+			return rollback(errors.Wrapf(err, "error linking layer directory %s", layerDir))
+		}
+	}
+	if copied {
+		if err := os.RemoveAll(src); err != nil {
+			logrus.Warnf("Unable to remove layer directory %s after migrating it: %v", src, err)
+		}
+	}
+	return nil
+}
+
+// moveLayerDir renames src to dst, or copies it if dst is on another
+// filesystem, and reports whether it copied.
+func moveLayerDir(src, dst string) (bool, error) {
+	err := os.Rename(src, dst)
+	if err == nil {
+		return false, nil
+	}
+	if linkErr, ok := err.(*os.LinkError); !ok || linkErr.Err != unix.EXDEV {
+		return false, errors.Wrapf(err, "error moving layer directory %s to %s", src, dst)
+	}
+	if err := copy.DirCopy(src, dst, copy.Content, true); err != nil {
+		if err2 := os.RemoveAll(dst); err2 != nil {
+			logrus.Errorf("Unable to remove partial copy of layer %s: %v", dst, err2)
+		}
+		return false, errors.Wrapf(err, "error copying layer directory %s to %s", src, dst)
+	}
+	return true, nil
+}
+
+// migratedLayerDir returns the directory the writable layer of the container
+// was migrated to, or "" if it was not.
+func (c *Container) migratedLayerDir() string {
+	storageCtr, err := c.runtime.store.Container(c.ID())
+	if err != nil {
+		return ""
+	}
+	layerDir, err := c.runtime.containerLayerDir(c.runtime.store.GraphRoot(), storageCtr.LayerID)
+	if err != nil {
+		return ""
+	}
+	fi, err := os.Lstat(layerDir)
+	if err != nil || fi.Mode()&os.ModeSymlink == 0 {
+		return ""
+	}
+	target, err := os.Readlink(layerDir)
+	if err != nil {
+		return ""
+	}
+	return target
+}
@@ -0,0 +1,85 @@
+package libpod
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateLayerDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "migrate")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	layerDir := filepath.Join(dir, "graphroot", "overlay", "layer")
+	require.NoError(t, os.MkdirAll(filepath.Join(layerDir, "diff"), 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(layerDir, "diff", "data"), []byte("intact"), 0600))
+	readData := func() string {
+		data, err := ioutil.ReadFile(filepath.Join(layerDir, "diff", "data"))
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	first := filepath.Join(dir, "first", "overlay", "layer")
+	require.NoError(t, migrateLayerDir(layerDir, first))
+	link, err := os.Readlink(layerDir)
+	require.NoError(t, err)
+	assert.Equal(t, first, link)
+	assert.Equal(t, "intact", readData())
+
+	// Migrating to where the layer is fails, and keeps it there.
+	assert.Error(t, migrateLayerDir(layerDir, first))
+	assert.Equal(t, "intact", readData())
+
+	// An existing target is not overwritten, and the layer stays linked.
+	taken := filepath.Join(dir, "taken", "overlay", "layer")
+	require.NoError(t, os.MkdirAll(taken, 0700))
+	assert.Error(t, migrateLayerDir(layerDir, taken))
+	link, err = os.Readlink(layerDir)
+	require.NoError(t, err)
+	assert.Equal(t, first, link)
+
+	second := filepath.Join(dir, "second", "overlay", "layer")
+	require.NoError(t, migrateLayerDir(layerDir, second))
+	link, err = os.Readlink(layerDir)
+	require.NoError(t, err)
+	assert.Equal(t, second, link)
+	_, err = os.Stat(first)
+	assert.True(t, os.IsNotExist(err), "layer left at the previous location")
+	assert.Equal(t, "intact", readData())
+
+	// Migrating to the graphroot moves the layer back.
+	require.NoError(t, migrateLayerDir(layerDir, layerDir))
+	fi, err := os.Lstat(layerDir)
+	require.NoError(t, err)
+	assert.True(t, fi.IsDir())
+	assert.Equal(t, "intact", readData())
+}
+
+func TestMigrateLayerDirAcrossFilesystems(t *testing.T) {
+	dir, err := ioutil.TempDir("", "migrate")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	other, err := ioutil.TempDir("/dev/shm", "migrate")
+	if err != nil {
+		t.Skipf("no tmpfs to migrate to: %v", err)
+	}
+	defer os.RemoveAll(other)
+
+	layerDir := filepath.Join(dir, "overlay", "layer")
+	require.NoError(t, os.MkdirAll(filepath.Join(layerDir, "diff"), 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(layerDir, "diff", "data"), []byte("intact"), 0600))
+	require.NoError(t, os.Symlink("data", filepath.Join(layerDir, "diff", "link")))
+
+	target := filepath.Join(other, "overlay", "layer")
+	require.NoError(t, migrateLayerDir(layerDir, target))
+	data, err := ioutil.ReadFile(filepath.Join(layerDir, "diff", "link"))
+	require.NoError(t, err)
+	assert.Equal(t, "intact", string(data))
+	_, err = os.Stat(layerDir + ".migrating")
+	assert.True(t, os.IsNotExist(err), "copied layer left behind")
+}
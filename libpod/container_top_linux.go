@@ -27,7 +27,7 @@ func (c *Container) Top(descriptors []string) ([]string, error) {
 		return nil, errors.Wrapf(err, "unable to look up state for %s", c.ID())
 	}
 	if conStat != define.ContainerStateRunning {
-		return nil, errors.Errorf("top can only be used on running containers")
+		return nil, errors.Wrapf(define.ErrCtrStateInvalid, "top can only be used on running containers")
 	}
 
 	// Also support comma-separated input.
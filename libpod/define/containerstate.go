@@ -1,6 +1,10 @@
 package define
 
-import "github.com/pkg/errors"
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
 
 // ContainerStatus represents the current state of a container
 type ContainerStatus int
@@ -136,3 +140,19 @@ type ContainerStats struct {
 	BlockOutput   uint64
 	PIDs          uint64
 }
+
+// ContainerStartTiming is the time spent in the phases of starting a
+// container.  Phases which were not part of the start, e.g. creating the
+// container in the OCI runtime when it was initialized before, are zero.
+// Mounting the image and setting up the network run concurrently.
+type ContainerStartTiming struct {
+	// Started is when the start began.
+	Started time.Time
+	// Total is the time the whole start took.
+	Total         time.Duration
+	ImageMount    time.Duration
+	RootfsPrepare time.Duration
+	RuntimeCreate time.Duration
+	RuntimeStart  time.Duration
+	NetworkSetup  time.Duration
+}
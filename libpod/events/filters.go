@@ -59,14 +59,11 @@ func generateEventFilter(filter, filterValue string) (func(e *Event) bool, error
 	case "LABEL":
 		return func(e *Event) bool {
 			var found bool
-			// iterate labels and see if we match a key and value
+			// iterate labels and see if we match a key and value, or
+			// just the key when no value is given
+			filterValueSplit := strings.SplitN(filterValue, "=", 2)
 			for eventKey, eventValue := range e.Attributes {
-				filterValueSplit := strings.SplitN(filterValue, "=", 2)
-				// if the filter isn't right, just return false
-				if len(filterValueSplit) < 2 {
-					return false
-				}
-				if eventKey == filterValueSplit[0] && eventValue == filterValueSplit[1] {
+				if eventKey == filterValueSplit[0] && (len(filterValueSplit) < 2 || eventValue == filterValueSplit[1]) {
 					found = true
 					break
 				}
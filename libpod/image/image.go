@@ -65,6 +65,8 @@ type Runtime struct {
 	EventsLogFilePath   string
 	EventsLogger        string
 	Eventer             events.Eventer
+	// PullLimiter limits the downloads of the pulls from registries.
+	PullLimiter *PullLimiter
 }
 
 // InfoImage keep information of Image along with all associated layers
@@ -93,7 +95,8 @@ var ErrImageIsBareList = stderrors.New("image contains a manifest list or image
 // NewImageRuntimeFromStore creates an ImageRuntime based on a provided store
 func NewImageRuntimeFromStore(store storage.Store) *Runtime {
 	return &Runtime{
-		store: store,
+		store:       store,
+		PullLimiter: NewPullLimiter(),
 	}
 }
 
@@ -620,6 +623,16 @@ func (i *Image) TopLayer() string {
 // TODO: the force param does nothing as of now. Need to move container
 // handling logic here eventually.
 func (i *Image) Remove(ctx context.Context, force bool) error {
+	return i.remove(ctx, true)
+}
+
+// RemoveNoPrune removes an image as Remove, but keeps its parent images
+// left untagged.
+func (i *Image) RemoveNoPrune(ctx context.Context, force bool) error {
+	return i.remove(ctx, false)
+}
+
+func (i *Image) remove(ctx context.Context, prune bool) error {
 	parent, err := i.GetParent(ctx)
 	if err != nil {
 		return err
@@ -628,7 +641,7 @@ func (i *Image) Remove(ctx context.Context, force bool) error {
 		return err
 	}
 	i.newImageEvent(events.Remove)
-	for parent != nil {
+	for prune && parent != nil {
 		nextParent, err := parent.GetParent(ctx)
 		if err != nil {
 			return err
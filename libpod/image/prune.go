@@ -47,13 +47,10 @@ func generatePruneFilterFuncs(filter, filterValue string) (ImageFilter, error) {
 		}
 		until := time.Unix(seconds, nanoseconds)
 		return func(i *Image) bool {
-			if !until.IsZero() && i.Created().After((until)) {
-				return true
-			}
-			return false
+			return until.IsZero() || i.Created().Before(until)
 		}, nil
 	}
-	return nil, nil
+	return nil, errors.Errorf("%q is not a valid filter", filter)
 }
 
 // GetPruneImages returns a slice of images that have no names/unused
@@ -72,11 +69,12 @@ func (ir *Runtime) GetPruneImages(ctx context.Context, all bool, filterFuncs []I
 		return nil, err
 	}
 
+images:
 	for _, i := range allImages {
 		// filter the images based on this.
 		for _, filterFunc := range filterFuncs {
 			if !filterFunc(i) {
-				continue
+				continue images
 			}
 		}
 
@@ -346,8 +346,12 @@ func (ir *Runtime) doPullImage(ctx context.Context, sc *types.SystemContext, goa
 			}
 		}
 		imageInfo := imageInfo
+		srcRef := imageInfo.srcRef
+		if ir.PullLimiter != nil && srcRef.Transport().Name() == DockerTransport {
+			srcRef = &limitedReference{ImageReference: srcRef, limiter: ir.PullLimiter}
+		}
 		if err = retry.RetryIfNecessary(ctx, func() error {
-			_, err = cp.Image(ctx, policyContext, imageInfo.dstRef, imageInfo.srcRef, copyOptions)
+			_, err = cp.Image(ctx, policyContext, imageInfo.dstRef, srcRef, copyOptions)
 			return err
 		}, retryOptions); err != nil {
 			pullErrors = append(pullErrors, err)
@@ -362,7 +366,7 @@ func (ir *Runtime) doPullImage(ctx context.Context, sc *types.SystemContext, goa
 				}
 			}
 			if !goal.pullAllPairs {
-				ir.newImageEvent(events.Pull, "")
+				ir.newImageEvent(events.Pull, imageInfo.image)
 				return []string{imageInfo.image}, nil
 			}
 			images = append(images, imageInfo.image)
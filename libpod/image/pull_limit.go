@@ -0,0 +1,211 @@
+package image
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/containers/image/v5/types"
+)
+
+// PullLimiter limits the blob downloads of all pulls sharing it, to keep a
+// burst of pulls from saturating the network link.  The number of concurrent
+// downloads is limited, and all downloads share a token bucket filled with
+// the bandwidth.  A zero limit means no limit.
+type PullLimiter struct {
+	lock         sync.Mutex
+	maxDownloads int
+	bandwidth    int64
+	active       int
+	waiting      []chan struct{}
+	// tokens are the bytes which can be read without waiting, negative
+	// when readers are waiting for the bucket to fill up.
+	tokens float64
+	filled time.Time
+}
+
+// NewPullLimiter creates a pull limiter without limits.
+func NewPullLimiter() *PullLimiter {
+	return &PullLimiter{}
+}
+
+// SetLimits sets the maximum number of concurrent downloads and the
+// bandwidth in bytes per second, zero meaning no limit.  The limits apply
+// to downloads in progress too.
+func (l *PullLimiter) SetLimits(maxDownloads int, bandwidth int64) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.maxDownloads = maxDownloads
+	if bandwidth != l.bandwidth {
+		l.bandwidth = bandwidth
+		l.tokens = 0
+		l.filled = time.Now()
+	}
+	l.grant()
+}
+
+// Limits returns the maximum number of concurrent downloads and the
+// bandwidth in bytes per second.
+func (l *PullLimiter) Limits() (int, int64) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.maxDownloads, l.bandwidth
+}
+
+// Downloads returns the number of downloads in progress and the number of
+// downloads waiting to start.
+func (l *PullLimiter) Downloads() (int, int) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.active, len(l.waiting)
+}
+
+// grant starts waiting downloads as far as the limit allows.  The lock must
+// be held.
+func (l *PullLimiter) grant() {
+	for len(l.waiting) > 0 && (l.maxDownloads <= 0 || l.active < l.maxDownloads) {
+		close(l.waiting[0])
+		l.waiting = l.waiting[1:]
+		l.active++
+	}
+}
+
+// acquire waits until a download can start.
+func (l *PullLimiter) acquire(ctx context.Context) error {
+	l.lock.Lock()
+	if len(l.waiting) == 0 && (l.maxDownloads <= 0 || l.active < l.maxDownloads) {
+		l.active++
+		l.lock.Unlock()
+		return nil
+	}
+	ready := make(chan struct{})
+	l.waiting = append(l.waiting, ready)
+	l.lock.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		l.lock.Lock()
+		defer l.lock.Unlock()
+		for i, ch := range l.waiting {
+			if ch == ready {
+				l.waiting = append(l.waiting[:i], l.waiting[i+1:]...)
+				return ctx.Err()
+			}
+		}
+		// The download was started meanwhile.
+		l.active--
+		l.grant()
+		return ctx.Err()
+	}
+}
+
+// release ends a download started with acquire.
+func (l *PullLimiter) release() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.active--
+	l.grant()
+}
+
+// take takes n bytes from the bucket, and returns how long to wait for the
+// bucket to have filled up with them.
+func (l *PullLimiter) take(n int) time.Duration {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.bandwidth <= 0 {
+		return 0
+	}
+	now := time.Now()
+	l.tokens += now.Sub(l.filled).Seconds() * float64(l.bandwidth)
+	l.filled = now
+	// Allow bursts of at most a second.
+	if l.tokens > float64(l.bandwidth) {
+		l.tokens = float64(l.bandwidth)
+	}
+	l.tokens -= float64(n)
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / float64(l.bandwidth) * float64(time.Second))
+}
+
+// chunkSize returns the most bytes to read at once, so that a single read
+// doesn't take the bucket far below zero.
+func (l *PullLimiter) chunkSize() int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	const maxChunk = 32 * 1024
+	if l.bandwidth <= 0 || l.bandwidth >= maxChunk {
+		return maxChunk
+	}
+	if l.bandwidth < 512 {
+		return 512
+	}
+	return int(l.bandwidth)
+}
+
+// limitedReference wraps the source of a pull, so its blobs are downloaded
+// within the limits.
+type limitedReference struct {
+	types.ImageReference
+	limiter *PullLimiter
+}
+
+func (r *limitedReference) NewImageSource(ctx context.Context, sys *types.SystemContext) (types.ImageSource, error) {
+	src, err := r.ImageReference.NewImageSource(ctx, sys)
+	if err != nil {
+		return nil, err
+	}
+	return &limitedSource{ImageSource: src, limiter: r.limiter}, nil
+}
+
+type limitedSource struct {
+	types.ImageSource
+	limiter *PullLimiter
+}
+
+func (s *limitedSource) GetBlob(ctx context.Context, info types.BlobInfo, cache types.BlobInfoCache) (io.ReadCloser, int64, error) {
+	if err := s.limiter.acquire(ctx); err != nil {
+		return nil, -1, err
+	}
+	rc, size, err := s.ImageSource.GetBlob(ctx, info, cache)
+	if err != nil {
+		s.limiter.release()
+		return nil, -1, err
+	}
+	return &limitedReader{ctx: ctx, rc: rc, limiter: s.limiter}, size, nil
+}
+
+// limitedReader reads a blob within the bandwidth, and ends the download
+// when closed.
+type limitedReader struct {
+	ctx     context.Context
+	rc      io.ReadCloser
+	limiter *PullLimiter
+	once    sync.Once
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) {
+	if size := r.limiter.chunkSize(); len(p) > size {
+		p = p[:size]
+	}
+	n, err := r.rc.Read(p)
+	if wait := r.limiter.take(n); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-r.ctx.Done():
+			return n, r.ctx.Err()
+		}
+	}
+	return n, err
+}
+
+func (r *limitedReader) Close() error {
+	r.once.Do(r.limiter.release)
+	return r.rc.Close()
+}
@@ -0,0 +1,74 @@
+package image
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullLimiterConcurrency(t *testing.T) {
+	l := NewPullLimiter()
+	l.SetLimits(1, 0)
+	ctx := context.Background()
+
+	require.NoError(t, l.acquire(ctx))
+	started := make(chan error)
+	go func() {
+		started <- l.acquire(ctx)
+	}()
+	require.Eventually(t, func() bool {
+		_, waiting := l.Downloads()
+		return waiting == 1
+	}, time.Second, 10*time.Millisecond)
+	active, _ := l.Downloads()
+	assert.Equal(t, 1, active)
+
+	l.release()
+	require.NoError(t, <-started)
+	active, waiting := l.Downloads()
+	assert.Equal(t, 1, active)
+	assert.Equal(t, 0, waiting)
+
+	// A waiting download gives up when canceled.
+	cancelCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		started <- l.acquire(cancelCtx)
+	}()
+	require.Eventually(t, func() bool {
+		_, waiting := l.Downloads()
+		return waiting == 1
+	}, time.Second, 10*time.Millisecond)
+	cancel()
+	assert.Equal(t, context.Canceled, <-started)
+	_, waiting = l.Downloads()
+	assert.Equal(t, 0, waiting)
+
+	// Raising the limit starts waiting downloads.
+	go func() {
+		started <- l.acquire(ctx)
+	}()
+	require.Eventually(t, func() bool {
+		_, waiting := l.Downloads()
+		return waiting == 1
+	}, time.Second, 10*time.Millisecond)
+	l.SetLimits(0, 0)
+	require.NoError(t, <-started)
+	active, _ = l.Downloads()
+	assert.Equal(t, 2, active)
+}
+
+func TestPullLimiterBandwidth(t *testing.T) {
+	l := NewPullLimiter()
+	assert.Equal(t, time.Duration(0), l.take(1<<30))
+
+	l.SetLimits(0, 1000)
+	assert.Equal(t, 1000, l.chunkSize())
+	// Taking more than is in the bucket waits for it to fill up.
+	wait := l.take(500)
+	assert.True(t, wait > 400*time.Millisecond && wait <= 500*time.Millisecond, "wait %s", wait)
+	wait = l.take(500)
+	assert.True(t, wait > 900*time.Millisecond && wait <= time.Second, "wait %s", wait)
+}
@@ -0,0 +1,89 @@
+package image
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/containers/image/v5/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRegistry returns a registry answering searches with the results,
+// and its host name.
+func newTestRegistry(results string) (*httptest.Server, string) {
+	registry := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/":
+			w.WriteHeader(http.StatusOK)
+		case "/v1/search":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"query":%q,"results":%s}`, r.URL.Query().Get("q"), results)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	// Index names are shortened to their last two components, which would
+	// mangle an IP address.
+	return registry, strings.Replace(strings.TrimPrefix(registry.URL, "https://"), "127.0.0.1", "localhost", 1)
+}
+
+func TestSearchImages(t *testing.T) {
+	registry, host := newTestRegistry(`[
+		{"name":"library/alpine","description":"A minimal image","star_count":10,"is_official":true},
+		{"name":"someone/alpine","description":"` + strings.Repeat("long ", 20) + `","star_count":3,"is_automated":true},
+		{"name":"other/alpine","description":"","star_count":0}
+	]`)
+	defer registry.Close()
+
+	options := SearchOptions{InsecureSkipTLSVerify: types.OptionalBoolTrue}
+	results, err := SearchImages(host+"/alpine", options)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, SearchResult{
+		Index:       host,
+		Name:        host + "/library/alpine",
+		Description: "A minimal image",
+		Stars:       10,
+		Official:    "[OK]",
+	}, results[0])
+	assert.Equal(t, "[OK]", results[1].Automated)
+	assert.Len(t, results[1].Description, descriptionTruncLength+3)
+
+	options.NoTrunc = true
+	options.Limit = 2
+	results, err = SearchImages(host+"/alpine", options)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, strings.Repeat("long ", 20), results[1].Description)
+
+	options.Limit = 0
+	for _, tt := range []struct {
+		filter []string
+		names  []string
+	}{
+		{[]string{"is-official=true"}, []string{"library/alpine"}},
+		{[]string{"is-automated=true"}, []string{"someone/alpine"}},
+		{[]string{"stars=3"}, []string{"library/alpine", "someone/alpine"}},
+		{[]string{"stars=3", "is-official=false"}, []string{"someone/alpine"}},
+	} {
+		filter, err := ParseSearchFilter(tt.filter)
+		require.NoError(t, err)
+		options.Filter = *filter
+		results, err = SearchImages(host+"/alpine", options)
+		require.NoError(t, err)
+		names := []string{}
+		for _, result := range results {
+			names = append(names, strings.TrimPrefix(result.Name, host+"/"))
+		}
+		assert.Equal(t, tt.names, names, "filter %v", tt.filter)
+	}
+
+	_, err = ParseSearchFilter([]string{"nonesuch=1"})
+	assert.Error(t, err)
+	_, err = ParseSearchFilter([]string{"stars=many"})
+	assert.Error(t, err)
+}
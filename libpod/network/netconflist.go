@@ -264,6 +264,10 @@ func IfPassesFilter(netconf *libcni.NetworkConfigList, filters map[string][]stri
 		default:
 			return false, errors.Errorf("invalid filter %q", key)
 		}
+		// the network has to match all filters
+		if !result {
+			return false, nil
+		}
 	}
 	return result, nil
 }
@@ -4,6 +4,8 @@ import (
 	"net"
 	"reflect"
 	"testing"
+
+	"github.com/containernetworking/cni/libcni"
 )
 
 func TestNewIPAMDefaultRoute(t *testing.T) {
@@ -107,3 +109,53 @@ func TestNewIPAMLocalHostRange(t *testing.T) {
 		})
 	}
 }
+
+func TestIfPassesFilter(t *testing.T) {
+	netconf, err := libcni.ConfListFromBytes([]byte(`{
+		"cniVersion": "0.4.0",
+		"name": "mynet",
+		"plugins": [{"type": "bridge"}],
+		"args": {"podman_labels": {"abc": "val"}}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		filters map[string][]string
+		want    bool
+	}{
+		{
+			name:    "no filters",
+			filters: map[string][]string{},
+			want:    true,
+		},
+		{
+			name:    "all filters match",
+			filters: map[string][]string{"name": {"mynet"}, "label": {"abc=val"}, "driver": {"bridge"}},
+			want:    true,
+		},
+		{
+			name:    "name does not match",
+			filters: map[string][]string{"name": {"other"}, "label": {"abc=val"}},
+			want:    false,
+		},
+		{
+			name:    "label does not match",
+			filters: map[string][]string{"name": {"mynet"}, "label": {"abc=other"}},
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := IfPassesFilter(netconf, tt.filters)
+			if err != nil {
+				t.Errorf("no error expected: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IfPassesFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/containers/podman/v3/libpod/define"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 )
 
 // OCIRuntime is an implementation of an OCI runtime.
@@ -48,6 +49,9 @@ type OCIRuntime interface {
 	PauseContainer(ctr *Container) error
 	// UnpauseContainer unpauses the given container.
 	UnpauseContainer(ctr *Container) error
+	// UpdateContainer changes the resource limits of the given running
+	// container.
+	UpdateContainer(ctr *Container, resources *spec.LinuxResources) error
 
 	// HTTPAttach performs an attach intended to be transported over HTTP.
 	// For terminal attach, the container's output will be directly streamed
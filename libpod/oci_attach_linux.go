@@ -189,12 +189,14 @@ func registerResizeFunc(resize <-chan define.TerminalSize, bundlePath string) {
 }
 
 func setupStdioChannels(streams *define.AttachStreams, conn *net.UnixConn, detachKeys []byte) (chan error, chan error) {
-	receiveStdoutError := make(chan error)
+	// Buffered, the copy still running when the attach ends must not block
+	// forever.
+	receiveStdoutError := make(chan error, 1)
 	go func() {
 		receiveStdoutError <- redirectResponseToOutputStreams(streams.OutputStream, streams.ErrorStream, streams.AttachOutput, streams.AttachError, conn)
 	}()
 
-	stdinDone := make(chan error)
+	stdinDone := make(chan error, 1)
 	go func() {
 		var err error
 		if streams.AttachInput {
@@ -262,7 +264,9 @@ func readStdio(streams *define.AttachStreams, receiveStdoutError, stdinDone chan
 	case err = <-receiveStdoutError:
 		return err
 	case err = <-stdinDone:
-		if err == define.ErrDetach {
+		// Detached, or the input failed, as when a remote client went
+		// away, then nobody waits for the output.
+		if err != nil {
 			return err
 		}
 		if streams.AttachOutput || streams.AttachError {
@@ -507,6 +507,32 @@ func (r *ConmonOCIRuntime) UnpauseContainer(ctr *Container) error {
 	return utils.ExecCmdWithStdStreams(os.Stdin, os.Stdout, os.Stderr, env, r.path, append(r.runtimeFlags, "resume", ctr.ID())...)
 }
 
+// UpdateContainer changes the resource limits of the given running
+// container with the update command of the runtime.
+func (r *ConmonOCIRuntime) UpdateContainer(ctr *Container, resources *spec.LinuxResources) error {
+	runtimeDir, err := util.GetRuntimeDir()
+	if err != nil {
+		return err
+	}
+	env := []string{fmt.Sprintf("XDG_RUNTIME_DIR=%s", runtimeDir)}
+
+	data, err := json.Marshal(resources)
+	if err != nil {
+		return errors.Wrapf(err, "error encoding resources of container %s", ctr.ID())
+	}
+	resourcesFile := filepath.Join(ctr.bundlePath(), "resources-update.json")
+	if err := ioutil.WriteFile(resourcesFile, data, 0600); err != nil {
+		return errors.Wrapf(err, "error writing resources of container %s", ctr.ID())
+	}
+	defer os.Remove(resourcesFile)
+
+	var stderr bytes.Buffer
+	if err := utils.ExecCmdWithStdStreams(nil, nil, &stderr, env, r.path, append(r.runtimeFlags, "update", "--resources", resourcesFile, ctr.ID())...); err != nil {
+		return errors.Wrapf(err, "error updating resources of container %s: %s", ctr.ID(), strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
 // HTTPAttach performs an attach for the HTTP API.
 // The caller must handle closing the HTTP connection after this returns.
 // The cancel channel is not closed; it is up to the caller to do so after
@@ -671,8 +697,10 @@ func (r *ConmonOCIRuntime) HTTPAttach(ctr *Container, req *http.Request, w http.
 
 	logrus.Debugf("Forwarding attach output for container %s", ctr.ID())
 
-	stdoutChan := make(chan error)
-	stdinChan := make(chan error)
+	// Buffered, the copy still running when we return must not block
+	// forever.  Closing the connections makes it finish.
+	stdoutChan := make(chan error, 1)
+	stdinChan := make(chan error, 1)
 
 	// Handle STDOUT/STDERR
 	go func() {
@@ -713,6 +741,10 @@ func (r *ConmonOCIRuntime) HTTPAttach(ctr *Container, req *http.Request, w http.
 
 			return nil
 		case err := <-stdinChan:
+			if errors.Cause(err) == define.ErrDetach {
+				logrus.Debugf("Detached from container %s attach session", ctr.ID())
+				return nil
+			}
 			if err != nil {
 				return err
 			}
@@ -124,6 +124,303 @@ func (r *Runtime) RenameContainer(ctx context.Context, ctr *Container, newName s
 	return ctr, nil
 }
 
+// UpdateContainerLabels adds and removes labels of the given container.
+// Labels to remove are removed before the labels to add are set. Unless
+// allowRunning is set, the container must not be running or paused.
+func (r *Runtime) UpdateContainerLabels(ctx context.Context, ctr *Container, add map[string]string, remove []string, allowRunning bool) error {
+	ctr.lock.Lock()
+	defer ctr.lock.Unlock()
+
+	if err := ctr.syncContainer(); err != nil {
+		return err
+	}
+
+	if !allowRunning && (ctr.state.State == define.ContainerStateRunning || ctr.state.State == define.ContainerStatePaused) {
+		return errors.Wrapf(define.ErrCtrStateInvalid, "cannot change labels of container %s as it is %s", ctr.ID(), ctr.state.State)
+	}
+
+	// We need to pull an updated config, in case a rename or another
+	// label change fired and the config was re-written.
+	newConf, err := r.state.GetContainerConfig(ctr.ID())
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving container %s configuration from DB", ctr.ID())
+	}
+	ctr.config = newConf
+
+	oldLabels := ctr.config.Labels
+	labels := make(map[string]string, len(oldLabels)+len(add))
+	for k, v := range oldLabels {
+		labels[k] = v
+	}
+	for _, k := range remove {
+		delete(labels, k)
+	}
+	for k, v := range add {
+		labels[k] = v
+	}
+	ctr.config.Labels = labels
+
+	if err := r.state.SafeRewriteContainerConfig(ctr, "", "", ctr.config); err != nil {
+		// Set the labels back to reflect what is actually present in
+		// the DB.
+		ctr.config.Labels = oldLabels
+		return errors.Wrapf(err, "error changing labels of container %s", ctr.ID())
+	}
+
+	return nil
+}
+
+// SetContainerRestartBackoff sets the backoff of restarts of the given
+// container after failures. A nil backoff restarts failed containers
+// immediately again.
+func (r *Runtime) SetContainerRestartBackoff(ctx context.Context, ctr *Container, backoff *ContainerRestartBackoff) error {
+	ctr.lock.Lock()
+	defer ctr.lock.Unlock()
+
+	if err := ctr.syncContainer(); err != nil {
+		return err
+	}
+
+	if backoff != nil {
+		if backoff.InitialDelay <= 0 || backoff.MaxDelay < backoff.InitialDelay {
+			return errors.Wrapf(define.ErrInvalidArg, "restart backoff delays must be positive, with the maximum not below the initial delay")
+		}
+		if backoff.Multiplier < 1 {
+			return errors.Wrapf(define.ErrInvalidArg, "restart backoff multiplier must be at least 1, got %v", backoff.Multiplier)
+		}
+	}
+
+	// We need to pull an updated config, in case another change fired and
+	// the config was re-written.
+	newConf, err := r.state.GetContainerConfig(ctr.ID())
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving container %s configuration from DB", ctr.ID())
+	}
+	ctr.config = newConf
+
+	oldBackoff := ctr.config.RestartBackoff
+	ctr.config.RestartBackoff = backoff
+
+	if err := r.state.SafeRewriteContainerConfig(ctr, "", "", ctr.config); err != nil {
+		ctr.config.RestartBackoff = oldBackoff
+		return errors.Wrapf(err, "error setting restart backoff of container %s", ctr.ID())
+	}
+
+	return nil
+}
+
+// SetContainerAutoRemove sets whether the given container is removed when it
+// exits. The container is not removed by this, even if it is not running.
+// For a running container, disabling takes effect once it is started again.
+func (r *Runtime) SetContainerAutoRemove(ctx context.Context, ctr *Container, autoRemove bool) error {
+	ctr.lock.Lock()
+	defer ctr.lock.Unlock()
+
+	if err := ctr.syncContainer(); err != nil {
+		return err
+	}
+
+	// We need to pull an updated config, in case another change fired and
+	// the config was re-written.
+	newConf, err := r.state.GetContainerConfig(ctr.ID())
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving container %s configuration from DB", ctr.ID())
+	}
+	ctr.config = newConf
+
+	oldAnnotations := ctr.config.Spec.Annotations
+	oldExitCommand := ctr.config.ExitCommand
+	annotations := make(map[string]string, len(oldAnnotations)+1)
+	for k, v := range oldAnnotations {
+		annotations[k] = v
+	}
+	exitCommand := make([]string, 0, len(oldExitCommand)+1)
+	for _, arg := range oldExitCommand {
+		if arg != "--rm" {
+			exitCommand = append(exitCommand, arg)
+		}
+	}
+	if autoRemove {
+		annotations[define.InspectAnnotationAutoremove] = define.InspectResponseTrue
+		// The exit command must end in the arguments of the cleanup
+		// command.
+		for i, arg := range exitCommand {
+			if arg == "cleanup" && i > 0 && exitCommand[i-1] == "container" {
+				exitCommand = append(exitCommand[:i+1], append([]string{"--rm"}, exitCommand[i+1:]...)...)
+				break
+			}
+		}
+	} else {
+		annotations[define.InspectAnnotationAutoremove] = define.InspectResponseFalse
+	}
+	ctr.config.Spec.Annotations = annotations
+	ctr.config.ExitCommand = exitCommand
+
+	if err := r.state.SafeRewriteContainerConfig(ctr, "", "", ctr.config); err != nil {
+		ctr.config.Spec.Annotations = oldAnnotations
+		ctr.config.ExitCommand = oldExitCommand
+		return errors.Wrapf(err, "error setting auto removal of container %s", ctr.ID())
+	}
+
+	return nil
+}
+
+// UpdateContainerResources changes the resource limits and the restart policy
+// of the given container. Only the limits set in resources are changed, the
+// others are kept, and an empty restart policy keeps the current one. The
+// limits of a running container are changed in place.
+func (r *Runtime) UpdateContainerResources(ctx context.Context, ctr *Container, resources *spec.LinuxResources, restartPolicy string, restartRetries uint) error {
+	ctr.lock.Lock()
+	defer ctr.lock.Unlock()
+
+	if err := ctr.syncContainer(); err != nil {
+		return err
+	}
+
+	switch restartPolicy {
+	case RestartPolicyNone, RestartPolicyNo, RestartPolicyOnFailure, RestartPolicyAlways, RestartPolicyUnlessStopped:
+	default:
+		return errors.Wrapf(define.ErrInvalidArg, "%q is not a valid restart policy", restartPolicy)
+	}
+
+	// We need to pull an updated config, in case another change fired and
+	// the config was re-written.
+	newConf, err := r.state.GetContainerConfig(ctr.ID())
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving container %s configuration from DB", ctr.ID())
+	}
+	ctr.config = newConf
+	if ctr.config.Spec.Linux == nil {
+		return errors.Wrapf(define.ErrInvalidArg, "container %s has no resource limits", ctr.ID())
+	}
+
+	merged := new(spec.LinuxResources)
+	if ctr.config.Spec.Linux.Resources != nil {
+		if err := JSONDeepCopy(ctr.config.Spec.Linux.Resources, merged); err != nil {
+			return err
+		}
+	}
+	mergeLinuxResources(merged, resources)
+
+	if ctr.state.State == define.ContainerStateRunning || ctr.state.State == define.ContainerStatePaused {
+		if err := ctr.ociRuntime.UpdateContainer(ctr, resources); err != nil {
+			return err
+		}
+		if err := ctr.updateSpecResources(resources); err != nil {
+			return err
+		}
+	}
+
+	oldResources := ctr.config.Spec.Linux.Resources
+	oldPolicy, oldRetries := ctr.config.RestartPolicy, ctr.config.RestartRetries
+	ctr.config.Spec.Linux.Resources = merged
+	if restartPolicy != RestartPolicyNone {
+		ctr.config.RestartPolicy = restartPolicy
+		ctr.config.RestartRetries = restartRetries
+	}
+
+	if err := r.state.SafeRewriteContainerConfig(ctr, "", "", ctr.config); err != nil {
+		ctr.config.Spec.Linux.Resources = oldResources
+		ctr.config.RestartPolicy, ctr.config.RestartRetries = oldPolicy, oldRetries
+		return errors.Wrapf(err, "error updating resources of container %s", ctr.ID())
+	}
+
+	return nil
+}
+
+// mergeLinuxResources sets the cpu, memory and block IO limits set in update
+// on resources.
+func mergeLinuxResources(resources, update *spec.LinuxResources) {
+	if update == nil {
+		return
+	}
+	if cpu := update.CPU; cpu != nil {
+		if resources.CPU == nil {
+			resources.CPU = new(spec.LinuxCPU)
+		}
+		if cpu.Shares != nil {
+			resources.CPU.Shares = cpu.Shares
+		}
+		if cpu.Quota != nil {
+			resources.CPU.Quota = cpu.Quota
+		}
+		if cpu.Period != nil {
+			resources.CPU.Period = cpu.Period
+		}
+		if cpu.Cpus != "" {
+			resources.CPU.Cpus = cpu.Cpus
+		}
+		if cpu.Mems != "" {
+			resources.CPU.Mems = cpu.Mems
+		}
+	}
+	if memory := update.Memory; memory != nil {
+		if resources.Memory == nil {
+			resources.Memory = new(spec.LinuxMemory)
+		}
+		if memory.Limit != nil {
+			resources.Memory.Limit = memory.Limit
+		}
+		if memory.Reservation != nil {
+			resources.Memory.Reservation = memory.Reservation
+		}
+		if memory.Swap != nil {
+			resources.Memory.Swap = memory.Swap
+		}
+	}
+	if blockIO := update.BlockIO; blockIO != nil {
+		if resources.BlockIO == nil {
+			resources.BlockIO = new(spec.LinuxBlockIO)
+		}
+		if blockIO.Weight != nil {
+			resources.BlockIO.Weight = blockIO.Weight
+		}
+	}
+}
+
+// ResizeContainerShm resizes the /dev/shm tmpfs of a running container and
+// keeps the size for its next starts. The container must use the tmpfs
+// created for it by Libpod.
+func (r *Runtime) ResizeContainerShm(ctx context.Context, ctr *Container, size int64) error {
+	ctr.lock.Lock()
+	defer ctr.lock.Unlock()
+
+	if err := ctr.syncContainer(); err != nil {
+		return err
+	}
+
+	if size <= 0 {
+		return errors.Wrapf(define.ErrInvalidArg, "shm size must be positive, got %d", size)
+	}
+	if ctr.state.State != define.ContainerStateRunning {
+		return errors.Wrapf(define.ErrCtrStateInvalid, "container %s is not running", ctr.ID())
+	}
+	if ctr.config.ShmDir != filepath.Join(ctr.bundlePath(), "shm") {
+		return errors.Wrapf(define.ErrCtrStateInvalid, "/dev/shm of container %s is not managed by Libpod", ctr.ID())
+	}
+
+	// We need to pull an updated config, in case another change fired and
+	// the config was re-written.
+	newConf, err := r.state.GetContainerConfig(ctr.ID())
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving container %s configuration from DB", ctr.ID())
+	}
+	ctr.config = newConf
+
+	if err := ctr.remountSHM(size); err != nil {
+		return err
+	}
+
+	oldSize := ctr.config.ShmSize
+	ctr.config.ShmSize = size
+	if err := r.state.SafeRewriteContainerConfig(ctr, "", "", ctr.config); err != nil {
+		ctr.config.ShmSize = oldSize
+		return errors.Wrapf(err, "error setting shm size of container %s", ctr.ID())
+	}
+
+	return nil
+}
+
 func (r *Runtime) initContainerVariables(rSpec *spec.Spec, config *ContainerConfig) (*Container, error) {
 	if rSpec == nil {
 		return nil, errors.Wrapf(define.ErrInvalidArg, "must provide a valid runtime spec to create container")
@@ -32,6 +32,16 @@ import (
 // RemoveImage deletes an image from local storage
 // Images being used by running containers can only be removed if force=true
 func (r *Runtime) RemoveImage(ctx context.Context, img *image.Image, force bool) (*image.ImageDeleteResponse, error) {
+	return r.removeImage(ctx, img, force, true)
+}
+
+// RemoveImageNoPrune deletes an image from local storage as RemoveImage, but
+// keeps its parent images left untagged.
+func (r *Runtime) RemoveImageNoPrune(ctx context.Context, img *image.Image, force bool) (*image.ImageDeleteResponse, error) {
+	return r.removeImage(ctx, img, force, false)
+}
+
+func (r *Runtime) removeImage(ctx context.Context, img *image.Image, force, prune bool) (*image.ImageDeleteResponse, error) {
 	response := image.ImageDeleteResponse{}
 	r.lock.Lock()
 	defer r.lock.Unlock()
@@ -91,12 +101,16 @@ func (r *Runtime) RemoveImage(ctx context.Context, img *image.Image, force bool)
 		return nil, errors.Wrapf(define.ErrImageInUse,
 			"unable to delete %s (must force) - image is referred to in multiple tags", img.ID())
 	}
-	err = img.Remove(ctx, force)
+	remove := img.Remove
+	if !prune {
+		remove = img.RemoveNoPrune
+	}
+	err = remove(ctx, force)
 	if err != nil && errors.Cause(err) == storage.ErrImageUsedByContainer {
 		if errStorage := r.rmStorageContainers(force, img); errStorage == nil {
 			// Containers associated with the image should be deleted now,
 			// let's try removing the image again.
-			err = img.Remove(ctx, force)
+			err = remove(ctx, force)
 		} else {
 			err = errStorage
 		}
@@ -234,6 +234,33 @@ func (v *Volume) IsDangling() (bool, error) {
 	return len(ctrs) == 0, nil
 }
 
+// Mount mounts the volume on the host if necessary and returns the path on
+// the host it can be accessed at. Each call to Mount must be paired with a call
+// to Unmount.
+func (v *Volume) Mount() (string, error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if !v.valid {
+		return "", define.ErrVolumeRemoved
+	}
+	if err := v.mount(); err != nil {
+		return "", err
+	}
+	return v.mountPoint(), nil
+}
+
+// Unmount releases a mount of the volume acquired by Mount.
+func (v *Volume) Unmount() error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if !v.valid {
+		return define.ErrVolumeRemoved
+	}
+	return v.unmount(false)
+}
+
 // UsesVolumeDriver determines whether the volume uses a volume driver. Volume
 // drivers are pluggable backends for volumes that will manage the storage and
 // mounting.
@@ -7,10 +7,33 @@ import (
 	"github.com/containers/podman/v3/pkg/api/handlers/utils"
 )
 
-func Changes(w http.ResponseWriter, r *http.Request) {
+// ContainerChanges reports the changes to the filesystem of a container.
+func ContainerChanges(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value("runtime").(*libpod.Runtime)
 
-	id := utils.GetName(r)
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	writeChanges(w, runtime, ctr.ID())
+}
+
+// ImageChanges reports the changes of the top layer of an image.
+func ImageChanges(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+
+	name := utils.GetName(r)
+	img, err := runtime.ImageRuntime().NewFromLocal(name)
+	if err != nil {
+		utils.ImageNotFound(w, name, err)
+		return
+	}
+	writeChanges(w, runtime, img.ID())
+}
+
+func writeChanges(w http.ResponseWriter, runtime *libpod.Runtime, id string) {
 	changes, err := runtime.GetDiff("", id)
 	if err != nil {
 		utils.InternalServerError(w, err)
@@ -106,7 +106,7 @@ func ListContainers(w http.ResponseWriter, r *http.Request) {
 		for k, v := range query.Filters {
 			generatedFunc, err := filters.GenerateContainerFilterFuncs(k, v, runtime)
 			if err != nil {
-				utils.InternalServerError(w, err)
+				utils.BadRequest(w, "filters", k, err)
 				return
 			}
 			filterFuncs = append(filterFuncs, generatedFunc)
@@ -141,6 +141,24 @@ func ListContainers(w http.ResponseWriter, r *http.Request) {
 			containers = containers[:query.Limit]
 		}
 	}
+	if utils.WantsLibpodFormat(r) {
+		opts := entities.ContainerListOptions{
+			All:  all,
+			Pod:  true,
+			Size: query.Size,
+		}
+		list := make([]entities.ListContainer, len(containers))
+		for i, ctnr := range containers {
+			listCon, err := ps.ListContainerBatch(runtime, ctnr, opts)
+			if err != nil {
+				utils.InternalServerError(w, err)
+				return
+			}
+			list[i] = listCon
+		}
+		utils.WriteResponse(w, http.StatusOK, list)
+		return
+	}
 	var list = make([]*handlers.Container, len(containers))
 	for i, ctnr := range containers {
 		api, err := LibpodToContainer(ctnr, query.Size)
@@ -173,6 +191,15 @@ func GetContainer(w http.ResponseWriter, r *http.Request) {
 		utils.ContainerNotFound(w, name, err)
 		return
 	}
+	if utils.WantsLibpodFormat(r) {
+		data, err := ctnr.Inspect(query.Size)
+		if err != nil {
+			utils.InternalServerError(w, err)
+			return
+		}
+		utils.WriteResponse(w, http.StatusOK, data)
+		return
+	}
 	api, err := LibpodToContainerJSON(ctnr, query.Size)
 	if err != nil {
 		utils.InternalServerError(w, err)
@@ -181,6 +208,15 @@ func GetContainer(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, http.StatusOK, api)
 }
 
+// parseKillSignal parses the signal of a kill request as a name or number,
+// unlike signal.ParseSignalNameOrNumber it accepts signal 0.
+func parseKillSignal(rawSignal string) (syscall.Signal, error) {
+	if n, err := strconv.Atoi(strings.TrimPrefix(rawSignal, "-")); err == nil && n == 0 {
+		return 0, nil
+	}
+	return signal.ParseSignalNameOrNumber(rawSignal)
+}
+
 func KillContainer(w http.ResponseWriter, r *http.Request) {
 	// /{version}/containers/(name)/kill
 	runtime := r.Context().Value("runtime").(*libpod.Runtime)
@@ -195,10 +231,38 @@ func KillContainer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	sig, err := parseKillSignal(query.Signal)
+	if err != nil {
+		utils.BadRequest(w, "signal", query.Signal, err)
+		return
+	}
+
+	name := utils.GetName(r)
+	// Signal 0 sends nothing, it only probes whether the container is
+	// running.
+	if sig == 0 {
+		ctr, err := runtime.LookupContainer(name)
+		if err != nil {
+			utils.ContainerNotFound(w, name, err)
+			return
+		}
+		state, err := ctr.State()
+		if err != nil {
+			utils.InternalServerError(w, err)
+			return
+		}
+		if state != define.ContainerStateRunning {
+			utils.Error(w, fmt.Sprintf("Container %s is not running", name), http.StatusConflict,
+				errors.Wrapf(define.ErrCtrStateInvalid, "container %s is %s", name, state))
+			return
+		}
+		utils.WriteResponse(w, http.StatusNoContent, nil)
+		return
+	}
+
 	// Now use the ABI implementation to prevent us from having duplicate
 	// code.
 	containerEngine := abi.ContainerEngine{Libpod: runtime}
-	name := utils.GetName(r)
 	options := entities.KillOptions{
 		Signal: query.Signal,
 	}
@@ -219,18 +283,16 @@ func KillContainer(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if len(report) > 0 && report[0].Err != nil {
+		if errors.Cause(report[0].Err) == define.ErrCtrStateInvalid {
+			utils.Error(w, fmt.Sprintf("Container %s is not running", name), http.StatusConflict, report[0].Err)
+			return
+		}
 		utils.InternalServerError(w, report[0].Err)
 		return
 	}
-	// Docker waits for the container to stop if the signal is 0 or
-	// SIGKILL.
+	// Docker waits for the container to stop if the signal is SIGKILL.
 	if !utils.IsLibpodRequest(r) {
-		sig, err := signal.ParseSignalNameOrNumber(query.Signal)
-		if err != nil {
-			utils.InternalServerError(w, err)
-			return
-		}
-		if sig == 0 || syscall.Signal(sig) == syscall.SIGKILL {
+		if sig == syscall.SIGKILL {
 			opts := entities.WaitOptions{
 				Condition: []define.ContainerStatus{define.ContainerStateExited, define.ContainerStateStopped},
 				Interval:  time.Millisecond * 250,
@@ -431,8 +493,12 @@ func LibpodToContainerJSON(l *libpod.Container, sz bool) (*types.ContainerJSON,
 		ExecIDs:         inspect.ExecIDs,
 		HostConfig:      &hc,
 		GraphDriver:     graphDriver,
-		SizeRw:          inspect.SizeRw,
-		SizeRootFs:      &inspect.SizeRootFs,
+	}
+
+	// docker reports the sizes only when asked for
+	if sz {
+		cb.SizeRw = inspect.SizeRw
+		cb.SizeRootFs = &inspect.SizeRootFs
 	}
 
 	// set Path and Args
@@ -541,6 +607,10 @@ func RenameContainer(w http.ResponseWriter, r *http.Request) {
 		utils.Error(w, "Something went wrong.", http.StatusBadRequest, errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
 		return
 	}
+	if query.Name == "" {
+		utils.BadRequest(w, "name", query.Name, errors.New("a new name is required"))
+		return
+	}
 
 	ctr, err := runtime.LookupContainer(name)
 	if err != nil {
@@ -553,6 +623,10 @@ func RenameContainer(w http.ResponseWriter, r *http.Request) {
 			utils.Error(w, "Something went wrong.", http.StatusConflict, err)
 			return
 		}
+		if errors.Cause(err) == define.RegexError {
+			utils.BadRequest(w, "name", query.Name, err)
+			return
+		}
 		utils.InternalServerError(w, err)
 		return
 	}
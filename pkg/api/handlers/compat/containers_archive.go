@@ -9,6 +9,7 @@ import (
 	"github.com/containers/podman/v3/libpod/define"
 	"github.com/containers/podman/v3/pkg/api/handlers/utils"
 	"github.com/containers/podman/v3/pkg/copy"
+	"github.com/containers/podman/v3/pkg/domain/entities"
 	"github.com/containers/podman/v3/pkg/domain/infra/abi"
 	"github.com/gorilla/schema"
 	"github.com/pkg/errors"
@@ -92,11 +93,14 @@ func handleHeadAndGet(w http.ResponseWriter, r *http.Request, decoder *schema.De
 
 func handlePut(w http.ResponseWriter, r *http.Request, decoder *schema.Decoder, runtime *libpod.Runtime) {
 	query := struct {
-		Path string `schema:"path"`
-		// TODO handle params below
-		NoOverwriteDirNonDir bool `schema:"noOverwriteDirNonDir"`
-		CopyUIDGID           bool `schema:"copyUIDGID"`
-	}{}
+		Path                 string `schema:"path"`
+		NoOverwriteDirNonDir bool   `schema:"noOverwriteDirNonDir"`
+		CopyUIDGID           bool   `schema:"copyUIDGID"`
+	}{
+		// podman cp changes the ownership of the files to the user of
+		// the container, docker keeps the ownership in the archive.
+		CopyUIDGID: utils.IsLibpodRequest(r),
+	}
 
 	err := decoder.Decode(&query, r.URL.Query())
 	if err != nil {
@@ -107,19 +111,26 @@ func handlePut(w http.ResponseWriter, r *http.Request, decoder *schema.Decoder,
 	containerName := utils.GetName(r)
 	containerEngine := abi.ContainerEngine{Libpod: runtime}
 
-	copyFunc, err := containerEngine.ContainerCopyFromArchive(r.Context(), containerName, query.Path, r.Body)
-	if errors.Cause(err) == define.ErrNoSuchCtr || os.IsNotExist(err) {
+	options := entities.ContainerCopyFromArchiveOptions{
+		Chown:                query.CopyUIDGID,
+		NoOverwriteDirNonDir: query.NoOverwriteDirNonDir,
+	}
+	copyFunc, err := containerEngine.ContainerCopyFromArchive(r.Context(), containerName, query.Path, r.Body, options)
+	if errors.Cause(err) == define.ErrNoSuchCtr || errors.Cause(err) == copy.ErrENOENT || os.IsNotExist(err) {
 		// 404 is returned for an absent container and path.  The
 		// clients must deal with it accordingly.
-		utils.Error(w, "Not found.", http.StatusNotFound, errors.Wrap(err, "the container doesn't exists"))
+		utils.Error(w, "Not found.", http.StatusNotFound, errors.Wrap(err, "the container or path doesn't exist"))
 		return
 	} else if err != nil {
 		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	// Extract the archive before answering, so that errors can be
+	// reported.
 	if err := copyFunc(); err != nil {
-		logrus.Error(err.Error())
+		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, err)
+		return
 	}
+	w.WriteHeader(http.StatusOK)
 }
@@ -1,6 +1,7 @@
 package compat
 
 import (
+	"bufio"
 	"net/http"
 
 	"github.com/containers/podman/v3/libpod"
@@ -8,6 +9,7 @@ import (
 	"github.com/containers/podman/v3/pkg/api/handlers/utils"
 	"github.com/containers/podman/v3/pkg/api/server/idle"
 	"github.com/gorilla/schema"
+	"github.com/moby/term"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
@@ -69,26 +71,8 @@ func AttachContainer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	name := utils.GetName(r)
-	ctr, err := runtime.LookupContainer(name)
-	if err != nil {
-		utils.ContainerNotFound(w, name, err)
-		return
-	}
-
-	state, err := ctr.State()
-	if err != nil {
-		utils.InternalServerError(w, err)
-		return
-	}
-	// For Docker compatibility, we need to re-initialize containers in these states.
-	if state == define.ContainerStateConfigured || state == define.ContainerStateExited {
-		if err := ctr.Init(r.Context(), ctr.PodID() != ""); err != nil {
-			utils.Error(w, "Container in wrong state", http.StatusConflict, errors.Wrapf(err, "error preparing container %s for attach", ctr.ID()))
-			return
-		}
-	} else if !(state == define.ContainerStateCreated || state == define.ContainerStateRunning) {
-		utils.InternalServerError(w, errors.Wrapf(define.ErrCtrStateInvalid, "can only attach to created or running containers - currently in state %s", state.String()))
+	ctr := attachableContainer(w, r, runtime)
+	if ctr == nil {
 		return
 	}
 
@@ -100,7 +84,7 @@ func AttachContainer(w http.ResponseWriter, r *http.Request) {
 	// HTTPAttach will handle everything about the connection from here on
 	// (including closing it and writing errors to it).
 	hijackChan := make(chan bool, 1)
-	err = ctr.HTTPAttach(r, w, streams, detachKeys, nil, query.Stream, query.Logs, hijackChan)
+	err := ctr.HTTPAttach(r, w, streams, detachKeys, nil, query.Stream, query.Logs, hijackChan)
 
 	if <-hijackChan {
 		// If connection was Hijacked, we have to signal it's being closed
@@ -118,3 +102,157 @@ func AttachContainer(w http.ResponseWriter, r *http.Request) {
 	}
 	logrus.Debugf("Attach for container %s completed successfully", ctr.ID())
 }
+
+// attachableContainer looks up the container of an attach request and
+// prepares it for the attach.  It returns nil after writing the error if the
+// container can't be attached to.
+func attachableContainer(w http.ResponseWriter, r *http.Request, runtime *libpod.Runtime) *libpod.Container {
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return nil
+	}
+
+	state, err := ctr.State()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return nil
+	}
+	// For Docker compatibility, we need to re-initialize containers in these states.
+	if state == define.ContainerStateConfigured || state == define.ContainerStateExited {
+		if err := ctr.Init(r.Context(), ctr.PodID() != ""); err != nil {
+			utils.Error(w, "Container in wrong state", http.StatusConflict, errors.Wrapf(err, "error preparing container %s for attach", ctr.ID()))
+			return nil
+		}
+	} else if !(state == define.ContainerStateCreated || state == define.ContainerStateRunning) {
+		utils.Error(w, "Container in wrong state", http.StatusConflict, errors.Wrapf(define.ErrCtrStateInvalid, "can only attach to created or running containers - currently in state %s", state.String()))
+		return nil
+	}
+	return ctr
+}
+
+// AttachContainerWebsocket attaches to a container over a WebSocket.  The
+// messages of the client are the input of the container, and its output is
+// sent in binary messages, stdout and stderr alike.
+func AttachContainerWebsocket(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+
+	query := struct {
+		DetachKeys string `schema:"detachKeys"`
+		Stdin      bool   `schema:"stdin"`
+		Stdout     bool   `schema:"stdout"`
+		Stderr     bool   `schema:"stderr"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, "Error parsing parameters", http.StatusBadRequest, err)
+		return
+	}
+
+	// As for the hijacked attach, all streams are attached unless some
+	// are given.
+	streams := &define.AttachStreams{
+		AttachInput:  true,
+		AttachOutput: true,
+		AttachError:  true,
+	}
+	if _, found := r.URL.Query()["stdin"]; found {
+		streams.AttachInput = query.Stdin
+	}
+	if _, found := r.URL.Query()["stdout"]; found {
+		streams.AttachOutput = query.Stdout
+	}
+	if _, found := r.URL.Query()["stderr"]; found {
+		streams.AttachError = query.Stderr
+	}
+	if !streams.AttachInput && !streams.AttachOutput && !streams.AttachError {
+		utils.Error(w, "Parameter conflict", http.StatusBadRequest, errors.Errorf("at least one of stdin, stdout, stderr must be true"))
+		return
+	}
+
+	config, err := runtime.GetConfig()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	detachKeys := config.Engine.DetachKeys
+	if _, found := r.URL.Query()["detachKeys"]; found {
+		detachKeys = query.DetachKeys
+	}
+	if detachKeys != "" {
+		if _, err := term.ToBytes(detachKeys); err != nil {
+			utils.BadRequest(w, "detachKeys", detachKeys, err)
+			return
+		}
+	}
+	if err := utils.CheckWebsocketUpgrade(r); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, err)
+		return
+	}
+
+	ctr := attachableContainer(w, r, runtime)
+	if ctr == nil {
+		return
+	}
+
+	ws, err := utils.UpgradeWebsocket(w, r)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	// The connection was hijacked, we have to signal it's being closed.
+	t := r.Context().Value("idletracker").(*idle.Tracker)
+	defer t.Close()
+	defer ws.Close()
+
+	output := websocketOutput{ws: ws}
+	streams.OutputStream = output
+	streams.ErrorStream = output
+	if streams.AttachInput {
+		streams.InputStream = bufio.NewReader(ws)
+	} else {
+		// The messages of the client are read all the same, to answer
+		// its pings and to notice it closing the connection.
+		streams.AttachInput = true
+		streams.InputStream = bufio.NewReader(websocketDiscard{ws: ws})
+	}
+
+	err = ctr.Attach(streams, detachKeys, nil)
+	switch errors.Cause(err) {
+	case nil, define.ErrDetach, utils.ErrWebsocketClosed:
+		logrus.Debugf("WebSocket attach for container %s completed", ctr.ID())
+	default:
+		logrus.Error(errors.Wrapf(err, "error attaching to container %s over a websocket", ctr.ID()))
+	}
+}
+
+// websocketOutput sends the output of a container over a WebSocket, the
+// connection is closed by the handler.
+type websocketOutput struct {
+	ws *utils.WebsocketConn
+}
+
+func (o websocketOutput) Write(p []byte) (int, error) {
+	return o.ws.Write(p)
+}
+
+func (o websocketOutput) Close() error {
+	return nil
+}
+
+// websocketDiscard reads the messages of a client not attached to stdin,
+// and only returns when reading fails.
+type websocketDiscard struct {
+	ws *utils.WebsocketConn
+}
+
+func (d websocketDiscard) Read(p []byte) (int, error) {
+	for {
+		if _, err := d.ws.Read(p); err != nil {
+			return 0, err
+		}
+	}
+}
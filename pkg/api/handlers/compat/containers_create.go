@@ -12,6 +12,7 @@ import (
 	"github.com/containers/podman/v3/pkg/domain/entities"
 	"github.com/containers/podman/v3/pkg/domain/infra/abi"
 	"github.com/containers/podman/v3/pkg/specgen"
+	"github.com/containers/storage"
 	"github.com/gorilla/schema"
 	"github.com/pkg/errors"
 )
@@ -20,7 +21,8 @@ func CreateContainer(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value("runtime").(*libpod.Runtime)
 	decoder := r.Context().Value("decoder").(*schema.Decoder)
 	query := struct {
-		Name string `schema:"name"`
+		Name            string `schema:"name"`
+		PortReservation string `schema:"port-reservation"`
 	}{
 		// override any golang type defaults
 	}
@@ -33,7 +35,7 @@ func CreateContainer(w http.ResponseWriter, r *http.Request) {
 	// compatible configuration
 	body := handlers.CreateContainerConfig{}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, errors.Wrap(err, "Decode()"))
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, errors.Wrap(err, "Decode()"))
 		return
 	}
 
@@ -86,12 +88,33 @@ func CreateContainer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := utils.CheckReservedPorts(runtime, sg.PortMappings, query.PortReservation); err != nil {
+		utils.Error(w, "Something went wrong.", http.StatusConflict, err)
+		return
+	}
+	if query.PortReservation != "" {
+		if err := utils.ClaimPortReservation(sg.PortMappings, query.PortReservation); err != nil {
+			utils.BadRequest(w, "port-reservation", query.PortReservation, err)
+			return
+		}
+	}
+
 	ic := abi.ContainerEngine{Libpod: runtime}
 	report, err := ic.ContainerCreate(r.Context(), sg)
 	if err != nil {
+		utils.UnclaimPortReservation(query.PortReservation)
+		// The name is reserved by storage before the container is added
+		// to the database.
+		if cause := errors.Cause(err); cause == define.ErrCtrExists || cause == storage.ErrDuplicateName {
+			utils.Error(w, "Something went wrong.", http.StatusConflict, err)
+			return
+		}
 		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, errors.Wrap(err, "container create"))
 		return
 	}
+	if query.PortReservation != "" {
+		utils.HoldPortReservation(query.PortReservation, report.Id)
+	}
 	createResponse := entities.ContainerCreateResponse{
 		ID:       report.Id,
 		Warnings: []string{},
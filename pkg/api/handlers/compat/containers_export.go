@@ -1,13 +1,14 @@
 package compat
 
 import (
-	"io/ioutil"
+	"bufio"
+	"io"
 	"net/http"
-	"os"
 
 	"github.com/containers/podman/v3/libpod"
 	"github.com/containers/podman/v3/pkg/api/handlers/utils"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 func ExportContainer(w http.ResponseWriter, r *http.Request) {
@@ -18,25 +19,24 @@ func ExportContainer(w http.ResponseWriter, r *http.Request) {
 		utils.ContainerNotFound(w, name, err)
 		return
 	}
-	tmpfile, err := ioutil.TempFile("", "api.tar")
-	if err != nil {
-		utils.Error(w, "unable to create tarball tempfile", http.StatusInternalServerError, errors.Wrap(err, "unable to create tempfile"))
-		return
-	}
-	defer os.Remove(tmpfile.Name())
-	if err := tmpfile.Close(); err != nil {
-		utils.Error(w, "unable to close tempfile", http.StatusInternalServerError, errors.Wrap(err, "unable to close tempfile"))
-		return
-	}
-	if err := con.Export(tmpfile.Name()); err != nil {
-		utils.Error(w, "failed to save the image", http.StatusInternalServerError, errors.Wrap(err, "failed to save image"))
+
+	// Stream the archive as it is written instead of buffering it.
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(con.ExportTo(writer))
+	}()
+	defer reader.Close()
+
+	// Errors mounting the container come before the archive, and can still
+	// be reported.
+	rdr := bufio.NewReader(reader)
+	if _, err := rdr.Peek(1); err != nil && err != io.EOF {
+		utils.Error(w, "failed to export the container", http.StatusInternalServerError, errors.Wrap(err, "failed to export container"))
 		return
 	}
-	rdr, err := os.Open(tmpfile.Name())
-	if err != nil {
-		utils.Error(w, "failed to read temp tarball", http.StatusInternalServerError, errors.Wrap(err, "failed to read the exported tarfile"))
-		return
+	w.Header().Set("Content-Type", "application/x-tar")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, rdr); err != nil {
+		logrus.Errorf("Unable to export container %s: %v", con.ID(), err)
 	}
-	defer rdr.Close()
-	utils.WriteResponse(w, http.StatusOK, rdr)
 }
@@ -28,7 +28,7 @@ func PruneContainers(w http.ResponseWriter, r *http.Request) {
 	for k, v := range query.Filters {
 		generatedFunc, err := filters.GenerateContainerFilterFuncs(k, v, runtime)
 		if err != nil {
-			utils.InternalServerError(w, err)
+			utils.BadRequest(w, "filters", k, err)
 			return
 		}
 		filterFuncs = append(filterFuncs, generatedFunc)
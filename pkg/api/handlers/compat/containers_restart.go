@@ -36,11 +36,15 @@ func RestartContainer(w http.ResponseWriter, r *http.Request) {
 	name := utils.GetName(r)
 
 	options := entities.RestartOptions{
-		All:     query.All,
-		Timeout: &query.DockerTimeout,
+		All: query.All,
 	}
+	// Without a timeout, the stop timeout of the container is used.
 	if utils.IsLibpodRequest(r) {
-		options.Timeout = &query.LibpodTimeout
+		if _, found := r.URL.Query()["timeout"]; found {
+			options.Timeout = &query.LibpodTimeout
+		}
+	} else if _, found := r.URL.Query()["t"]; found {
+		options.Timeout = &query.DockerTimeout
 	}
 	report, err := containerEngine.ContainerRestart(r.Context(), []string{name}, options)
 	if err != nil {
@@ -9,6 +9,8 @@ import (
 	"github.com/containers/podman/v3/libpod/define"
 	"github.com/containers/podman/v3/pkg/api/handlers/utils"
 	"github.com/gorilla/schema"
+	"github.com/moby/term"
+	"github.com/pkg/errors"
 )
 
 func StartContainer(w http.ResponseWriter, r *http.Request) {
@@ -23,6 +25,10 @@ func StartContainer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if len(query.DetachKeys) > 0 {
+		if _, err := term.ToBytes(query.DetachKeys); err != nil {
+			utils.BadRequest(w, "detachKeys", query.DetachKeys, err)
+			return
+		}
 		// TODO - start does not support adding detach keys
 		logrus.Info("the detach keys parameter is not supported on start container")
 	}
@@ -42,6 +48,10 @@ func StartContainer(w http.ResponseWriter, r *http.Request) {
 		utils.WriteResponse(w, http.StatusNotModified, nil)
 		return
 	}
+	if state == define.ContainerStatePaused {
+		utils.Error(w, "Something went wrong.", http.StatusConflict, errors.Wrapf(define.ErrCtrStateInvalid, "container %s is paused, unpause it instead", con.ID()))
+		return
+	}
 	if err := con.Start(r.Context(), len(con.PodID()) > 0); err != nil {
 		utils.InternalServerError(w, err)
 		return
@@ -76,8 +76,8 @@ func StatsContainer(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Write header and content type.
-	w.WriteHeader(http.StatusOK)
 	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
 	if flusher, ok := w.(http.Flusher); ok {
 		flusher.Flush()
 	}
@@ -212,7 +212,13 @@ streamLabel: // A label to flatten the scope
 			logrus.Errorf("Unable to unmarshal previous stats: %q", err)
 		}
 
-		time.Sleep(DefaultStatsPeriod)
+		// Don't wait for the next sample when the client is gone.
+		select {
+		case <-r.Context().Done():
+			logrus.Debugf("Client connection (container stats) cancelled")
+			return
+		case <-time.After(DefaultStatsPeriod):
+		}
 		goto streamLabel
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
 	"github.com/containers/podman/v3/pkg/api/handlers"
 	"github.com/containers/podman/v3/pkg/api/handlers/utils"
 	"github.com/gorilla/schema"
@@ -39,6 +40,10 @@ func TopContainer(w http.ResponseWriter, r *http.Request) {
 
 	output, err := c.Top([]string{query.PsArgs})
 	if err != nil {
+		if errors.Cause(err) == define.ErrCtrStateInvalid {
+			utils.Error(w, "Something went wrong.", http.StatusConflict, err)
+			return
+		}
 		utils.InternalServerError(w, err)
 		return
 	}
@@ -0,0 +1,140 @@
+package compat
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/parsers"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+)
+
+// updateToLinuxResources converts the limits of a docker update, zero meaning
+// unchanged, into the resources to change.
+func updateToLinuxResources(update *container.UpdateConfig) (*spec.LinuxResources, error) {
+	resources := &spec.LinuxResources{}
+	cpu := &spec.LinuxCPU{}
+	switch {
+	case update.CPUShares < 0:
+		return nil, errors.Errorf("invalid CpuShares %d", update.CPUShares)
+	case update.CPUShares > 0:
+		shares := uint64(update.CPUShares)
+		cpu.Shares = &shares
+	}
+	switch {
+	case update.CPUPeriod < 0:
+		return nil, errors.Errorf("invalid CpuPeriod %d", update.CPUPeriod)
+	case update.CPUPeriod > 0:
+		period := uint64(update.CPUPeriod)
+		cpu.Period = &period
+	}
+	switch {
+	case update.CPUQuota < -1:
+		return nil, errors.Errorf("invalid CpuQuota %d", update.CPUQuota)
+	case update.CPUQuota != 0:
+		quota := update.CPUQuota
+		cpu.Quota = &quota
+	}
+	if update.NanoCPUs != 0 {
+		if update.NanoCPUs < 0 || update.CPUPeriod != 0 || update.CPUQuota != 0 {
+			return nil, errors.Errorf("invalid NanoCpus %d, it cannot be set with CpuPeriod or CpuQuota", update.NanoCPUs)
+		}
+		period := uint64(100000)
+		quota := update.NanoCPUs * int64(period) / 1e9
+		cpu.Period, cpu.Quota = &period, &quota
+	}
+	if update.CpusetCpus != "" {
+		if _, err := parsers.ParseUintList(update.CpusetCpus); err != nil {
+			return nil, errors.Wrapf(err, "invalid CpusetCpus %q", update.CpusetCpus)
+		}
+		cpu.Cpus = update.CpusetCpus
+	}
+	if update.CpusetMems != "" {
+		if _, err := parsers.ParseUintList(update.CpusetMems); err != nil {
+			return nil, errors.Wrapf(err, "invalid CpusetMems %q", update.CpusetMems)
+		}
+		cpu.Mems = update.CpusetMems
+	}
+	if *cpu != (spec.LinuxCPU{}) {
+		resources.CPU = cpu
+	}
+
+	memory := &spec.LinuxMemory{}
+	switch {
+	case update.Memory < 0:
+		return nil, errors.Errorf("invalid Memory %d", update.Memory)
+	case update.Memory > 0:
+		limit := update.Memory
+		memory.Limit = &limit
+	}
+	switch {
+	case update.MemoryReservation < 0:
+		return nil, errors.Errorf("invalid MemoryReservation %d", update.MemoryReservation)
+	case update.MemoryReservation > 0:
+		reservation := update.MemoryReservation
+		memory.Reservation = &reservation
+	}
+	switch {
+	case update.MemorySwap < -1:
+		return nil, errors.Errorf("invalid MemorySwap %d", update.MemorySwap)
+	case update.MemorySwap > 0 && update.Memory > 0 && update.MemorySwap < update.Memory:
+		return nil, errors.Errorf("MemorySwap %d must not be below Memory %d", update.MemorySwap, update.Memory)
+	case update.MemorySwap != 0:
+		swap := update.MemorySwap
+		memory.Swap = &swap
+	}
+	if memory.Limit != nil || memory.Reservation != nil || memory.Swap != nil {
+		resources.Memory = memory
+	}
+
+	if update.BlkioWeight != 0 {
+		if update.BlkioWeight < 10 || update.BlkioWeight > 1000 {
+			return nil, errors.Errorf("invalid BlkioWeight %d, it must be between 10 and 1000", update.BlkioWeight)
+		}
+		weight := update.BlkioWeight
+		resources.BlockIO = &spec.LinuxBlockIO{Weight: &weight}
+	}
+	return resources, nil
+}
+
+func UpdateContainer(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+
+	// /{version}/containers/(name)/update
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+
+	update := container.UpdateConfig{}
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, errors.Wrap(err, "Decode()"))
+		return
+	}
+	resources, err := updateToLinuxResources(&update)
+	if err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, err)
+		return
+	}
+	if update.RestartPolicy.MaximumRetryCount < 0 {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Errorf("invalid MaximumRetryCount %d", update.RestartPolicy.MaximumRetryCount))
+		return
+	}
+
+	if err := runtime.UpdateContainerResources(r.Context(), ctr, resources, update.RestartPolicy.Name, uint(update.RestartPolicy.MaximumRetryCount)); err != nil {
+		if errors.Cause(err) == define.ErrInvalidArg {
+			utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, container.ContainerUpdateOKBody{Warnings: []string{}})
+}
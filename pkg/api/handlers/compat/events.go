@@ -125,7 +125,7 @@ func GetEvents(w http.ResponseWriter, r *http.Request) {
 	coder := json.NewEncoder(w)
 	coder.SetEscapeHTML(true)
 
-	for stream := true; stream; stream = query.Stream {
+	for {
 		select {
 		case err := <-errorChannel:
 			if err != nil {
@@ -133,7 +133,12 @@ func GetEvents(w http.ResponseWriter, r *http.Request) {
 				utils.InternalServerError(w, err)
 			}
 			return
-		case evt := <-eventChannel:
+		case evt, ok := <-eventChannel:
+			if !ok {
+				// All events were read, the reader returns next.
+				eventChannel = nil
+				continue
+			}
 			if evt == nil {
 				continue
 			}
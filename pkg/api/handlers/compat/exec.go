@@ -23,7 +23,11 @@ func ExecCreateHandler(w http.ResponseWriter, r *http.Request) {
 
 	input := new(handlers.ExecCreateConfig)
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		utils.InternalServerError(w, errors.Wrapf(err, "error decoding request body as JSON"))
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, errors.Wrapf(err, "error decoding request body as JSON"))
+		return
+	}
+	if len(input.Cmd) == 0 {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, errors.New("no exec command specified"))
 		return
 	}
 
@@ -78,7 +82,8 @@ func ExecCreateHandler(w http.ResponseWriter, r *http.Request) {
 	sessID, err := ctr.ExecCreate(libpodConfig)
 	if err != nil {
 		if errors.Cause(err) == define.ErrCtrStateInvalid {
-			// Check if the container is paused. If so, return a 409
+			// Check if the container is paused or not running. If so,
+			// return a 409
 			state, err := ctr.State()
 			if err == nil {
 				// Ignore the error != nil case. We're already
@@ -87,6 +92,10 @@ func ExecCreateHandler(w http.ResponseWriter, r *http.Request) {
 					utils.Error(w, "Container is paused", http.StatusConflict, errors.Errorf("cannot create exec session as container %s is paused", ctr.ID()))
 					return
 				}
+				if state != define.ContainerStateRunning {
+					utils.Error(w, "Container is not running", http.StatusConflict, errors.Errorf("cannot create exec session as container %s is not running", ctr.ID()))
+					return
+				}
 			}
 		}
 		utils.InternalServerError(w, err)
@@ -112,6 +121,10 @@ func ExecInspectHandler(w http.ResponseWriter, r *http.Request) {
 
 	logrus.Debugf("Inspecting exec session %s of container %s", sessionID, sessionCtr.ID())
 
+	if err := sessionCtr.ExecSyncStatus(sessionID); err != nil {
+		logrus.Errorf("Error updating status of container %s exec session %s: %v", sessionCtr.ID(), sessionID, err)
+	}
+
 	session, err := sessionCtr.ExecSession(sessionID)
 	if err != nil {
 		utils.InternalServerError(w, errors.Wrapf(err, "error retrieving exec session %s from container %s", sessionID, sessionCtr.ID()))
@@ -166,7 +179,7 @@ func ExecStartHandler(w http.ResponseWriter, r *http.Request) {
 		// Instead, we perform a detached start, and return 200 if
 		// successful.
 		if err := sessionCtr.ExecStart(sessionID); err != nil {
-			utils.InternalServerError(w, err)
+			execStartError(w, err)
 			return
 		}
 		// This is a 200 despite having no content
@@ -192,8 +205,19 @@ func ExecStartHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	} else {
 		// If the Hijack failed we are going to assume we can still inform client of failure
-		utils.InternalServerError(w, err)
+		execStartError(w, err)
 		logErr(err)
 	}
 	logrus.Debugf("Attach for container %s exec session %s completed successfully", sessionCtr.ID(), sessionID)
 }
+
+// execStartError reports an error starting an exec session.  Sessions
+// started already, or whose container stopped meanwhile, are a conflict.
+func execStartError(w http.ResponseWriter, err error) {
+	switch errors.Cause(err) {
+	case define.ErrExecSessionStateInvalid, define.ErrCtrStateInvalid:
+		utils.Error(w, http.StatusText(http.StatusConflict), http.StatusConflict, err)
+	default:
+		utils.InternalServerError(w, err)
+	}
+}
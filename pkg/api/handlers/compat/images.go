@@ -1,6 +1,7 @@
 package compat
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -30,7 +31,8 @@ import (
 // mergeNameAndTagOrDigest creates an image reference as string from the
 // provided image name and tagOrDigest which can be a tag, a digest or empty.
 func mergeNameAndTagOrDigest(name, tagOrDigest string) string {
-	if len(tagOrDigest) == 0 {
+	if len(tagOrDigest) == 0 || strings.Contains(name, "@") {
+		// A name with digest is complete.
 		return name
 	}
 
@@ -44,36 +46,58 @@ func mergeNameAndTagOrDigest(name, tagOrDigest string) string {
 
 func ExportImage(w http.ResponseWriter, r *http.Request) {
 	// 200 ok
+	// 404 no such image
 	// 500 server
 	runtime := r.Context().Value("runtime").(*libpod.Runtime)
 
-	name := utils.GetName(r)
-	newImage, err := runtime.ImageRuntime().NewFromLocal(name)
-	if err != nil {
-		utils.ImageNotFound(w, name, errors.Wrapf(err, "failed to find image %s", name))
-		return
+	saveImages(w, r, runtime, []string{utils.GetName(r)})
+}
+
+// saveImages streams a docker-archive of the images to the client while it
+// is written.  Unknown images are answered with 404.
+func saveImages(w http.ResponseWriter, r *http.Request, runtime *libpod.Runtime, names []string) {
+	for _, name := range names {
+		if _, err := runtime.ImageRuntime().NewFromLocal(name); err != nil {
+			utils.ImageNotFound(w, name, errors.Wrapf(err, "failed to find image %s", name))
+			return
+		}
 	}
-	tmpfile, err := ioutil.TempFile("", "api.tar")
+
+	pr, pw, err := os.Pipe()
 	if err != nil {
-		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, errors.Wrap(err, "unable to create tempfile"))
-		return
-	}
-	defer os.Remove(tmpfile.Name())
-	if err := tmpfile.Close(); err != nil {
-		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, errors.Wrap(err, "unable to close tempfile"))
+		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, errors.Wrap(err, "unable to create pipe"))
 		return
 	}
-	if err := newImage.Save(r.Context(), name, "docker-archive", tmpfile.Name(), []string{}, false, false, true); err != nil {
+	defer pr.Close()
+	saveErr := make(chan error, 1)
+	go func() {
+		defer pw.Close()
+		// c/image writes archives to paths only, the pipe is opened again
+		// through its path.
+		path := fmt.Sprintf("/proc/self/fd/%d", pw.Fd())
+		saveErr <- runtime.ImageRuntime().SaveImages(r.Context(), names, image2.DockerArchive, path, true, true)
+	}()
+
+	// Failures before the first byte of the archive are still reported
+	// to the client.
+	rdr := bufio.NewReader(pr)
+	if _, err := rdr.Peek(1); err != nil {
+		if err = <-saveErr; err == nil {
+			err = errors.New("empty archive")
+		}
 		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, errors.Wrap(err, "failed to save image"))
 		return
 	}
-	rdr, err := os.Open(tmpfile.Name())
-	if err != nil {
-		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, errors.Wrap(err, "failed to read the exported tarfile"))
-		return
+	w.Header().Set("Content-Type", "application/x-tar")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, rdr); err != nil {
+		logrus.Warnf("Failed to stream the archive of %s: %v", strings.Join(names, ", "), err)
+	}
+	// Let the save fail if the client has gone away meanwhile.
+	pr.Close()
+	if err := <-saveErr; err != nil {
+		logrus.Errorf("Failed to save %s: %v", strings.Join(names, ", "), err)
 	}
-	defer rdr.Close()
-	utils.WriteResponse(w, http.StatusOK, rdr)
 }
 
 func CommitContainer(w http.ResponseWriter, r *http.Request) {
@@ -217,6 +241,7 @@ func CreateImageFromImage(w http.ResponseWriter, r *http.Request) {
 	query := struct {
 		FromImage string `schema:"fromImage"`
 		Tag       string `schema:"tag"`
+		Platform  string `schema:"platform"`
 	}{
 		// This is where you can override the golang default value for one of fields
 	}
@@ -239,12 +264,35 @@ func CreateImageFromImage(w http.ResponseWriter, r *http.Request) {
 	if sys := runtime.SystemContext(); sys != nil {
 		registryOpts.DockerCertPath = sys.DockerCertPath
 	}
+	if query.Platform != "" {
+		// os[/arch[/variant]]
+		platform := strings.Split(query.Platform, "/")
+		for _, p := range platform {
+			if p == "" || len(platform) > 3 {
+				utils.BadRequest(w, "platform", query.Platform, errors.New("platform must be os[/arch[/variant]]"))
+				return
+			}
+		}
+		registryOpts.OSChoice = platform[0]
+		if len(platform) > 1 {
+			registryOpts.ArchitectureChoice = platform[1]
+		}
+		if len(platform) > 2 {
+			registryOpts.VariantChoice = platform[2]
+		}
+	}
 
 	stderr := channel.NewWriter(make(chan []byte))
 	defer stderr.Close()
 
 	progress := make(chan types.ProgressProperties)
 
+	// An image pulled again is up to date if its ID did not change.
+	var oldImg string
+	if local, err := runtime.ImageRuntime().NewFromLocal(fromImage); err == nil {
+		oldImg = local.ID()
+	}
+
 	var img string
 	runCtx, cancel := context.WithCancel(context.Background())
 	go func() {
@@ -274,8 +322,8 @@ func CreateImageFromImage(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	w.Header().Add("Content-Type", "application/json")
 	flush()
 
 	enc := json.NewEncoder(w)
@@ -328,6 +376,15 @@ loop: // break out of for/select infinite loop
 				if err := enc.Encode(report); err != nil {
 					logrus.Warnf("Failed to json encode error %q", err.Error())
 				}
+				// Docker clients show the last status as summary.
+				report.Status = "Status: Downloaded newer image for " + fromImage
+				if img == oldImg {
+					report.Status = "Status: Image is up to date for " + fromImage
+				}
+				report.Id = ""
+				if err := enc.Encode(report); err != nil {
+					logrus.Warnf("Failed to json encode error %q", err.Error())
+				}
 				flush()
 			}
 			break loop // break out of for/select infinite loop
@@ -353,7 +410,7 @@ func GetImage(w http.ResponseWriter, r *http.Request) {
 	}
 	inspect, err := handlers.ImageDataToImageInspect(r.Context(), newImage)
 	if err != nil {
-		utils.Error(w, "Server error", http.StatusInternalServerError, errors.Wrapf(err, "failed to convert ImageData to ImageInspect '%s'", inspect.ID))
+		utils.Error(w, "Server error", http.StatusInternalServerError, errors.Wrapf(err, "failed to convert ImageData to ImageInspect '%s'", name))
 		return
 	}
 	utils.WriteResponse(w, http.StatusOK, inspect)
@@ -372,20 +429,29 @@ func GetImages(w http.ResponseWriter, r *http.Request) {
 			utils.Error(w, "Something went wrong.", http.StatusInternalServerError, errors.Wrap(err, "Failed transform image summaries"))
 			return
 		}
+		// Docker prefixes IDs with the digest algorithm and lists
+		// untagged images as <none>.
+		is.ID = "sha256:" + is.ID
+		if is.ParentId != "" {
+			is.ParentId = "sha256:" + is.ParentId
+		}
+		if len(is.RepoTags) == 0 {
+			is.RepoTags = []string{"<none>:<none>"}
+			if len(is.RepoDigests) == 0 {
+				is.RepoDigests = []string{"<none>@<none>"}
+			}
+		}
 		summaries[j] = is
 	}
 	utils.WriteResponse(w, http.StatusOK, summaries)
 }
 
 func LoadImages(w http.ResponseWriter, r *http.Request) {
-	// TODO this is basically wrong
 	decoder := r.Context().Value("decoder").(*schema.Decoder)
 	runtime := r.Context().Value("runtime").(*libpod.Runtime)
 
 	query := struct {
-		Changes map[string]string `json:"changes"`
-		Message string            `json:"message"`
-		Quiet   bool              `json:"quiet"`
+		Quiet bool `schema:"quiet"`
 	}{
 		// This is where you can override the golang default value for one of fields
 	}
@@ -395,10 +461,6 @@ func LoadImages(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var (
-		err    error
-		writer io.Writer
-	)
 	f, err := ioutil.TempFile("", "api_load.tar")
 	if err != nil {
 		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, errors.Wrap(err, "failed to create tempfile"))
@@ -414,26 +476,94 @@ func LoadImages(w http.ResponseWriter, r *http.Request) {
 		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, errors.Wrap(err, "failed to write temporary file"))
 		return
 	}
-	id, err := runtime.LoadImage(r.Context(), f.Name(), writer, "")
-	if err != nil {
-		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, errors.Wrap(err, "failed to load image"))
-		return
+
+	stdout := channel.NewWriter(make(chan []byte, 1))
+	defer stdout.Close()
+
+	// Progress is not reported at all when quiet.
+	var writer io.Writer
+	if !query.Quiet {
+		writer = stdout
+	}
+
+	var (
+		names   string
+		loadErr error
+	)
+	runCtx, cancel := context.WithCancel(context.Background())
+	go func() {
+		defer cancel()
+		names, loadErr = runtime.LoadImage(runCtx, f.Name(), writer, "")
+	}()
+
+	flush := func() {
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+	}
+
+	// The status is sent with the first progress message, archives which
+	// cannot be loaded at all are answered with 400.
+	var started bool
+	start := func() {
+		if !started {
+			started = true
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(true)
+
+loop: // break out of for/select infinite loop
+	for {
+		var report struct {
+			Stream string `json:"stream,omitempty"`
+			Error  string `json:"error,omitempty"`
+		}
+
+		select {
+		case e := <-stdout.Chan():
+			start()
+			report.Stream = string(e)
+			if err := enc.Encode(report); err != nil {
+				logrus.Warnf("Failed to json encode error %q", err.Error())
+			}
+			flush()
+		case <-runCtx.Done():
+			if loadErr != nil {
+				if !started {
+					utils.Error(w, "Something went wrong.", http.StatusBadRequest, errors.Wrap(loadErr, "failed to load image"))
+					return
+				}
+				report.Error = loadErr.Error()
+			} else {
+				start()
+				report.Stream = fmt.Sprintf("Loaded image: %s\n", names)
+			}
+			if err := enc.Encode(report); err != nil {
+				logrus.Warnf("Failed to json encode error %q", err.Error())
+			}
+			flush()
+			break loop // break out of for/select infinite loop
+		case <-r.Context().Done():
+			// Client has closed connection
+			break loop // break out of for/select infinite loop
+		}
 	}
-	utils.WriteResponse(w, http.StatusOK, struct {
-		Stream string `json:"stream"`
-	}{
-		Stream: fmt.Sprintf("Loaded image: %s\n", id),
-	})
 }
 
 func ExportImages(w http.ResponseWriter, r *http.Request) {
 	// 200 OK
+	// 400 no names
+	// 404 no such image
 	// 500 Error
 	decoder := r.Context().Value("decoder").(*schema.Decoder)
 	runtime := r.Context().Value("runtime").(*libpod.Runtime)
 
 	query := struct {
-		Names string `schema:"names"`
+		Names []string `schema:"names"`
 	}{
 		// This is where you can override the golang default value for one of fields
 	}
@@ -441,27 +571,19 @@ func ExportImages(w http.ResponseWriter, r *http.Request) {
 		utils.Error(w, "Something went wrong.", http.StatusBadRequest, errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
 		return
 	}
-	images := make([]string, 0)
-	images = append(images, strings.Split(query.Names, ",")...)
-	tmpfile, err := ioutil.TempFile("", "api.tar")
-	if err != nil {
-		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, errors.Wrap(err, "unable to create tempfile"))
-		return
-	}
-	defer os.Remove(tmpfile.Name())
-	if err := tmpfile.Close(); err != nil {
-		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, errors.Wrap(err, "unable to close tempfile"))
-		return
-	}
-	if err := runtime.ImageRuntime().SaveImages(r.Context(), images, "docker-archive", tmpfile.Name(), false, true); err != nil {
-		utils.InternalServerError(w, err)
-		return
+	// Docker clients repeat the names parameter, older podman clients
+	// separate the names by commas.
+	images := make([]string, 0, len(query.Names))
+	for _, names := range query.Names {
+		for _, name := range strings.Split(names, ",") {
+			if name != "" {
+				images = append(images, name)
+			}
+		}
 	}
-	rdr, err := os.Open(tmpfile.Name())
-	if err != nil {
-		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, errors.Wrap(err, "failed to read the exported tarfile"))
+	if len(images) == 0 {
+		utils.BadRequest(w, "names", "", errors.New("no images to save"))
 		return
 	}
-	defer rdr.Close()
-	utils.WriteResponse(w, http.StatusOK, rdr)
+	saveImages(w, r, runtime, images)
 }
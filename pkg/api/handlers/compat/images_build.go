@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/containers/buildah"
@@ -42,25 +43,38 @@ func BuildImage(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	contextDirectory, err := extractTarFile(r)
-	if err != nil {
-		utils.InternalServerError(w, err)
-		return
-	}
-
-	defer func() {
-		if logrus.IsLevelEnabled(logrus.DebugLevel) {
-			if v, found := os.LookupEnv("PODMAN_RETAIN_BUILD_ARTIFACT"); found {
-				if keep, _ := strconv.ParseBool(v); keep {
-					return
-				}
-			}
+	// Contexts uploaded before are kept for further builds.
+	var contextDirectory string
+	if handle := r.URL.Query().Get("contexthandle"); handle != "" {
+		dir, release, ok := lookupBuildContext(handle)
+		if !ok {
+			utils.Error(w, "Something went wrong.", http.StatusNotFound, errors.Errorf("no build context with handle %s", handle))
+			return
 		}
-		err := os.RemoveAll(filepath.Dir(contextDirectory))
+		defer release()
+		contextDirectory = dir
+	} else {
+		dir, err := extractTarFile(r)
 		if err != nil {
-			logrus.Warn(errors.Wrapf(err, "failed to remove build scratch directory %q", filepath.Dir(contextDirectory)))
+			utils.InternalServerError(w, err)
+			return
 		}
-	}()
+		contextDirectory = dir
+
+		defer func() {
+			if logrus.IsLevelEnabled(logrus.DebugLevel) {
+				if v, found := os.LookupEnv("PODMAN_RETAIN_BUILD_ARTIFACT"); found {
+					if keep, _ := strconv.ParseBool(v); keep {
+						return
+					}
+				}
+			}
+			err := os.RemoveAll(filepath.Dir(contextDirectory))
+			if err != nil {
+				logrus.Warn(errors.Wrapf(err, "failed to remove build scratch directory %q", filepath.Dir(contextDirectory)))
+			}
+		}()
+	}
 
 	query := struct {
 		AddHosts               string `schema:"extrahosts"`
@@ -98,10 +112,12 @@ func BuildImage(w http.ResponseWriter, r *http.Request) {
 		Quiet                  bool   `schema:"q"`
 		Registry               string `schema:"registry"`
 		Rm                     bool   `schema:"rm"`
+		Secrets                string `schema:"secrets"`
 		//FIXME SecurityOpt in remote API is not handled
 		SecurityOpt string   `schema:"securityopt"`
 		ShmSize     int      `schema:"shmsize"`
 		Squash      bool     `schema:"squash"`
+		SSH         string   `schema:"ssh"`
 		Tag         []string `schema:"t"`
 		Target      string   `schema:"target"`
 		Timestamp   int64    `schema:"timestamp"`
@@ -137,6 +153,42 @@ func BuildImage(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	var secrets = []string{}
+	if _, found := r.URL.Query()["secrets"]; found {
+		if err := json.Unmarshal([]byte(query.Secrets), &secrets); err != nil {
+			utils.BadRequest(w, "secrets", query.Secrets, err)
+			return
+		}
+		for _, secret := range secrets {
+			if err := validateBuildMount(secret, "src", "env"); err != nil {
+				utils.BadRequest(w, "secrets", secret, err)
+				return
+			}
+		}
+	}
+	var sshSources = []string{}
+	if _, found := r.URL.Query()["ssh"]; found {
+		if err := json.Unmarshal([]byte(query.SSH), &sshSources); err != nil {
+			utils.BadRequest(w, "ssh", query.SSH, err)
+			return
+		}
+		for _, ssh := range sshSources {
+			if err := validateBuildSSH(ssh); err != nil {
+				utils.BadRequest(w, "ssh", ssh, err)
+				return
+			}
+		}
+	}
+	// Secret and SSH mounts are consumed by RUN --mount=type=secret|ssh
+	// instructions, which the vendored buildah cannot process yet.
+	// Refuse rather than build without them, as Containerfiles may fall
+	// back to less safe ways of getting at the credentials.
+	if len(secrets) > 0 || len(sshSources) > 0 {
+		utils.Error(w, "build secrets not supported", http.StatusNotImplemented,
+			errors.New("secret and ssh mounts (RUN --mount=type=secret|ssh) are not supported by this version of buildah"))
+		return
+	}
+
 	compression := archive.Compression(query.Compression)
 	// convert label formats
 	var dropCaps = []string{}
@@ -333,12 +385,15 @@ func BuildImage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	runCtx, cancel := context.WithCancel(context.Background())
-	var imageID string
+	var (
+		imageID  string
+		buildErr error
+	)
 	go func() {
 		defer cancel()
-		imageID, _, err = runtime.Build(r.Context(), buildOptions, query.Dockerfile)
-		if err != nil {
-			stderr.Write([]byte(err.Error() + "\n"))
+		imageID, _, buildErr = runtime.Build(r.Context(), buildOptions, query.Dockerfile)
+		if buildErr != nil {
+			stderr.Write([]byte(buildErr.Error() + "\n"))
 		}
 	}()
 
@@ -349,8 +404,8 @@ func BuildImage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Send headers and prime client for stream to come
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	w.Header().Add("Content-Type", "application/json")
 	flush()
 
 	var failed bool
@@ -402,14 +457,38 @@ loop:
 			}
 			flush()
 		case <-runCtx.Done():
-			if !failed {
-				if !utils.IsLibpodRequest(r) {
-					m.Stream = fmt.Sprintf("Successfully built %12.12s\n", imageID)
+			// The error of the build may not have been received yet.
+			if buildErr != nil && !failed {
+				m.Error = buildErr.Error() + "\n"
+				if err := enc.Encode(m); err != nil {
+					logrus.Warnf("Failed to json encode error %v", err)
+				}
+				flush()
+				break loop
+			}
+			if !failed && !utils.IsLibpodRequest(r) {
+				// Docker clients take the ID of the image from the aux
+				// message, and the progress lines following it.
+				aux := struct {
+					Aux struct {
+						ID string `json:"ID"`
+					} `json:"aux"`
+				}{}
+				aux.Aux.ID = "sha256:" + imageID
+				if err := enc.Encode(aux); err != nil {
+					logrus.Warnf("Failed to json encode error %v", err)
+				}
+				progress := []string{fmt.Sprintf("Successfully built %12.12s\n", imageID)}
+				for _, tag := range query.Tag {
+					progress = append(progress, fmt.Sprintf("Successfully tagged %s\n", tag))
+				}
+				for _, line := range progress {
+					m.Stream = line
 					if err := enc.Encode(m); err != nil {
 						logrus.Warnf("Failed to json encode error %v", err)
 					}
-					flush()
 				}
+				flush()
 			}
 			break loop
 		}
@@ -447,3 +526,41 @@ func extractTarFile(r *http.Request) (string, error) {
 	err = archive.Untar(tarBall, buildDir, nil)
 	return buildDir, err
 }
+
+// validateBuildMount validates a BuildKit style secret specification,
+// "id=<id>[,<key>=<value>]", where key must be one of sourceKeys.
+func validateBuildMount(spec string, sourceKeys ...string) error {
+	var id string
+	for _, field := range strings.Split(spec, ",") {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return errors.Errorf("invalid field %q, must be key=value", field)
+		}
+		switch kv[0] {
+		case "id":
+			id = kv[1]
+		case "type":
+		default:
+			if !util.StringInSlice(kv[0], sourceKeys) {
+				return errors.Errorf("invalid key %q, must be one of id, type, %s", kv[0], strings.Join(sourceKeys, ", "))
+			}
+		}
+	}
+	if id == "" {
+		return errors.New("id must be set")
+	}
+	return nil
+}
+
+// validateBuildSSH validates a BuildKit style ssh forwarding specification,
+// "default|<id>[=<socket>|<key>[,<key>]]".
+func validateBuildSSH(spec string) error {
+	kv := strings.SplitN(spec, "=", 2)
+	if kv[0] == "" {
+		return errors.New("id must be set")
+	}
+	if len(kv) == 2 && kv[1] == "" {
+		return errors.Errorf("no socket or keys given for %q", kv[0])
+	}
+	return nil
+}
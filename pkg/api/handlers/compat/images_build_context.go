@@ -0,0 +1,356 @@
+package compat
+
+import (
+	"archive/tar"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/containers/storage/pkg/archive"
+	"github.com/containers/storage/pkg/stringid"
+	securejoin "github.com/cyphar/filepath-securejoin"
+	"github.com/gorilla/schema"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// maxBuildContextSize is the limit of the size of the files in an
+	// uploaded context, clients can only lower it.
+	maxBuildContextSize    = 4 << 30
+	maxBuildContexts       = 16
+	defaultBuildContextTTL = time.Hour
+	maxBuildContextTTL     = 24 * time.Hour
+	// maxBuildContextTrailer is the limit of the data following the end of
+	// the tar, which is padded to a whole number of records.
+	maxBuildContextTrailer = 1 << 20
+)
+
+// buildContext is an uploaded build context, removed when it expires and
+// no build uses it anymore.
+type buildContext struct {
+	// anchorDir holds the extracted context in its build subdirectory.
+	anchorDir string
+	timer     *time.Timer
+	// builds is the number of builds using the context.
+	builds int
+	// expired is set once the context expired, the last build using it
+	// removes it.
+	expired bool
+}
+
+// buildContexts are the uploaded build contexts of the API service, by
+// handle.
+var buildContexts = struct {
+	lock     sync.Mutex
+	byHandle map[string]*buildContext
+}{
+	byHandle: make(map[string]*buildContext),
+}
+
+// lookupBuildContext returns the directory of an uploaded build context for
+// a build, and the function to call once the build is done with it.  The
+// context is kept until then even if it expires meanwhile.
+func lookupBuildContext(handle string) (string, func(), bool) {
+	buildContexts.lock.Lock()
+	defer buildContexts.lock.Unlock()
+	bc, ok := buildContexts.byHandle[handle]
+	if !ok {
+		return "", nil, false
+	}
+	bc.builds++
+	release := func() {
+		buildContexts.lock.Lock()
+		bc.builds--
+		remove := bc.expired && bc.builds == 0
+		buildContexts.lock.Unlock()
+		if remove {
+			bc.remove()
+		}
+	}
+	return filepath.Join(bc.anchorDir, "build"), release, true
+}
+
+// expireBuildContext removes a build context, or leaves it to the last build
+// using it.
+func expireBuildContext(handle string) {
+	buildContexts.lock.Lock()
+	bc, ok := buildContexts.byHandle[handle]
+	delete(buildContexts.byHandle, handle)
+	if ok {
+		bc.expired = true
+	}
+	remove := ok && bc.builds == 0
+	buildContexts.lock.Unlock()
+	if remove {
+		bc.remove()
+	}
+}
+
+func (bc *buildContext) remove() {
+	bc.timer.Stop()
+	if err := os.RemoveAll(bc.anchorDir); err != nil {
+		logrus.Warn(errors.Wrapf(err, "failed to remove build context directory %q", bc.anchorDir))
+	}
+}
+
+// checkBuildContextLinks returns an error if a symbolic link in the build
+// context at root points outside of it.
+func checkBuildContextLinks(root string) error {
+	return filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil || info.Mode()&os.ModeSymlink == 0 {
+			return err
+		}
+		rel, _ := filepath.Rel(root, p)
+		target, err := os.Readlink(p)
+		if err != nil {
+			return err
+		}
+		if filepath.IsAbs(target) {
+			return errors.Errorf("build context entry %q links outside of the context", rel)
+		}
+		resolved, err := filepath.EvalSymlinks(p)
+		if os.IsNotExist(err) {
+			resolved, err = resolveDanglingLink(root, filepath.Dir(p), target)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "error resolving build context entry %q", rel)
+		}
+		if !insideDir(root, resolved) {
+			return errors.Errorf("build context entry %q links outside of the context", rel)
+		}
+		return nil
+	})
+}
+
+// resolveDanglingLink resolves the target of a link in dir which does not
+// exist, as far as it exists.  It stops at the first component outside of
+// root.
+func resolveDanglingLink(root, dir, target string) (string, error) {
+	cur, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", err
+	}
+	for _, name := range strings.Split(target, string(filepath.Separator)) {
+		switch name {
+		case "", ".":
+			continue
+		case "..":
+			cur = filepath.Dir(cur)
+		default:
+			cur = filepath.Join(cur, name)
+			if info, err := os.Lstat(cur); err == nil && info.Mode()&os.ModeSymlink != 0 {
+				if cur, err = filepath.EvalSymlinks(cur); err != nil {
+					return "", err
+				}
+			}
+		}
+		if !insideDir(root, cur) {
+			break
+		}
+	}
+	return cur, nil
+}
+
+func insideDir(dir, p string) bool {
+	return p == dir || strings.HasPrefix(p, dir+string(filepath.Separator))
+}
+
+func buildContextsFull() bool {
+	buildContexts.lock.Lock()
+	defer buildContexts.lock.Unlock()
+	return len(buildContexts.byHandle) >= maxBuildContexts
+}
+
+func buildContextsFullError(w http.ResponseWriter) {
+	utils.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests,
+		errors.Errorf("%d build contexts are stored already, wait for one to expire", maxBuildContexts))
+}
+
+// UploadBuildContext validates a build context and keeps it, so several
+// builds can use it without uploading it again.
+func UploadBuildContext(w http.ResponseWriter, r *http.Request) {
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Dockerfile string `schema:"dockerfile"`
+		MaxSize    int64  `schema:"maxsize"`
+		TTL        string `schema:"ttl"`
+	}{
+		// override any golang type defaults
+		Dockerfile: "Dockerfile",
+		MaxSize:    maxBuildContextSize,
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	if query.MaxSize <= 0 {
+		utils.BadRequest(w, "maxsize", "", errors.Errorf("maxsize must be positive, got %d", query.MaxSize))
+		return
+	}
+	if query.MaxSize > maxBuildContextSize {
+		query.MaxSize = maxBuildContextSize
+	}
+	ttl := defaultBuildContextTTL
+	if query.TTL != "" {
+		var err error
+		if ttl, err = time.ParseDuration(query.TTL); err != nil {
+			utils.BadRequest(w, "ttl", query.TTL, err)
+			return
+		}
+		if ttl <= 0 || ttl > maxBuildContextTTL {
+			utils.BadRequest(w, "ttl", query.TTL, errors.Errorf("ttl must be positive and at most %s", maxBuildContextTTL))
+			return
+		}
+	}
+	dockerfile := path.Clean(query.Dockerfile)
+	if path.IsAbs(dockerfile) || dockerfile == ".." || strings.HasPrefix(dockerfile, "../") {
+		utils.BadRequest(w, "dockerfile", query.Dockerfile, errors.New("dockerfile must be relative to the context"))
+		return
+	}
+	// Refuse early, the count is checked again before the context is kept.
+	if buildContextsFull() {
+		buildContextsFullError(w)
+		return
+	}
+
+	anchorDir, err := ioutil.TempDir("", "libpod_builder")
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	keep := false
+	defer func() {
+		if !keep {
+			if err := os.RemoveAll(anchorDir); err != nil {
+				logrus.Warn(errors.Wrapf(err, "failed to remove build context directory %q", anchorDir))
+			}
+		}
+	}()
+
+	tarPath := filepath.Join(anchorDir, "tarBall")
+	tarBall, err := os.OpenFile(tarPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	defer tarBall.Close()
+
+	body, err := archive.DecompressStream(r.Body)
+	if err != nil {
+		utils.BadRequest(w, "context", "", errors.Wrap(err, "invalid build context"))
+		return
+	}
+	defer body.Close()
+
+	// Validate the entries while storing the context, so oversized
+	// contexts are refused before they are uploaded completely.
+	report := entities.BuildContextReport{Dockerfile: dockerfile}
+	reader := tar.NewReader(io.TeeReader(body, tarBall))
+	for {
+		hdr, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			utils.BadRequest(w, "context", "", errors.Wrap(err, "invalid build context tar"))
+			return
+		}
+		name := path.Clean(hdr.Name)
+		if path.IsAbs(hdr.Name) || name == ".." || strings.HasPrefix(name, "../") {
+			utils.BadRequest(w, "context", hdr.Name, errors.Errorf("build context entry %q is outside of the context", hdr.Name))
+			return
+		}
+		if hdr.Typeflag == tar.TypeLink && (path.IsAbs(hdr.Linkname) || strings.HasPrefix(path.Clean(hdr.Linkname), "../")) {
+			utils.BadRequest(w, "context", hdr.Name, errors.Errorf("build context entry %q links outside of the context", hdr.Name))
+			return
+		}
+		if hdr.Typeflag == tar.TypeReg || hdr.Typeflag == tar.TypeRegA {
+			report.Files++
+			report.Size += hdr.Size
+		}
+		if report.Size > query.MaxSize {
+			utils.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge,
+				errors.Errorf("build context exceeds the maximum size of %d bytes", query.MaxSize))
+			return
+		}
+		// Read the entry, so it is stored.
+		if _, err := io.Copy(ioutil.Discard, reader); err != nil {
+			utils.BadRequest(w, "context", hdr.Name, errors.Wrap(err, "invalid build context tar"))
+			return
+		}
+	}
+	// The padding after the end of the tar is not needed for extracting it.
+	n, err := io.CopyN(ioutil.Discard, body, maxBuildContextTrailer+1)
+	if err != nil && err != io.EOF {
+		utils.BadRequest(w, "context", "", errors.Wrap(err, "invalid build context"))
+		return
+	}
+	if n > maxBuildContextTrailer {
+		utils.BadRequest(w, "context", "", errors.New("build context has trailing data after the end of the tar"))
+		return
+	}
+
+	buildDir := filepath.Join(anchorDir, "build")
+	if err := os.Mkdir(buildDir, 0700); err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if _, err := tarBall.Seek(0, 0); err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if err := archive.Untar(tarBall, buildDir, nil); err != nil {
+		utils.InternalServerError(w, errors.Wrap(err, "failed to extract build context"))
+		return
+	}
+	// The real path, links are resolved against it.
+	if buildDir, err = filepath.EvalSymlinks(buildDir); err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if err := checkBuildContextLinks(buildDir); err != nil {
+		utils.BadRequest(w, "context", "", err)
+		return
+	}
+	tarBall.Close()
+	if err := os.Remove(tarPath); err != nil {
+		logrus.Warn(errors.Wrapf(err, "failed to remove build context tar %q", tarPath))
+	}
+
+	dockerfilePath, err := securejoin.SecureJoin(buildDir, dockerfile)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if info, err := os.Stat(dockerfilePath); err != nil || !info.Mode().IsRegular() {
+		utils.BadRequest(w, "dockerfile", query.Dockerfile, errors.Errorf("no Dockerfile %s in the build context", dockerfile))
+		return
+	}
+
+	report.Handle = stringid.GenerateRandomID()
+	report.Expires = time.Now().Add(ttl)
+	handle := report.Handle
+	buildContexts.lock.Lock()
+	if len(buildContexts.byHandle) >= maxBuildContexts {
+		buildContexts.lock.Unlock()
+		buildContextsFullError(w)
+		return
+	}
+	buildContexts.byHandle[handle] = &buildContext{
+		anchorDir: anchorDir,
+		timer:     time.AfterFunc(ttl, func() { expireBuildContext(handle) }),
+	}
+	buildContexts.lock.Unlock()
+	keep = true
+	utils.WriteResponse(w, http.StatusCreated, report)
+}
@@ -27,12 +27,22 @@ func HistoryImage(w http.ResponseWriter, r *http.Request) {
 	for _, h := range history {
 		l := handlers.HistoryResponse{
 			ID:        h.ID,
-			Created:   h.Created.Unix(),
 			CreatedBy: h.CreatedBy,
 			Tags:      h.Tags,
 			Size:      h.Size,
 			Comment:   h.Comment,
 		}
+		if h.Created != nil {
+			l.Created = h.Created.Unix()
+		}
+		// Docker prefixes the IDs of images in the history and lists
+		// their tags.
+		if !utils.IsLibpodRequest(r) && h.ID != "<missing>" {
+			l.ID = "sha256:" + h.ID
+			if img, err := runtime.ImageRuntime().NewFromLocal(h.ID); err == nil {
+				l.Tags = img.Names()
+			}
+		}
 		allHistory = append(allHistory, l)
 	}
 	utils.WriteResponse(w, http.StatusOK, allHistory)
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/containers/podman/v3/libpod"
 	"github.com/containers/podman/v3/pkg/api/handlers"
@@ -32,7 +34,24 @@ func PruneImages(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Only dangling images are pruned unless dangling=false asks for all
+	// unused images, libpod has no filter for it.
+	for _, val := range query.Filters["dangling"] {
+		dangling, err := strconv.ParseBool(val)
+		if err != nil {
+			utils.BadRequest(w, "filters", fmt.Sprintf("dangling=%s", val), err)
+			return
+		}
+		query.All = !dangling
+	}
+	delete(query.Filters, "dangling")
 	for k, v := range query.Filters {
+		switch k {
+		case "label", "until":
+		default:
+			utils.BadRequest(w, "filters", k, errors.Errorf("%q is not a valid filter", k))
+			return
+		}
 		for _, val := range v {
 			filters = append(filters, fmt.Sprintf("%s=%s", k, val))
 		}
@@ -43,7 +62,7 @@ func PruneImages(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	idr := make([]types.ImageDeleteResponseItem, len(imagePruneReports))
+	idr := make([]types.ImageDeleteResponseItem, 0, len(imagePruneReports))
 	var reclaimedSpace uint64
 	var errorMsg bytes.Buffer
 	for _, p := range imagePruneReports {
@@ -55,9 +74,16 @@ func PruneImages(w http.ResponseWriter, r *http.Request) {
 			continue
 		}
 
-		idr = append(idr, types.ImageDeleteResponseItem{
-			Deleted: p.Id,
-		})
+		// Tagged images are reported by their first tag.
+		if strings.Contains(p.Id, ":") {
+			idr = append(idr, types.ImageDeleteResponseItem{
+				Untagged: p.Id,
+			})
+		} else {
+			idr = append(idr, types.ImageDeleteResponseItem{
+				Deleted: "sha256:" + p.Id,
+			})
+		}
 		reclaimedSpace = reclaimedSpace + p.Size
 	}
 	if errorMsg.Len() > 0 {
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/containers/image/v5/docker"
 	"github.com/containers/image/v5/types"
 	"github.com/containers/podman/v3/libpod"
 	"github.com/containers/podman/v3/pkg/api/handlers/utils"
@@ -16,6 +17,7 @@ import (
 	"github.com/containers/podman/v3/pkg/domain/entities"
 	"github.com/containers/podman/v3/pkg/domain/infra/abi"
 	"github.com/containers/storage"
+	"github.com/docker/distribution/registry/api/errcode"
 	"github.com/docker/docker/pkg/jsonmessage"
 	"github.com/gorilla/schema"
 	"github.com/pkg/errors"
@@ -58,7 +60,8 @@ func PushImage(w http.ResponseWriter, r *http.Request) {
 	// Note that Docker's docs state "Image name or ID" to be in the path
 	// parameter but it really must be a name as Docker does not allow for
 	// pushing an image by ID.
-	imageName := strings.TrimSuffix(utils.GetName(r), "/push") // GetName returns the entire path
+	repository := strings.TrimSuffix(utils.GetName(r), "/push") // GetName returns the entire path
+	imageName := repository
 	if query.Tag != "" {
 		imageName += ":" + query.Tag
 	}
@@ -67,6 +70,10 @@ func PushImage(w http.ResponseWriter, r *http.Request) {
 			errors.Wrapf(err, "image source %q is not a containers-storage-transport reference", imageName))
 		return
 	}
+	if _, err := runtime.ImageRuntime().NewFromLocal(imageName); err != nil {
+		utils.ImageNotFound(w, imageName, errors.Wrapf(err, "An image does not exist locally with the tag: %s", imageName))
+		return
+	}
 
 	authconf, authfile, key, err := auth.GetCredentials(r)
 	if err != nil {
@@ -104,6 +111,10 @@ func PushImage(w http.ResponseWriter, r *http.Request) {
 	errorWriter := channel.NewWriter(make(chan []byte))
 	defer errorWriter.Close()
 
+	// Authentication and authorization failures are reported with their
+	// status, so clients can tell them apart.
+	var errorCode int
+
 	statusWriter := channel.NewWriter(make(chan []byte))
 	defer statusWriter.Close()
 
@@ -113,13 +124,14 @@ func PushImage(w http.ResponseWriter, r *http.Request) {
 	go func() {
 		defer cancel()
 
-		statusWriter.Write([]byte(fmt.Sprintf("The push refers to repository [%s]", imageName)))
+		statusWriter.Write([]byte(fmt.Sprintf("The push refers to repository [%s]", repository)))
 
 		err := imageEngine.Push(runCtx, imageName, destination, options)
 		if err != nil {
-			if errors.Cause(err) != storage.ErrImageUnknown {
+			if errors.Cause(err) == storage.ErrImageUnknown {
 				errorWriter.Write([]byte("An image does not exist locally with the tag: " + imageName))
 			} else {
+				errorCode = pushErrorCode(err)
 				errorWriter.Write([]byte(err.Error()))
 			}
 		}
@@ -131,8 +143,8 @@ func PushImage(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	w.Header().Add("Content-Type", "application/json")
 	flush()
 
 	enc := json.NewEncoder(w)
@@ -172,6 +184,7 @@ loop: // break out of for/select infinite loop
 		case e := <-errorWriter.Chan():
 			failed = true
 			report.Error = &jsonmessage.JSONError{
+				Code:    errorCode,
 				Message: string(e),
 			}
 			report.ErrorMessage = string(e)
@@ -191,6 +204,17 @@ loop: // break out of for/select infinite loop
 					if err := enc.Encode(report); err != nil {
 						logrus.Warnf("Failed to json encode error %q", err.Error())
 					}
+					// Docker clients take the digest from the aux message.
+					aux, err := json.Marshal(struct {
+						Tag    string
+						Digest string
+					}{tag, string(digestBytes)})
+					if err == nil {
+						auxMsg := json.RawMessage(aux)
+						if err := enc.Encode(jsonmessage.JSONMessage{Aux: &auxMsg}); err != nil {
+							logrus.Warnf("Failed to json encode error %q", err.Error())
+						}
+					}
 					flush()
 				}
 			}
@@ -201,3 +225,24 @@ loop: // break out of for/select infinite loop
 		}
 	}
 }
+
+// pushErrorCode returns the HTTP status of the registry refusing a push for
+// lack of credentials or permissions, and 0 for any other error.
+func pushErrorCode(err error) int {
+	switch e := errors.Cause(err).(type) {
+	case docker.ErrUnauthorizedForCredentials:
+		return http.StatusUnauthorized
+	case errcode.Errors:
+		if len(e) > 0 {
+			return pushErrorCode(e[0])
+		}
+	case errcode.Error:
+		switch e.Code {
+		case errcode.ErrorCodeUnauthorized:
+			return http.StatusUnauthorized
+		case errcode.ErrorCodeDenied:
+			return http.StatusForbidden
+		}
+	}
+	return 0
+}
@@ -4,7 +4,10 @@ import (
 	"net/http"
 
 	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/libpod/image"
 	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/storage"
 	"github.com/gorilla/schema"
 	"github.com/pkg/errors"
 )
@@ -24,11 +27,6 @@ func RemoveImage(w http.ResponseWriter, r *http.Request) {
 		utils.Error(w, "Something went wrong.", http.StatusBadRequest, errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
 		return
 	}
-	if _, found := r.URL.Query()["noprune"]; found {
-		if query.NoPrune {
-			utils.UnSupportedParameter("noprune")
-		}
-	}
 	name := utils.GetName(r)
 	newImage, err := runtime.ImageRuntime().NewFromLocal(name)
 	if err != nil {
@@ -36,22 +34,82 @@ func RemoveImage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	results, err := runtime.RemoveImage(r.Context(), newImage, query.Force)
+	var results *image.ImageDeleteResponse
+	if query.Force {
+		results, err = untagImageInUse(newImage)
+		if err != nil {
+			switch errors.Cause(err) {
+			case define.ErrImageInUse:
+				utils.Error(w, "Something went wrong.", http.StatusConflict, err)
+			default:
+				utils.Error(w, "Something went wrong.", http.StatusInternalServerError, err)
+			}
+			return
+		}
+	}
+	if results == nil {
+		remove := runtime.RemoveImage
+		if query.NoPrune {
+			remove = runtime.RemoveImageNoPrune
+		}
+		results, err = remove(r.Context(), newImage, query.Force)
+	}
 	if err != nil {
-		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, err)
+		switch errors.Cause(err) {
+		case define.ErrImageInUse, storage.ErrImageUsedByContainer:
+			utils.Error(w, "Something went wrong.", http.StatusConflict, err)
+		default:
+			utils.Error(w, "Something went wrong.", http.StatusInternalServerError, err)
+		}
 		return
 	}
 
+	// Docker lists the removed tags first, and no deletion when only a
+	// tag was removed.
 	response := make([]map[string]string, 0, len(results.Untagged)+1)
-	deleted := make(map[string]string, 1)
-	deleted["Deleted"] = results.Deleted
-	response = append(response, deleted)
-
 	for _, u := range results.Untagged {
 		untagged := make(map[string]string, 1)
 		untagged["Untagged"] = u
 		response = append(response, untagged)
 	}
+	if results.Deleted != "" {
+		deleted := make(map[string]string, 1)
+		deleted["Deleted"] = "sha256:" + results.Deleted
+		response = append(response, deleted)
+	}
 
 	utils.WriteResponse(w, http.StatusOK, response)
 }
+
+// untagImageInUse removes the tags of an image used by containers instead of
+// removing the containers with the image, as Docker does when forced: the
+// tag given or, for an image given by ID, all of them.  It returns nil if
+// no container uses the image.
+func untagImageInUse(img *image.Image) (*image.ImageDeleteResponse, error) {
+	ctrs, err := img.Containers()
+	if err != nil {
+		return nil, err
+	}
+	if len(ctrs) == 0 {
+		return nil, nil
+	}
+	tags := img.Names()
+	if !img.InputIsID() {
+		tag, err := img.MatchRepoTag(img.InputName)
+		if err != nil {
+			return nil, err
+		}
+		tags = []string{tag}
+	}
+	if len(tags) == 0 {
+		return nil, errors.Wrapf(define.ErrImageInUse, "unable to delete %s, it is being used by %d containers", img.ID(), len(ctrs))
+	}
+	response := image.ImageDeleteResponse{}
+	for _, tag := range tags {
+		if err := img.UntagImage(tag); err != nil {
+			return nil, err
+		}
+		response.Untagged = append(response.Untagged, tag)
+	}
+	return &response, nil
+}
@@ -3,14 +3,16 @@ package compat
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/containers/image/v5/types"
 	"github.com/containers/podman/v3/libpod"
-	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/libpod/image"
 	"github.com/containers/podman/v3/pkg/api/handlers/utils"
 	"github.com/containers/podman/v3/pkg/auth"
 	"github.com/containers/podman/v3/pkg/domain/entities"
 	"github.com/containers/podman/v3/pkg/domain/infra/abi"
+	"github.com/docker/docker/api/types/registry"
 	"github.com/gorilla/schema"
 	"github.com/pkg/errors"
 )
@@ -19,12 +21,12 @@ func SearchImages(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value("runtime").(*libpod.Runtime)
 	decoder := r.Context().Value("decoder").(*schema.Decoder)
 	query := struct {
-		Term      string              `json:"term"`
-		Limit     int                 `json:"limit"`
-		NoTrunc   bool                `json:"noTrunc"`
-		Filters   map[string][]string `json:"filters"`
-		TLSVerify bool                `json:"tlsVerify"`
-		ListTags  bool                `json:"listTags"`
+		Term      string              `schema:"term"`
+		Limit     int                 `schema:"limit"`
+		NoTrunc   bool                `schema:"noTrunc"`
+		Filters   map[string][]string `schema:"filters"`
+		TLSVerify bool                `schema:"tlsVerify"`
+		ListTags  bool                `schema:"listTags"`
 	}{
 		// This is where you can override the golang default value for one of fields
 	}
@@ -43,7 +45,13 @@ func SearchImages(w http.ResponseWriter, r *http.Request) {
 
 	filters := []string{}
 	for key, val := range query.Filters {
-		filters = append(filters, fmt.Sprintf("%s=%s", key, val[0]))
+		for _, v := range val {
+			filters = append(filters, fmt.Sprintf("%s=%s", key, v))
+		}
+	}
+	if _, err := image.ParseSearchFilter(filters); err != nil {
+		utils.BadRequest(w, "filters", strings.Join(filters, ","), err)
+		return
 	}
 
 	options := entities.ImageSearchOptions{
@@ -53,6 +61,10 @@ func SearchImages(w http.ResponseWriter, r *http.Request) {
 		ListTags: query.ListTags,
 		Filters:  filters,
 	}
+	if !utils.IsLibpodRequest(r) {
+		// Docker clients truncate the descriptions themselves.
+		options.NoTrunc = true
+	}
 	if _, found := r.URL.Query()["tlsVerify"]; found {
 		options.SkipTLSVerify = types.NewOptionalBool(!query.TLSVerify)
 	}
@@ -63,10 +75,18 @@ func SearchImages(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if !utils.IsLibpodRequest(r) {
-		if len(reports) == 0 {
-			utils.ImageNotFound(w, query.Term, define.ErrNoSuchImage)
-			return
+		results := make([]registry.SearchResult, 0, len(reports))
+		for _, report := range reports {
+			results = append(results, registry.SearchResult{
+				Name:        report.Name,
+				Description: report.Description,
+				StarCount:   report.Stars,
+				IsOfficial:  report.Official == "[OK]",
+				IsAutomated: report.Automated == "[OK]",
+			})
 		}
+		utils.WriteResponse(w, http.StatusOK, results)
+		return
 	}
 
 	utils.WriteResponse(w, http.StatusOK, reports)
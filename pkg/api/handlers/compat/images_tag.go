@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/image"
 	"github.com/containers/podman/v3/pkg/api/handlers/utils"
 	"github.com/pkg/errors"
 )
@@ -29,6 +30,10 @@ func TagImage(w http.ResponseWriter, r *http.Request) {
 	}
 	repo := r.Form.Get("repo")
 	tagName := fmt.Sprintf("%s:%s", repo, tag)
+	if _, err := image.NormalizedTag(tagName); err != nil {
+		utils.Error(w, "Something went wrong.", http.StatusBadRequest, errors.Wrapf(err, "invalid tag %q", tagName))
+		return
+	}
 	if err := newImage.TagImage(tagName); err != nil {
 		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, err)
 		return
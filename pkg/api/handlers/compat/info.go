@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"os"
 	goRuntime "runtime"
+	"sort"
 	"strings"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/containers/podman/v3/libpod/define"
 	"github.com/containers/podman/v3/pkg/api/handlers"
 	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/registries"
 	"github.com/containers/podman/v3/pkg/rootless"
 	docker "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/registry"
@@ -46,6 +48,15 @@ func GetInfo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	stateInfo := getContainersState(runtime)
+	containers := 0
+	for _, n := range stateInfo {
+		containers += n
+	}
+	registryConfig, err := getRegistryConfig()
+	if err != nil {
+		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, errors.Wrapf(err, "failed to obtain registries"))
+		return
+	}
 	sysInfo := sysinfo.New(true)
 
 	// FIXME: Need to expose if runtime supports Checkpointing
@@ -63,7 +74,7 @@ func GetInfo(w http.ResponseWriter, r *http.Request) {
 		ClusterAdvertise:   "",
 		ClusterStore:       "",
 		ContainerdCommit:   docker.Commit{},
-		Containers:         infoData.Store.ContainerStore.Number,
+		Containers:         containers,
 		ContainersPaused:   stateInfo[define.ContainerStatePaused],
 		ContainersRunning:  stateInfo[define.ContainerStateRunning],
 		ContainersStopped:  stateInfo[define.ContainerStateStopped] + stateInfo[define.ContainerStateExited],
@@ -104,7 +115,7 @@ func GetInfo(w http.ResponseWriter, r *http.Request) {
 		PidsLimit:          sysInfo.PidsLimit,
 		Plugins:            docker.PluginsInfo{},
 		ProductLicense:     "Apache-2.0",
-		RegistryConfig:     new(registry.ServiceConfig),
+		RegistryConfig:     registryConfig,
 		RuncCommit:         docker.Commit{},
 		Runtimes:           getRuntimes(configInfo),
 		SecurityOptions:    getSecOpts(sysInfo),
@@ -134,9 +145,58 @@ func getGraphStatus(storeInfo map[string]string) [][2]string {
 	for k, v := range storeInfo {
 		graphStatus = append(graphStatus, [2]string{k, v})
 	}
+	sort.Slice(graphStatus, func(i, j int) bool {
+		return graphStatus[i][0] < graphStatus[j][0]
+	})
 	return graphStatus
 }
 
+// getRegistryConfig describes the registries of registries.conf the way
+// Docker describes its index configurations.
+func getRegistryConfig() (*registry.ServiceConfig, error) {
+	serviceConfig := &registry.ServiceConfig{
+		AllowNondistributableArtifactsCIDRs:     []*registry.NetIPNet{},
+		AllowNondistributableArtifactsHostnames: []string{},
+		InsecureRegistryCIDRs:                   []*registry.NetIPNet{},
+		IndexConfigs:                            map[string]*registry.IndexInfo{},
+		Mirrors:                                 []string{},
+	}
+	regs, err := registries.GetRegistriesData()
+	if err != nil {
+		return nil, err
+	}
+	for _, reg := range regs {
+		if reg.Blocked {
+			continue
+		}
+		mirrors := make([]string, 0, len(reg.Mirrors))
+		for _, mirror := range reg.Mirrors {
+			mirrors = append(mirrors, mirror.Location)
+		}
+		serviceConfig.IndexConfigs[reg.Prefix] = &registry.IndexInfo{
+			Name:     reg.Prefix,
+			Mirrors:  mirrors,
+			Secure:   !reg.Insecure,
+			Official: reg.Prefix == "docker.io",
+		}
+	}
+	search, err := registries.GetRegistries()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range search {
+		if _, ok := serviceConfig.IndexConfigs[name]; !ok {
+			serviceConfig.IndexConfigs[name] = &registry.IndexInfo{
+				Name:     name,
+				Mirrors:  []string{},
+				Secure:   true,
+				Official: name == "docker.io",
+			}
+		}
+	}
+	return serviceConfig, nil
+}
+
 func getSecOpts(sysInfo *sysinfo.SysInfo) []string {
 	var secOpts []string
 	if sysInfo.AppArmor {
@@ -5,6 +5,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"syscall"
 	"time"
@@ -16,6 +17,7 @@ import (
 	"github.com/containers/podman/v3/pkg/api/handlers/utils"
 	"github.com/containers/podman/v3/pkg/domain/entities"
 	"github.com/containers/podman/v3/pkg/domain/infra/abi"
+	"github.com/containers/podman/v3/pkg/util"
 	"github.com/docker/docker/api/types"
 	dockerNetwork "github.com/docker/docker/api/types/network"
 	"github.com/gorilla/schema"
@@ -101,6 +103,7 @@ func getNetworkResourceByNameOrID(nameOrID string, runtime *libpod.Runtime, filt
 	if err != nil {
 		return nil, err
 	}
+	var enableIPv6 bool
 	for _, outer := range bridge.IPAM.Ranges {
 		for _, n := range outer {
 			ipamConfig := dockerNetwork.IPAMConfig{
@@ -108,6 +111,9 @@ func getNetworkResourceByNameOrID(nameOrID string, runtime *libpod.Runtime, filt
 				Gateway: n.Gateway,
 			}
 			ipamConfigs = append(ipamConfigs, ipamConfig)
+			if _, subnet, err := net.ParseCIDR(n.Subnet); err == nil && subnet.IP.To4() == nil {
+				enableIPv6 = true
+			}
 		}
 	}
 
@@ -139,7 +145,7 @@ func getNetworkResourceByNameOrID(nameOrID string, runtime *libpod.Runtime, filt
 		Created:    time.Unix(int64(stat.Ctim.Sec), int64(stat.Ctim.Nsec)), // nolint: unconvert
 		Scope:      "local",
 		Driver:     network.DefaultNetworkDriver,
-		EnableIPv6: false,
+		EnableIPv6: enableIPv6,
 		IPAM: dockerNetwork.IPAM{
 			Driver:  "default",
 			Options: map[string]string{},
@@ -198,6 +204,22 @@ func ListNetworks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The type filter tells the default network, which podman sets up
+	// itself, from the ones created by users.
+	var builtin, custom bool
+	for _, networkType := range filterMap["type"] {
+		switch networkType {
+		case "builtin":
+			builtin = true
+		case "custom":
+			custom = true
+		default:
+			utils.BadRequest(w, "filters", "type="+networkType, errors.Errorf("invalid filter: 'type'='%s'", networkType))
+			return
+		}
+	}
+	delete(filterMap, "type")
+
 	netNames, err := network.GetNetworkNamesFromFileSystem(config)
 	if err != nil {
 		utils.InternalServerError(w, err)
@@ -207,6 +229,9 @@ func ListNetworks(w http.ResponseWriter, r *http.Request) {
 	reports := []*types.NetworkResource{}
 	logrus.Debugf("netNames: %q", strings.Join(netNames, ", "))
 	for _, name := range netNames {
+		if builtin != custom && builtin != (name == config.Network.DefaultNetwork) {
+			continue
+		}
 		report, err := getNetworkResourceByNameOrID(name, runtime, filterMap)
 		if err != nil {
 			utils.InternalServerError(w, err)
@@ -226,7 +251,7 @@ func CreateNetwork(w http.ResponseWriter, r *http.Request) {
 	)
 	runtime := r.Context().Value("runtime").(*libpod.Runtime)
 	if err := json.NewDecoder(r.Body).Decode(&networkCreate); err != nil {
-		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, errors.Wrap(err, "Decode()"))
+		utils.Error(w, "Something went wrong.", http.StatusBadRequest, errors.Wrap(err, "Decode()"))
 		return
 	}
 
@@ -239,55 +264,90 @@ func CreateNetwork(w http.ResponseWriter, r *http.Request) {
 	// At present I think we should just support the bridge driver
 	// and allow demand to make us consider more
 	if networkCreate.Driver != network.DefaultNetworkDriver {
-		utils.InternalServerError(w, errors.New("network create only supports the bridge driver"))
+		utils.BadRequest(w, "Driver", networkCreate.Driver, errors.New("network create only supports the bridge driver"))
 		return
 	}
 	ncOptions := entities.NetworkCreateOptions{
 		Driver:   network.DefaultNetworkDriver,
 		Internal: networkCreate.Internal,
 		Labels:   networkCreate.Labels,
+		IPv6:     networkCreate.EnableIPv6,
+	}
+	for k, v := range networkCreate.Options {
+		// Docker names the MTU by the driver
+		if k == "com.docker.network.driver.mtu" {
+			k = "mtu"
+		}
+		if ncOptions.Options == nil {
+			ncOptions.Options = make(map[string]string, len(networkCreate.Options))
+		}
+		ncOptions.Options[k] = v
 	}
 	if networkCreate.IPAM != nil && len(networkCreate.IPAM.Config) > 0 {
 		if len(networkCreate.IPAM.Config) > 1 {
-			utils.InternalServerError(w, errors.New("compat network create can only support one IPAM config"))
+			utils.BadRequest(w, "IPAM", "", errors.New("compat network create can only support one IPAM config"))
 			return
 		}
 
 		if len(networkCreate.IPAM.Config[0].Subnet) > 0 {
 			_, subnet, err := net.ParseCIDR(networkCreate.IPAM.Config[0].Subnet)
 			if err != nil {
-				utils.InternalServerError(w, err)
+				utils.BadRequest(w, "Subnet", networkCreate.IPAM.Config[0].Subnet, err)
 				return
 			}
 			ncOptions.Subnet = *subnet
 		}
 		if len(networkCreate.IPAM.Config[0].Gateway) > 0 {
 			ncOptions.Gateway = net.ParseIP(networkCreate.IPAM.Config[0].Gateway)
+			if ncOptions.Gateway == nil {
+				utils.BadRequest(w, "Gateway", networkCreate.IPAM.Config[0].Gateway, errors.New("invalid IP address"))
+				return
+			}
 		}
 		if len(networkCreate.IPAM.Config[0].IPRange) > 0 {
 			_, IPRange, err := net.ParseCIDR(networkCreate.IPAM.Config[0].IPRange)
 			if err != nil {
-				utils.InternalServerError(w, err)
+				utils.BadRequest(w, "IPRange", networkCreate.IPAM.Config[0].IPRange, err)
 				return
 			}
 			ncOptions.Range = *IPRange
 		}
 	}
+
+	if len(name) > 0 {
+		config, err := runtime.GetConfig()
+		if err != nil {
+			utils.InternalServerError(w, err)
+			return
+		}
+		netNames, err := network.GetNetworkNamesFromFileSystem(config)
+		if err != nil {
+			utils.InternalServerError(w, err)
+			return
+		}
+		if util.StringInSlice(name, netNames) {
+			utils.Error(w, "Something went wrong.", http.StatusConflict, errors.Wrapf(define.ErrNetworkExists, "network with name %s already exists", name))
+			return
+		}
+	}
+
 	ce := abi.ContainerEngine{Libpod: runtime}
-	if _, err := ce.NetworkCreate(r.Context(), name, ncOptions); err != nil {
+	report, err := ce.NetworkCreate(r.Context(), name, ncOptions)
+	if err != nil {
 		utils.InternalServerError(w, err)
 		return
 	}
 
+	if len(name) == 0 {
+		// the generated name names the config file
+		name = strings.TrimSuffix(filepath.Base(report.Filename), filepath.Ext(report.Filename))
+	}
 	net, err := getNetworkResourceByNameOrID(name, runtime, nil)
 	if err != nil {
 		utils.InternalServerError(w, err)
 		return
 	}
-	body := struct {
-		ID      string `json:"Id"`
-		Warning []string
-	}{
+	body := types.NetworkCreateResponse{
 		ID: net.ID,
 	}
 	utils.WriteResponse(w, http.StatusCreated, body)
@@ -316,6 +376,10 @@ func RemoveNetwork(w http.ResponseWriter, r *http.Request) {
 	name := utils.GetName(r)
 	reports, err := ic.NetworkRm(r.Context(), []string{name}, options)
 	if err != nil {
+		if errors.Cause(err) == define.ErrNetworkInUse {
+			utils.Error(w, "Something went wrong.", http.StatusConflict, err)
+			return
+		}
 		utils.Error(w, "remove Network failed", http.StatusInternalServerError, err)
 		return
 	}
@@ -345,7 +409,7 @@ func Connect(w http.ResponseWriter, r *http.Request) {
 		netConnect types.NetworkConnect
 	)
 	if err := json.NewDecoder(r.Body).Decode(&netConnect); err != nil {
-		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, errors.Wrap(err, "Decode()"))
+		utils.Error(w, "Something went wrong.", http.StatusBadRequest, errors.Wrap(err, "Decode()"))
 		return
 	}
 	name := utils.GetName(r)
@@ -364,6 +428,10 @@ func Connect(w http.ResponseWriter, r *http.Request) {
 			utils.Error(w, "network not found", http.StatusNotFound, err)
 			return
 		}
+		if errors.Cause(err) == define.ErrNetworkExists {
+			utils.Error(w, "Something went wrong.", http.StatusConflict, err)
+			return
+		}
 		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, err)
 		return
 	}
@@ -376,7 +444,7 @@ func Disconnect(w http.ResponseWriter, r *http.Request) {
 
 	var netDisconnect types.NetworkDisconnect
 	if err := json.NewDecoder(r.Body).Decode(&netDisconnect); err != nil {
-		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, errors.Wrap(err, "Decode()"))
+		utils.Error(w, "Something went wrong.", http.StatusBadRequest, errors.Wrap(err, "Decode()"))
 		return
 	}
 
@@ -384,7 +452,7 @@ func Disconnect(w http.ResponseWriter, r *http.Request) {
 	err := runtime.DisconnectContainerFromNetwork(netDisconnect.Container, name, netDisconnect.Force)
 	if err != nil {
 		if errors.Cause(err) == define.ErrNoSuchCtr {
-			utils.Error(w, "container not found", http.StatusNotFound, err)
+			utils.ContainerNotFound(w, netDisconnect.Container, err)
 			return
 		}
 		if errors.Cause(err) == define.ErrNoSuchNetwork {
@@ -3,6 +3,7 @@ package compat
 import (
 	"fmt"
 	"net/http"
+	goRuntime "runtime"
 
 	"github.com/containers/buildah"
 )
@@ -19,6 +20,8 @@ func Ping(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Docker-Experimental", "true")
 	w.Header().Set("Cache-Control", "no-cache")
 	w.Header().Set("Pragma", "no-cache")
+	w.Header().Set("OSType", goRuntime.GOOS)
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 
 	w.Header().Set("Libpod-Buildah-Version", buildah.Version)
 	w.WriteHeader(http.StatusOK)
@@ -53,6 +53,11 @@ func ResizeTTY(w http.ResponseWriter, r *http.Request) {
 				fmt.Errorf("container %q in wrong state %q", name, state.String()))
 			return
 		}
+		if spec := ctnr.Spec(); spec == nil || spec.Process == nil || !spec.Process.Terminal {
+			utils.Error(w, "Container has no tty", http.StatusConflict,
+				fmt.Errorf("container %q was not created with a tty", name))
+			return
+		}
 		if err := ctnr.AttachResize(sz); err != nil {
 			utils.InternalServerError(w, errors.Wrapf(err, "cannot resize container"))
 			return
@@ -75,13 +80,30 @@ func ResizeTTY(w http.ResponseWriter, r *http.Request) {
 				fmt.Errorf("container %q in wrong state %q", name, state.String()))
 			return
 		}
+		session, err := ctnr.ExecSession(name)
+		if err != nil {
+			utils.SessionNotFound(w, name, err)
+			return
+		}
+		if session.Config == nil || !session.Config.Terminal {
+			utils.Error(w, "Exec session has no tty", http.StatusConflict,
+				fmt.Errorf("exec session %q was not created with a tty", name))
+			return
+		}
 		if err := ctnr.ExecResize(name, sz); err != nil {
+			if errors.Cause(err) == define.ErrExecSessionStateInvalid {
+				utils.Error(w, "Exec session not running", http.StatusConflict, err)
+				return
+			}
 			utils.InternalServerError(w, errors.Wrapf(err, "cannot resize session"))
 			return
 		}
 		// This is not a 204, even though we write nothing, for compatibility
 		// reasons.
 		status = http.StatusCreated
+		if !utils.IsLibpodRequest(r) {
+			status = http.StatusOK
+		}
 	}
 	w.WriteHeader(status)
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/containers/podman/v3/pkg/domain/entities"
 	"github.com/containers/storage/pkg/archive"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/registry"
 )
 
 // Create container
@@ -15,6 +16,25 @@ type swagCtrCreateResponse struct {
 	}
 }
 
+// Disk usage
+// swagger:response SystemDiskUseCompat
+type swagDiskUseCompatResponse struct {
+	// in:body
+	Body struct {
+		types.DiskUsage
+	}
+}
+
+// Update container
+// swagger:response ContainerUpdateResponse
+type swagCtrUpdateResponse struct {
+	// in:body
+	Body struct {
+		// warnings
+		Warnings []string
+	}
+}
+
 // Wait container
 // swagger:response ContainerWaitResponse
 type swagCtrWaitResponse struct {
@@ -61,7 +81,7 @@ type NetworkCreateRequest struct {
 // swagger:response CompatNetworkCreate
 type swagCompatNetworkCreateResponse struct {
 	// in:body
-	Body struct{ types.NetworkCreate }
+	Body struct{ types.NetworkCreateResponse }
 }
 
 // Network disconnect
@@ -84,3 +104,17 @@ type swagCompatNetworkPruneResponse struct {
 	// in:body
 	Body []string
 }
+
+// Image prune
+// swagger:response DocsImagePruneResponse
+type swagCompatImagePruneResponse struct {
+	// in:body
+	Body struct{ types.ImagesPruneReport }
+}
+
+// Image search
+// swagger:response DocsCompatSearchResponse
+type swagCompatSearchResponse struct {
+	// in:body
+	Body []registry.SearchResult
+}
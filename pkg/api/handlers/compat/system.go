@@ -9,21 +9,49 @@ import (
 	"github.com/containers/podman/v3/pkg/api/handlers/utils"
 	"github.com/containers/podman/v3/pkg/domain/entities"
 	"github.com/containers/podman/v3/pkg/domain/infra/abi"
+	"github.com/containers/storage"
 	docker "github.com/docker/docker/api/types"
+	"github.com/gorilla/schema"
+	"github.com/pkg/errors"
 )
 
+// GetDiskUsage reports the disk usage of images, containers and volumes.
+// Docker clients compute the totals of `docker system df` from the same
+// entries they list with --verbose, so these are always listed.
 func GetDiskUsage(w http.ResponseWriter, r *http.Request) {
-	options := entities.SystemDfOptions{}
 	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Verbose bool `schema:"verbose"`
+	}{
+		// override any golang type defaults
+		Verbose: true,
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+
+	options := entities.SystemDfOptions{Verbose: query.Verbose}
 	ic := abi.ContainerEngine{Libpod: runtime}
 	df, err := ic.SystemDf(r.Context(), options)
 	if err != nil {
 		utils.InternalServerError(w, err)
 		return
 	}
+	layersSize, err := imageLayersSize(runtime.GetStore())
+	if err != nil {
+		utils.InternalServerError(w, errors.Wrap(err, "failed to compute size of image layers"))
+		return
+	}
 
 	imgs := make([]*docker.ImageSummary, len(df.Images))
 	for i, o := range df.Images {
+		repoTag := o.Repository + ":" + o.Tag
+		if o.Repository == "" {
+			repoTag = "<none>:<none>"
+		}
 		t := docker.ImageSummary{
 			Containers:  int64(o.Containers),
 			Created:     o.Created.Unix(),
@@ -31,10 +59,10 @@ func GetDiskUsage(w http.ResponseWriter, r *http.Request) {
 			Labels:      map[string]string{},
 			ParentID:    "",
 			RepoDigests: nil,
-			RepoTags:    []string{o.Tag},
+			RepoTags:    []string{repoTag},
 			SharedSize:  o.SharedSize,
 			Size:        o.Size,
-			VirtualSize: o.Size - o.UniqueSize,
+			VirtualSize: o.Size,
 		}
 		imgs[i] = &t
 	}
@@ -75,7 +103,7 @@ func GetDiskUsage(w http.ResponseWriter, r *http.Request) {
 			Scope:      "local",
 			Status:     nil,
 			UsageData: &docker.VolumeUsageData{
-				RefCount: 1,
+				RefCount: int64(o.Links),
 				Size:     o.Size,
 			},
 		}
@@ -83,7 +111,7 @@ func GetDiskUsage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	utils.WriteResponse(w, http.StatusOK, handlers.DiskUsage{DiskUsage: docker.DiskUsage{
-		LayersSize:  0,
+		LayersSize:  layersSize,
 		Images:      imgs,
 		Containers:  ctnrs,
 		Volumes:     vols,
@@ -91,3 +119,35 @@ func GetDiskUsage(w http.ResponseWriter, r *http.Request) {
 		BuilderSize: 0,
 	}})
 }
+
+// imageLayersSize returns the size of the layers of images, the layers of
+// containers being excluded as with Docker.
+func imageLayersSize(store storage.Store) (int64, error) {
+	ctrs, err := store.Containers()
+	if err != nil {
+		return 0, err
+	}
+	ctrLayers := make(map[string]bool, len(ctrs))
+	for _, ctr := range ctrs {
+		ctrLayers[ctr.LayerID] = true
+	}
+	layers, err := store.Layers()
+	if err != nil {
+		return 0, err
+	}
+	var size int64
+	for _, layer := range layers {
+		if ctrLayers[layer.ID] {
+			continue
+		}
+		layerSize := layer.UncompressedSize
+		if layerSize <= 0 {
+			// Layers not applied from a blob have no recorded size.
+			if layerSize, err = store.DiffSize(layer.Parent, layer.ID); err != nil {
+				return 0, err
+			}
+		}
+		size += layerSize
+	}
+	return size, nil
+}
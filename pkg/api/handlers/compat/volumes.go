@@ -3,8 +3,10 @@ package compat
 import (
 	"bytes"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/containers/podman/v3/libpod"
@@ -36,43 +38,25 @@ func ListVolumes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Reject any libpod specific filters since `GenerateVolumeFilters()` will
-	// happily parse them for us.
-	for filter := range query.Filters {
-		if filter == "opts" {
-			utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
-				errors.Errorf("unsupported libpod filters passed to docker endpoint"))
-			return
-		}
-	}
-	volumeFilters, err := filters.GenerateVolumeFilters(query.Filters)
+	volumeFilter, err := dockerVolumeFilter(query.Filters)
 	if err != nil {
-		utils.InternalServerError(w, err)
+		utils.BadRequest(w, "filters", "", err)
 		return
 	}
 
-	vols, err := runtime.Volumes(volumeFilters...)
+	vols, err := runtime.Volumes(volumeFilter)
 	if err != nil {
 		utils.InternalServerError(w, err)
 		return
 	}
 	volumeConfigs := make([]*docker_api_types.Volume, 0, len(vols))
 	for _, v := range vols {
-		mp, err := v.MountPoint()
+		config, err := volumeResponse(v)
 		if err != nil {
 			utils.InternalServerError(w, err)
 			return
 		}
-		config := docker_api_types.Volume{
-			Name:       v.Name(),
-			Driver:     v.Driver(),
-			Mountpoint: mp,
-			CreatedAt:  v.CreatedTime().Format(time.RFC3339),
-			Labels:     v.Labels(),
-			Scope:      v.Scope(),
-			Options:    v.Options(),
-		}
-		volumeConfigs = append(volumeConfigs, &config)
+		volumeConfigs = append(volumeConfigs, config)
 	}
 	response := docker_api_types_volume.VolumeListOKBody{
 		Volumes:  volumeConfigs,
@@ -81,6 +65,53 @@ func ListVolumes(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, http.StatusOK, response)
 }
 
+// dockerVolumeFilter returns a filter matching the volumes as Docker does:
+// a volume matches if it matches all filters, and any of the values of a
+// filter but for labels, which must all match.  Names match by substring.
+func dockerVolumeFilter(queryFilters map[string][]string) (libpod.VolumeFilter, error) {
+	var keyFilters []libpod.VolumeFilter
+	for filter, values := range queryFilters {
+		var valueFilters []libpod.VolumeFilter
+		for _, val := range values {
+			switch filter {
+			case "name":
+				nameVal := val
+				valueFilters = append(valueFilters, func(v *libpod.Volume) bool {
+					return strings.Contains(v.Name(), nameVal)
+				})
+			case "dangling", "driver", "label":
+				vf, err := filters.GenerateVolumeFilters(url.Values{filter: []string{val}})
+				if err != nil {
+					return nil, err
+				}
+				valueFilters = append(valueFilters, vf...)
+			default:
+				return nil, errors.Errorf("%q is not a valid volume filter", filter)
+			}
+		}
+		if filter == "label" {
+			keyFilters = append(keyFilters, valueFilters...)
+			continue
+		}
+		keyFilters = append(keyFilters, func(v *libpod.Volume) bool {
+			for _, f := range valueFilters {
+				if f(v) {
+					return true
+				}
+			}
+			return len(valueFilters) == 0
+		})
+	}
+	return func(v *libpod.Volume) bool {
+		for _, f := range keyFilters {
+			if !f(v) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
 func CreateVolume(w http.ResponseWriter, r *http.Request) {
 	var (
 		volumeOptions []libpod.VolumeCreateOption
@@ -96,35 +127,36 @@ func CreateVolume(w http.ResponseWriter, r *http.Request) {
 	}
 	// decode params from body
 	input := docker_api_types_volume.VolumeCreateBody{}
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, errors.Wrap(err, "Decode()"))
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil && err != io.EOF {
+		utils.Error(w, "Something went wrong.", http.StatusBadRequest, errors.Wrap(err, "Decode()"))
 		return
 	}
 
-	// See if the volume exists already
-	existingVolume, err := runtime.GetVolume(input.Name)
-	if err != nil && errors.Cause(err) != define.ErrNoSuchVolume {
-		utils.InternalServerError(w, err)
-		return
+	// See if the volume exists already, volumes without a name get a new
+	// one.
+	var existingVolume *libpod.Volume
+	if len(input.Name) > 0 {
+		vol, err := runtime.GetVolume(input.Name)
+		if err != nil && errors.Cause(err) != define.ErrNoSuchVolume {
+			utils.InternalServerError(w, err)
+			return
+		}
+		existingVolume = vol
 	}
 
 	// if using the compat layer and the volume already exists, we
 	// must return a 201 with the same information as create
 	if existingVolume != nil && !utils.IsLibpodRequest(r) {
-		mp, err := existingVolume.MountPoint()
+		if len(input.Driver) > 0 && input.Driver != existingVolume.Driver() {
+			utils.Error(w, "Something went wrong.", http.StatusConflict,
+				errors.Wrapf(define.ErrVolumeExists, "volume %s exists with driver %s", input.Name, existingVolume.Driver()))
+			return
+		}
+		response, err := volumeResponse(existingVolume)
 		if err != nil {
 			utils.InternalServerError(w, err)
 			return
 		}
-		response := docker_api_types.Volume{
-			CreatedAt:  existingVolume.CreatedTime().Format(time.RFC3339),
-			Driver:     existingVolume.Driver(),
-			Labels:     existingVolume.Labels(),
-			Mountpoint: mp,
-			Name:       existingVolume.Name(),
-			Options:    existingVolume.Options(),
-			Scope:      existingVolume.Scope(),
-		}
 		utils.WriteResponse(w, http.StatusCreated, response)
 		return
 	}
@@ -141,7 +173,7 @@ func CreateVolume(w http.ResponseWriter, r *http.Request) {
 	if len(input.DriverOpts) > 0 {
 		parsedOptions, err := parse.VolumeOptions(input.DriverOpts)
 		if err != nil {
-			utils.InternalServerError(w, err)
+			utils.BadRequest(w, "DriverOpts", "", err)
 			return
 		}
 		volumeOptions = append(volumeOptions, parsedOptions...)
@@ -151,31 +183,31 @@ func CreateVolume(w http.ResponseWriter, r *http.Request) {
 		utils.InternalServerError(w, err)
 		return
 	}
-	config, err := vol.Config()
+	volResponse, err := volumeResponse(vol)
 	if err != nil {
 		utils.InternalServerError(w, err)
 		return
 	}
+	utils.WriteResponse(w, http.StatusCreated, volResponse)
+}
+
+// volumeResponse returns the compat inspect shape of the volume.
+func volumeResponse(vol *libpod.Volume) (*docker_api_types.Volume, error) {
 	mp, err := vol.MountPoint()
 	if err != nil {
-		utils.InternalServerError(w, err)
-		return
+		return nil, err
 	}
-	volResponse := docker_api_types.Volume{
-		Name:       config.Name,
-		Driver:     config.Driver,
+	return &docker_api_types.Volume{
+		Name:       vol.Name(),
+		Driver:     vol.Driver(),
 		Mountpoint: mp,
-		CreatedAt:  config.CreatedTime.Format(time.RFC3339),
-		Labels:     config.Labels,
-		Options:    config.Options,
-		Scope:      "local",
-		// ^^ We don't have volume scoping so we'll just claim it's "local"
-		// like we do in the `libpod.Volume.Scope()` method
-		//
+		CreatedAt:  vol.CreatedTime().Format(time.RFC3339),
+		Labels:     vol.Labels(),
+		Options:    vol.Options(),
+		Scope:      vol.Scope(),
 		// TODO: We don't include the volume `Status` or `UsageData`, but both
 		// are nullable in the Docker engine API spec so that's fine for now
-	}
-	utils.WriteResponse(w, http.StatusCreated, volResponse)
+	}, nil
 }
 
 func InspectVolume(w http.ResponseWriter, r *http.Request) {
@@ -188,21 +220,11 @@ func InspectVolume(w http.ResponseWriter, r *http.Request) {
 		utils.VolumeNotFound(w, name, err)
 		return
 	}
-	mp, err := vol.MountPoint()
+	volResponse, err := volumeResponse(vol)
 	if err != nil {
 		utils.InternalServerError(w, err)
 		return
 	}
-	volResponse := docker_api_types.Volume{
-		Name:       vol.Name(),
-		Driver:     vol.Driver(),
-		Mountpoint: mp,
-		CreatedAt:  vol.CreatedTime().Format(time.RFC3339),
-		Labels:     vol.Labels(),
-		Options:    vol.Options(),
-		Scope:      vol.Scope(),
-		// TODO: As above, we don't return `Status` or `UsageData` yet
-	}
 	utils.WriteResponse(w, http.StatusOK, volResponse)
 }
 
@@ -235,20 +257,16 @@ func RemoveVolume(w http.ResponseWriter, r *http.Request) {
 	 * respectively.
 	 */
 	name := utils.GetName(r)
-	vol, err := runtime.LookupVolume(name)
+	// Docker removes volumes by their full name only.
+	vol, err := runtime.GetVolume(name)
 	if err == nil {
 		// As above, we do not pass `force` from the query parameters here
-		if err := runtime.RemoveVolume(r.Context(), vol, false); err != nil {
-			if errors.Cause(err) == define.ErrVolumeBeingUsed {
-				utils.Error(w, "volumes being used", http.StatusConflict, err)
-			} else {
-				utils.InternalServerError(w, err)
-			}
-		} else {
-			// Success
-			utils.WriteResponse(w, http.StatusNoContent, nil)
-		}
-	} else {
+		err = runtime.RemoveVolume(r.Context(), vol, false)
+	}
+	switch errors.Cause(err) {
+	case nil:
+		utils.WriteResponse(w, http.StatusNoContent, nil)
+	case define.ErrNoSuchVolume:
 		if !query.Force {
 			utils.VolumeNotFound(w, name, err)
 		} else {
@@ -257,6 +275,10 @@ func RemoveVolume(w http.ResponseWriter, r *http.Request) {
 			// volume
 			utils.WriteResponse(w, http.StatusNoContent, nil)
 		}
+	case define.ErrVolumeBeingUsed:
+		utils.Error(w, "volumes being used", http.StatusConflict, err)
+	default:
+		utils.InternalServerError(w, err)
 	}
 }
 
@@ -278,14 +300,22 @@ func PruneVolumes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	f := (url.Values)(query.Filters)
-	filterFuncs, err := filters.GenerateVolumeFilters(f)
+	// Volumes are pruned by their labels only, volumes still used by any
+	// container are never removed.
+	for filter := range query.Filters {
+		if filter != "label" {
+			utils.BadRequest(w, "filters", filter, errors.Errorf("%q is not a valid volume prune filter", filter))
+			return
+		}
+	}
+	volumeFilter, err := dockerVolumeFilter(query.Filters)
 	if err != nil {
+		f := (url.Values)(query.Filters)
 		utils.Error(w, "Something when wrong.", http.StatusBadRequest, errors.Wrapf(err, "failed to parse filters for %s", f.Encode()))
 		return
 	}
 
-	pruned, err := runtime.PruneVolumes(r.Context(), filterFuncs)
+	pruned, err := runtime.PruneVolumes(r.Context(), []libpod.VolumeFilter{volumeFilter})
 	if err != nil {
 		utils.InternalServerError(w, err)
 		return
@@ -57,6 +57,10 @@ func ContainerExists(w http.ResponseWriter, r *http.Request) {
 }
 
 func ListContainers(w http.ResponseWriter, r *http.Request) {
+	if !utils.WantsLibpodFormat(r) {
+		compat.ListContainers(w, r)
+		return
+	}
 	decoder := r.Context().Value("decoder").(*schema.Decoder)
 	query := struct {
 		All       bool                `schema:"all"`
@@ -116,6 +120,10 @@ func ListContainers(w http.ResponseWriter, r *http.Request) {
 }
 
 func GetContainer(w http.ResponseWriter, r *http.Request) {
+	if !utils.WantsLibpodFormat(r) {
+		compat.GetContainer(w, r)
+		return
+	}
 	decoder := r.Context().Value("decoder").(*schema.Decoder)
 	query := struct {
 		Size bool `schema:"size"`
@@ -0,0 +1,55 @@
+package libpod
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/pkg/errors"
+)
+
+// SetAutoRemove enables or disables removing a container when it exits.
+// Enabling it on an exited container removes the container right away.
+func SetAutoRemove(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	var req entities.ContainerAutoRemoveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, errors.Wrap(err, "Decode()"))
+		return
+	}
+	if req.Enabled == nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, errors.New("enabled is required"))
+		return
+	}
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	if err := runtime.SetContainerAutoRemove(context.Background(), ctr, *req.Enabled); err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+
+	report := entities.ContainerAutoRemoveReport{Id: ctr.ID(), Enabled: *req.Enabled}
+	if *req.Enabled {
+		state, err := ctr.State()
+		if err != nil {
+			utils.InternalServerError(w, err)
+			return
+		}
+		if state == define.ContainerStateExited || state == define.ContainerStateStopped {
+			if err := runtime.RemoveContainer(context.Background(), ctr, false, true); err != nil && errors.Cause(err) != define.ErrNoSuchCtr && errors.Cause(err) != define.ErrCtrRemoved {
+				utils.InternalServerError(w, errors.Wrapf(err, "failed to remove container %s", ctr.ID()))
+				return
+			}
+			report.Removed = true
+		}
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
@@ -0,0 +1,188 @@
+package libpod
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// ContainerBundle streams a tar archive of an OCI runtime bundle for the
+// container: its config.json, rootfs and a manifest.json listing the host
+// specific settings which were removed from config.json.
+func ContainerBundle(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	data, err := ctr.Inspect(false)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if data.OCIConfigPath == "" {
+		utils.Error(w, "Something went wrong.", http.StatusConflict,
+			errors.Wrapf(define.ErrCtrStateInvalid, "container %s has never been initialized", ctr.ID()))
+		return
+	}
+	content, err := ioutil.ReadFile(data.OCIConfigPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			utils.Error(w, "Something went wrong.", http.StatusConflict,
+				errors.Wrapf(define.ErrCtrStateInvalid, "container %s has never been initialized", ctr.ID()))
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	bundleSpec := new(spec.Spec)
+	if err := json.Unmarshal(content, bundleSpec); err != nil {
+		utils.InternalServerError(w, errors.Wrapf(err, "error unmarshalling config of container %s", ctr.ID()))
+		return
+	}
+
+	manifest := entities.ContainerBundleManifest{
+		ContainerID: ctr.ID(),
+		Name:        ctr.Name(),
+		Image:       data.ImageName,
+		Created:     ctr.CreatedTime(),
+		Stripped:    stripHostSpecific(bundleSpec),
+	}
+	configJSON, err := json.MarshalIndent(bundleSpec, "", "\t")
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	manifestJSON, err := json.MarshalIndent(manifest, "", "\t")
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-tar")
+	w.WriteHeader(http.StatusOK)
+	tw := tar.NewWriter(w)
+	now := time.Now()
+	for _, file := range []struct {
+		name    string
+		content []byte
+	}{
+		{"config.json", configJSON},
+		{"manifest.json", manifestJSON},
+	} {
+		hdr := &tar.Header{Name: file.name, Mode: 0644, Size: int64(len(file.content)), ModTime: now, Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			logrus.Errorf("Unable to write bundle of container %s: %v", ctr.ID(), err)
+			return
+		}
+		if _, err := tw.Write(file.content); err != nil {
+			logrus.Errorf("Unable to write bundle of container %s: %v", ctr.ID(), err)
+			return
+		}
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(ctr.ExportTo(writer))
+	}()
+	defer reader.Close()
+	if err := copyTarWithPrefix(tw, tar.NewReader(reader), bundleSpec.Root.Path); err != nil {
+		logrus.Errorf("Unable to write root filesystem of container %s to bundle: %v", ctr.ID(), err)
+		return
+	}
+	if err := tw.Close(); err != nil {
+		logrus.Errorf("Unable to write bundle of container %s: %v", ctr.ID(), err)
+	}
+}
+
+// stripHostSpecific removes settings referring to the host the container was
+// created on from a bundle config, and returns what was removed.
+func stripHostSpecific(s *spec.Spec) []entities.ContainerBundleStripped {
+	stripped := []entities.ContainerBundleStripped{}
+	if s.Root == nil {
+		s.Root = &spec.Root{}
+	}
+	if s.Root.Path != "" {
+		stripped = append(stripped, entities.ContainerBundleStripped{Field: "root.path", Value: s.Root.Path})
+	}
+	s.Root.Path = "rootfs"
+
+	mounts := make([]spec.Mount, 0, len(s.Mounts))
+	for _, m := range s.Mounts {
+		// Bind mounts pull in host files, such as volumes and the
+		// generated resolv.conf and hosts.
+		if m.Type == "bind" || filepath.IsAbs(m.Source) {
+			stripped = append(stripped, entities.ContainerBundleStripped{Field: "mounts", Value: m.Source + ":" + m.Destination})
+			continue
+		}
+		mounts = append(mounts, m)
+	}
+	s.Mounts = mounts
+
+	if s.Hooks != nil {
+		stripped = append(stripped, entities.ContainerBundleStripped{Field: "hooks"})
+		s.Hooks = nil
+	}
+	if s.Linux != nil {
+		if s.Linux.CgroupsPath != "" {
+			stripped = append(stripped, entities.ContainerBundleStripped{Field: "linux.cgroupsPath", Value: s.Linux.CgroupsPath})
+			s.Linux.CgroupsPath = ""
+		}
+		for i, ns := range s.Linux.Namespaces {
+			if ns.Path != "" {
+				stripped = append(stripped, entities.ContainerBundleStripped{Field: "linux.namespaces." + string(ns.Type), Value: ns.Path})
+				s.Linux.Namespaces[i].Path = ""
+			}
+		}
+	}
+	return stripped
+}
+
+// copyTarWithPrefix copies all entries of a tar archive into tw below prefix.
+func copyTarWithPrefix(tw *tar.Writer, tr *tar.Reader, prefix string) error {
+	dir := &tar.Header{Name: prefix + "/", Mode: 0755, ModTime: time.Now(), Typeflag: tar.TypeDir}
+	if err := tw.WriteHeader(dir); err != nil {
+		return err
+	}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := filepath.Clean("/" + hdr.Name)
+		if name == "/" {
+			continue
+		}
+		hdr.Name = prefix + name
+		if hdr.Typeflag == tar.TypeDir {
+			hdr.Name += "/"
+		}
+		if hdr.Typeflag == tar.TypeLink {
+			hdr.Linkname = prefix + filepath.Clean("/"+hdr.Linkname)
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+}
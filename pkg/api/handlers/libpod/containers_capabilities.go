@@ -0,0 +1,99 @@
+package libpod
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/pkg/errors"
+	"github.com/syndtr/gocapability/capability"
+)
+
+// ContainerCapabilities reports the capabilities the kernel enforces for the
+// init process of a running container, which may differ from the ones
+// requested at create time.
+func ContainerCapabilities(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	ctr, ok := lookupRunningContainer(w, r, runtime)
+	if !ok {
+		return
+	}
+	pid, err := ctr.PID()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	sets, err := readCapabilitySets(pid)
+	if err != nil {
+		utils.InternalServerError(w, errors.Wrapf(err, "error reading capabilities of container %s", ctr.ID()))
+		return
+	}
+	report := entities.ContainerCapabilitiesReport{
+		PID:         pid,
+		Effective:   capabilityNames(sets["CapEff"]),
+		Permitted:   capabilityNames(sets["CapPrm"]),
+		Inheritable: capabilityNames(sets["CapInh"]),
+		Bounding:    capabilityNames(sets["CapBnd"]),
+		Ambient:     capabilityNames(sets["CapAmb"]),
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+// readCapabilitySets reads the Cap* masks from /proc/<pid>/status.
+func readCapabilitySets(pid int) (map[string]uint64, error) {
+	f, err := os.Open(fmt.Sprintf("/proc/%d/status", pid))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sets := make(map[string]uint64)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value := splitStatusLine(scanner.Text())
+		if !strings.HasPrefix(key, "Cap") {
+			continue
+		}
+		mask, err := strconv.ParseUint(value, 16, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s", key)
+		}
+		sets[key] = mask
+	}
+	return sets, scanner.Err()
+}
+
+func splitStatusLine(line string) (string, string) {
+	parts := strings.SplitN(line, ":", 2)
+	if len(parts) != 2 {
+		return "", ""
+	}
+	return parts[0], strings.TrimSpace(parts[1])
+}
+
+// capabilityNames decodes a capability mask into CAP_* names.
+func capabilityNames(mask uint64) []string {
+	names := []string{}
+	for bit := 0; bit < 64; bit++ {
+		if mask&(1<<uint(bit)) == 0 {
+			continue
+		}
+		names = append(names, capabilityName(capability.Cap(bit)))
+	}
+	return names
+}
+
+func capabilityName(c capability.Cap) string {
+	for _, known := range capability.List() {
+		if known == c {
+			return "CAP_" + strings.ToUpper(c.String())
+		}
+	}
+	return "CAP_" + strconv.Itoa(int(c))
+}
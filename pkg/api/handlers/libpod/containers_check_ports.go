@@ -0,0 +1,156 @@
+package libpod
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/cri-o/ocicni/pkg/ocicni"
+	"github.com/pkg/errors"
+)
+
+// CheckContainerPorts reports the host ports of a container which is not
+// running yet that are used by running containers, reserved, or bound by
+// other processes on the host, before starting fails on them.
+func CheckContainerPorts(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	state, err := ctr.State()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if state == define.ContainerStateRunning || state == define.ContainerStatePaused {
+		utils.Error(w, "Something went wrong.", http.StatusConflict,
+			errors.Wrapf(define.ErrCtrStateInvalid, "container %s is already running", ctr.ID()))
+		return
+	}
+	mappings, err := ctr.PortMappings()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+
+	used, err := collectUsedHostPorts(runtime, ctr.ID())
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	report := entities.ContainerCheckPortsReport{Conflicts: []entities.ContainerPortConflict{}}
+	for _, m := range mappings {
+		conflict := entities.ContainerPortConflict{
+			HostIP:        m.HostIP,
+			HostPort:      m.HostPort,
+			ContainerPort: m.ContainerPort,
+			Protocol:      m.Protocol,
+		}
+		if conflict.Container, conflict.Reason = used.conflict(m.HostIP, m.HostPort, m.Protocol); conflict.Reason != "" {
+			report.Conflicts = append(report.Conflicts, conflict)
+		}
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+type hostPort struct {
+	port     int32
+	protocol string
+}
+
+// usedHostPorts are the host ports of the running containers.
+type usedHostPorts struct {
+	mappings map[hostPort][]ocicni.PortMapping
+	owners   map[hostPort][]string
+	runtime  *libpod.Runtime
+	// excludeID is the container the ports are checked for.
+	excludeID string
+}
+
+// collectUsedHostPorts collects the host ports of the running containers
+// but the excluded one.
+func collectUsedHostPorts(runtime *libpod.Runtime, excludeID string) (*usedHostPorts, error) {
+	allCtrs, err := runtime.GetRunningContainers()
+	if err != nil {
+		return nil, err
+	}
+	used := usedHostPorts{
+		mappings:  make(map[hostPort][]ocicni.PortMapping),
+		owners:    make(map[hostPort][]string),
+		runtime:   runtime,
+		excludeID: excludeID,
+	}
+	for _, other := range allCtrs {
+		if other.ID() == excludeID {
+			continue
+		}
+		otherMappings, err := other.PortMappings()
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range otherMappings {
+			key := hostPort{m.HostPort, m.Protocol}
+			used.mappings[key] = append(used.mappings[key], m)
+			used.owners[key] = append(used.owners[key], other.ID())
+		}
+	}
+	return &used, nil
+}
+
+// conflict returns why a host port cannot be bound, as it is used by a
+// running container, reserved, or bound by another process on the host.
+// The reason is empty if the port is free.
+func (u *usedHostPorts) conflict(hostIP string, port int32, protocol string) (container, reason string) {
+	key := hostPort{port, protocol}
+	for i, other := range u.mappings[key] {
+		if hostIPsOverlap(hostIP, other.HostIP) {
+			return u.owners[key][i], "host port is used by container " + u.owners[key][i]
+		}
+	}
+	if utils.PortReserved(u.runtime, u.excludeID, uint16(port), protocol) {
+		return "", "host port is reserved by a port reservation"
+	}
+	if err := probeHostPort(hostIP, port, protocol); err != nil {
+		return "", err.Error()
+	}
+	return "", ""
+}
+
+// hostIPsOverlap returns true if binding both addresses with the same port
+// conflicts; an empty address binds all of them.
+func hostIPsOverlap(a, b string) bool {
+	isAny := func(ip string) bool {
+		return ip == "" || ip == "0.0.0.0" || ip == "::"
+	}
+	return isAny(a) || isAny(b) || net.ParseIP(a).Equal(net.ParseIP(b))
+}
+
+// probeHostPort tries to bind the host port, which on Linux is done with
+// SO_REUSEADDR so ports in TIME_WAIT do not count as used.
+func probeHostPort(hostIP string, port int32, protocol string) error {
+	addr := net.JoinHostPort(hostIP, strconv.Itoa(int(port)))
+	switch protocol {
+	case "udp":
+		conn, err := net.ListenPacket("udp", addr)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	case "tcp", "":
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			return err
+		}
+		return listener.Close()
+	default:
+		// SCTP and other protocols cannot be probed.
+		return nil
+	}
+}
@@ -0,0 +1,250 @@
+package libpod
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	securejoin "github.com/cyphar/filepath-securejoin"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// corePattern describes where the kernel stores core dumps inside the mount
+// namespace of the crashing process.
+type corePattern struct {
+	// dir is the directory of the dumps, relative ones are relative to
+	// the working directory of the crashing process.
+	dir string
+	// name matches the file names of the dumps, fields maps the
+	// submatches of name to the specifiers they are expanded from.
+	name   *regexp.Regexp
+	fields []byte
+}
+
+// readCorePattern parses the core pattern of the kernel.  Core dumps piped
+// to a helper are not stored with the container and cannot be listed.
+func readCorePattern() (*corePattern, error) {
+	b, err := ioutil.ReadFile("/proc/sys/kernel/core_pattern")
+	if err != nil {
+		return nil, err
+	}
+	pattern := strings.TrimSpace(string(b))
+	if strings.HasPrefix(pattern, "|") {
+		return nil, errors.Wrapf(define.ErrNotImplemented, "core dumps are piped to %q and not stored with the container", strings.TrimPrefix(pattern, "|"))
+	}
+	if pattern == "" {
+		pattern = "core"
+	}
+	if !strings.Contains(pattern, "%p") {
+		if b, err := ioutil.ReadFile("/proc/sys/kernel/core_uses_pid"); err == nil && strings.TrimSpace(string(b)) == "1" {
+			pattern += ".%p"
+		}
+	}
+
+	dir, name := path.Split(pattern)
+	if strings.Contains(dir, "%") {
+		return nil, errors.Wrapf(define.ErrNotImplemented, "core pattern %q expands specifiers in its directory", pattern)
+	}
+	cp := corePattern{dir: dir}
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i := 0; i < len(name); i++ {
+		if name[i] != '%' || i == len(name)-1 {
+			expr.WriteString(regexp.QuoteMeta(name[i : i+1]))
+			continue
+		}
+		i++
+		switch name[i] {
+		case '%':
+			expr.WriteString("%")
+		case 'p', 'P', 'i', 'I', 'u', 'g', 'd', 's', 't', 'c':
+			expr.WriteString("([0-9]+)")
+			cp.fields = append(cp.fields, name[i])
+		default:
+			expr.WriteString("([^/]*)")
+			cp.fields = append(cp.fields, name[i])
+		}
+	}
+	expr.WriteString("$")
+	if cp.name, err = regexp.Compile(expr.String()); err != nil {
+		return nil, err
+	}
+	return &cp, nil
+}
+
+// coreDumpDir returns the directory on the host holding the core dumps of
+// the container mounted at mountPoint.
+func (cp *corePattern) coreDumpDir(ctr *libpod.Container, mountPoint string) (string, error) {
+	dir := cp.dir
+	if !path.IsAbs(dir) {
+		workDir := ctr.WorkingDir()
+		if workDir == "" {
+			workDir = "/"
+		}
+		dir = path.Join(workDir, dir)
+	}
+	return securejoin.SecureJoin(mountPoint, dir)
+}
+
+// coreDumps lists the core dumps in dir matching the pattern.
+func (cp *corePattern) coreDumps(dir string) ([]entities.ContainerCoreDump, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []entities.ContainerCoreDump{}, nil
+		}
+		return nil, err
+	}
+	dumps := []entities.ContainerCoreDump{}
+	for _, info := range infos {
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		match := cp.name.FindStringSubmatch(info.Name())
+		if match == nil {
+			continue
+		}
+		dump := entities.ContainerCoreDump{
+			ID:   info.Name(),
+			Size: info.Size(),
+			Time: info.ModTime(),
+		}
+		for i, field := range cp.fields {
+			value := match[i+1]
+			switch field {
+			case 'p':
+				dump.PID, _ = strconv.Atoi(value)
+			case 's':
+				dump.Signal, _ = strconv.Atoi(value)
+			case 't':
+				if sec, err := strconv.ParseInt(value, 10, 64); err == nil {
+					dump.Time = time.Unix(sec, 0)
+				}
+			case 'e':
+				dump.Executable = value
+			}
+		}
+		dumps = append(dumps, dump)
+	}
+	sort.Slice(dumps, func(i, j int) bool { return dumps[i].Time.Before(dumps[j].Time) })
+	return dumps, nil
+}
+
+// mountCoreDumps mounts the container and returns the directory holding its
+// core dumps, writing the error response on failure.  The container must be
+// unmounted again by the caller on success.
+func mountCoreDumps(w http.ResponseWriter, r *http.Request) (*libpod.Container, *corePattern, string, bool) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return nil, nil, "", false
+	}
+	cp, err := readCorePattern()
+	if err != nil {
+		if errors.Cause(err) == define.ErrNotImplemented {
+			utils.Error(w, http.StatusText(http.StatusNotImplemented), http.StatusNotImplemented, err)
+			return nil, nil, "", false
+		}
+		utils.InternalServerError(w, err)
+		return nil, nil, "", false
+	}
+	mountPoint, err := ctr.Mount()
+	if err != nil {
+		utils.InternalServerError(w, errors.Wrapf(err, "failed to mount container %s", name))
+		return nil, nil, "", false
+	}
+	dir, err := cp.coreDumpDir(ctr, mountPoint)
+	if err != nil {
+		unmountCoreDumps(ctr)
+		utils.InternalServerError(w, err)
+		return nil, nil, "", false
+	}
+	return ctr, cp, dir, true
+}
+
+func unmountCoreDumps(ctr *libpod.Container) {
+	if err := ctr.Unmount(false); err != nil {
+		logrus.Errorf("Unable to unmount container %s: %v", ctr.ID(), err)
+	}
+}
+
+// ListCoreDumps lists the core dumps stored in the container by the kernel,
+// when the core pattern of the host stores them in files.
+func ListCoreDumps(w http.ResponseWriter, r *http.Request) {
+	ctr, cp, dir, ok := mountCoreDumps(w, r)
+	if !ok {
+		return
+	}
+	defer unmountCoreDumps(ctr)
+
+	dumps, err := cp.coreDumps(dir)
+	if err != nil {
+		utils.InternalServerError(w, errors.Wrapf(err, "failed to list core dumps of container %s", ctr.ID()))
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, dumps)
+}
+
+// GetCoreDump streams a core dump stored in the container.
+func GetCoreDump(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if id == "" || strings.ContainsRune(id, '/') || id == "." || id == ".." {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, errors.Errorf("invalid core dump id %q", id))
+		return
+	}
+	ctr, cp, dir, ok := mountCoreDumps(w, r)
+	if !ok {
+		return
+	}
+	defer unmountCoreDumps(ctr)
+
+	if !cp.name.MatchString(id) {
+		utils.Error(w, "Something went wrong.", http.StatusNotFound, errors.Errorf("no core dump %s in container %s", id, ctr.ID()))
+		return
+	}
+	// The dump is written by a process of the container, do not follow
+	// symlinks out of it.
+	f, err := os.OpenFile(filepath.Join(dir, id), os.O_RDONLY|unix.O_NOFOLLOW, 0)
+	if err != nil {
+		if os.IsNotExist(err) || errors.Is(err, unix.ELOOP) {
+			utils.Error(w, "Something went wrong.", http.StatusNotFound, errors.Errorf("no core dump %s in container %s", id, ctr.ID()))
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if !info.Mode().IsRegular() {
+		utils.Error(w, "Something went wrong.", http.StatusNotFound, errors.Errorf("no core dump %s in container %s", id, ctr.ID()))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Length", strconv.FormatInt(info.Size(), 10))
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, f); err != nil {
+		logrus.Errorf("Unable to write core dump %s of container %s: %v", id, ctr.ID(), err)
+	}
+}
@@ -1,37 +1,181 @@
 package libpod
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"io/ioutil"
 	"net/http"
+	"text/template"
 
 	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
 	"github.com/containers/podman/v3/pkg/api/handlers/utils"
 	"github.com/containers/podman/v3/pkg/domain/entities"
 	"github.com/containers/podman/v3/pkg/specgen"
 	"github.com/containers/podman/v3/pkg/specgen/generate"
+	"github.com/gorilla/schema"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 // CreateContainer takes a specgenerator and makes a container. It returns
 // the new container ID on success along with any warnings.
 func CreateContainer(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		PortReservation string `schema:"port-reservation"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
 	var sg specgen.SpecGenerator
 	if err := json.NewDecoder(r.Body).Decode(&sg); err != nil {
 		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, errors.Wrap(err, "Decode()"))
 		return
 	}
+	if err := utils.CheckReservedPorts(runtime, sg.PortMappings, query.PortReservation); err != nil {
+		utils.Error(w, "Something went wrong.", http.StatusConflict, err)
+		return
+	}
+	if query.PortReservation != "" {
+		if err := utils.ClaimPortReservation(sg.PortMappings, query.PortReservation); err != nil {
+			utils.BadRequest(w, "port-reservation", query.PortReservation, err)
+			return
+		}
+	}
 	warn, err := generate.CompleteSpec(r.Context(), runtime, &sg)
 	if err != nil {
+		utils.UnclaimPortReservation(query.PortReservation)
 		utils.InternalServerError(w, err)
 		return
 	}
 	ctr, err := generate.MakeContainer(context.Background(), runtime, &sg)
 	if err != nil {
+		utils.UnclaimPortReservation(query.PortReservation)
 		utils.InternalServerError(w, err)
 		return
 	}
+	if query.PortReservation != "" {
+		utils.HoldPortReservation(query.PortReservation, ctr.ID())
+	}
 	response := entities.ContainerCreateResponse{ID: ctr.ID(), Warnings: warn}
 	utils.WriteJSON(w, http.StatusCreated, response)
 }
+
+// maxBatchContainers is the number of containers a batch create makes at
+// most.
+const maxBatchContainers = 1024
+
+// CreateContainerBatch makes count containers from a single specgenerator,
+// naming them from a template. Either all containers are created or, on
+// failure, the containers created so far are removed again.
+func CreateContainerBatch(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Count        int    `schema:"count"`
+		NameTemplate string `schema:"name-template"`
+	}{
+		// override any golang type defaults
+	}
+
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	if query.Count < 1 || query.Count > maxBatchContainers {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Errorf("count must be between 1 and %d, got %d", maxBatchContainers, query.Count))
+		return
+	}
+	names, err := batchContainerNames(query.NameTemplate, query.Count)
+	if err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, err)
+		return
+	}
+
+	// Every container needs its own spec, as completing and making the
+	// container modify it.
+	spec, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		utils.InternalServerError(w, errors.Wrap(err, "failed to read request body"))
+		return
+	}
+	if err := json.Unmarshal(spec, &specgen.SpecGenerator{}); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, errors.Wrap(err, "Decode()"))
+		return
+	}
+
+	response := entities.ContainerCreateBatchResponse{
+		IDs:      make([]string, 0, query.Count),
+		Names:    make([]string, 0, query.Count),
+		Warnings: []string{},
+	}
+	created := make([]*libpod.Container, 0, query.Count)
+	for _, name := range names {
+		var sg specgen.SpecGenerator
+		if err := json.Unmarshal(spec, &sg); err != nil {
+			utils.InternalServerError(w, errors.Wrap(err, "Decode()"))
+			return
+		}
+		sg.Name = name
+		warn, err := generate.CompleteSpec(r.Context(), runtime, &sg)
+		if err == nil {
+			var ctr *libpod.Container
+			ctr, err = generate.MakeContainer(context.Background(), runtime, &sg)
+			if err == nil {
+				created = append(created, ctr)
+				response.IDs = append(response.IDs, ctr.ID())
+				response.Names = append(response.Names, ctr.Name())
+				response.Warnings = append(response.Warnings, warn...)
+				continue
+			}
+		}
+
+		for _, ctr := range created {
+			if rmErr := runtime.RemoveContainer(context.Background(), ctr, true, true); rmErr != nil {
+				logrus.Errorf("Unable to remove container %s after failed batch create: %v", ctr.ID(), rmErr)
+			}
+		}
+		utils.InternalServerError(w, errors.Wrapf(err, "error creating container %s, removed %d containers created before", name, len(created)))
+		return
+	}
+	utils.WriteJSON(w, http.StatusCreated, response)
+}
+
+// batchContainerNames renders the name template for count containers,
+// indexed starting at 1, and verifies the names are valid and unique.
+func batchContainerNames(nameTemplate string, count int) ([]string, error) {
+	if nameTemplate == "" {
+		return nil, errors.New("name-template must be set")
+	}
+	tmpl, err := template.New("name").Parse(nameTemplate)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid name-template %q", nameTemplate)
+	}
+	names := make([]string, 0, count)
+	seen := make(map[string]bool, count)
+	for i := 1; i <= count; i++ {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, struct{ Index int }{Index: i}); err != nil {
+			return nil, errors.Wrapf(err, "error executing name-template %q", nameTemplate)
+		}
+		name := buf.String()
+		if !define.NameRegex.MatchString(name) {
+			return nil, errors.Wrapf(define.RegexError, "name-template %q produced invalid name %q", nameTemplate, name)
+		}
+		if seen[name] {
+			return nil, errors.Errorf("name-template %q does not produce unique names, %q is repeated", nameTemplate, name)
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names, nil
+}
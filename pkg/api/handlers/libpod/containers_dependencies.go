@@ -0,0 +1,159 @@
+package libpod
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/gorilla/schema"
+	"github.com/pkg/errors"
+)
+
+// ContainerDependencies returns the graph of containers the given container
+// depends on and, optionally, the containers depending on it.
+func ContainerDependencies(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Recursive  bool `schema:"recursive"`
+		Dependents bool `schema:"dependents"`
+	}{
+		// override any golang type defaults
+	}
+
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+
+	graph := newDependencyGraph()
+	if err := graph.addNode(ctr); err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+
+	// Walk towards the containers we depend on.
+	queue := []*libpod.Container{ctr}
+	visited := map[string]bool{ctr.ID(): true}
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		for id, types := range c.DependencyTypes() {
+			dep, err := runtime.GetContainer(id)
+			if err != nil {
+				utils.InternalServerError(w, errors.Wrapf(err, "error retrieving dependency %s of container %s", id, c.ID()))
+				return
+			}
+			if err := graph.addNode(dep); err != nil {
+				utils.InternalServerError(w, err)
+				return
+			}
+			graph.addEdge(c.ID(), dep.ID(), types)
+			if query.Recursive && !visited[dep.ID()] {
+				visited[dep.ID()] = true
+				queue = append(queue, dep)
+			}
+		}
+	}
+
+	// Walk towards the containers depending on us.
+	if query.Dependents {
+		queue = []*libpod.Container{ctr}
+		visited = map[string]bool{ctr.ID(): true}
+		for len(queue) > 0 {
+			c := queue[0]
+			queue = queue[1:]
+			ids, err := c.Dependents()
+			if err != nil {
+				utils.InternalServerError(w, errors.Wrapf(err, "error retrieving dependents of container %s", c.ID()))
+				return
+			}
+			for _, id := range ids {
+				dep, err := runtime.GetContainer(id)
+				if err != nil {
+					utils.InternalServerError(w, errors.Wrapf(err, "error retrieving dependent %s of container %s", id, c.ID()))
+					return
+				}
+				if err := graph.addNode(dep); err != nil {
+					utils.InternalServerError(w, err)
+					return
+				}
+				graph.addEdge(dep.ID(), c.ID(), dep.DependencyTypes()[c.ID()])
+				if query.Recursive && !visited[dep.ID()] {
+					visited[dep.ID()] = true
+					queue = append(queue, dep)
+				}
+			}
+		}
+	}
+
+	utils.WriteResponse(w, http.StatusOK, graph.report())
+}
+
+type dependencyGraph struct {
+	nodes map[string]entities.ContainerDependencyNode
+	edges map[[2]string]entities.ContainerDependencyEdge
+}
+
+func newDependencyGraph() *dependencyGraph {
+	return &dependencyGraph{
+		nodes: make(map[string]entities.ContainerDependencyNode),
+		edges: make(map[[2]string]entities.ContainerDependencyEdge),
+	}
+}
+
+func (g *dependencyGraph) addNode(ctr *libpod.Container) error {
+	if _, ok := g.nodes[ctr.ID()]; ok {
+		return nil
+	}
+	state, err := ctr.State()
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving state of container %s", ctr.ID())
+	}
+	g.nodes[ctr.ID()] = entities.ContainerDependencyNode{
+		ID:    ctr.ID(),
+		Name:  ctr.Name(),
+		State: state.String(),
+	}
+	return nil
+}
+
+func (g *dependencyGraph) addEdge(from, to string, types []string) {
+	sorted := append([]string{}, types...)
+	sort.Strings(sorted)
+	g.edges[[2]string{from, to}] = entities.ContainerDependencyEdge{
+		From:  from,
+		To:    to,
+		Types: sorted,
+	}
+}
+
+func (g *dependencyGraph) report() *entities.ContainerDependencyReport {
+	report := &entities.ContainerDependencyReport{
+		Nodes: make([]entities.ContainerDependencyNode, 0, len(g.nodes)),
+		Edges: make([]entities.ContainerDependencyEdge, 0, len(g.edges)),
+	}
+	for _, n := range g.nodes {
+		report.Nodes = append(report.Nodes, n)
+	}
+	for _, e := range g.edges {
+		report.Edges = append(report.Edges, e)
+	}
+	sort.Slice(report.Nodes, func(i, j int) bool { return report.Nodes[i].ID < report.Nodes[j].ID })
+	sort.Slice(report.Edges, func(i, j int) bool {
+		if report.Edges[i].From != report.Edges[j].From {
+			return report.Edges[i].From < report.Edges[j].From
+		}
+		return report.Edges[i].To < report.Edges[j].To
+	})
+	return report
+}
@@ -0,0 +1,199 @@
+package libpod
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/gorilla/schema"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// kmsgPath is the device exposing the kernel ring buffer one record per read.
+const kmsgPath = "/dev/kmsg"
+
+// kmsgPidRegex matches the PIDs the kernel names in OOM and similar messages,
+// e.g. "Killed process 1234 (...)" or "...,pid=1234,...".
+var kmsgPidRegex = regexp.MustCompile(`(?:process |pid=)(\d+)\b`)
+
+// ContainerKernelMessages writes the kernel messages related to a container,
+// and with stream set keeps writing new ones as they are logged.
+func ContainerKernelMessages(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Stream bool `schema:"stream"`
+	}{
+		// override any golang type defaults
+	}
+
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+
+	fd, err := unix.Open(kmsgPath, unix.O_RDONLY|unix.O_NONBLOCK|unix.O_CLOEXEC, 0)
+	if err != nil {
+		if err == unix.EPERM || err == unix.EACCES {
+			utils.Error(w, "kernel log not readable", http.StatusNotImplemented,
+				errors.Wrapf(err, "cannot read %s", kmsgPath))
+			return
+		}
+		utils.InternalServerError(w, errors.Wrapf(err, "cannot open %s", kmsgPath))
+		return
+	}
+	defer unix.Close(fd)
+
+	matcher := newKmsgMatcher(ctr)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+
+	coder := json.NewEncoder(w)
+	coder.SetEscapeHTML(true)
+
+	// Every read returns exactly one record, which is at most 8k.
+	buf := make([]byte, 8192)
+	for {
+		n, err := unix.Read(fd, buf)
+		switch err {
+		case nil:
+		case unix.EAGAIN:
+			if !query.Stream {
+				return
+			}
+			// Wait for new records, regularly checking whether
+			// the client went away.
+			fds := []unix.PollFd{{Fd: int32(fd), Events: unix.POLLIN}}
+			if _, err := unix.Poll(fds, 1000); err != nil && err != unix.EINTR {
+				logrus.Errorf("Unable to poll %s: %v", kmsgPath, err)
+				return
+			}
+			if r.Context().Err() != nil {
+				return
+			}
+			continue
+		case unix.EPIPE:
+			// The record was overwritten before we got to it,
+			// continue with the next one.
+			continue
+		case unix.EINTR:
+			continue
+		default:
+			logrus.Errorf("Unable to read %s: %v", kmsgPath, err)
+			return
+		}
+
+		msg, err := parseKmsgRecord(buf[:n])
+		if err != nil {
+			logrus.Debugf("Skipping kernel log record: %v", err)
+			continue
+		}
+		if !matcher.match(msg.Message) {
+			continue
+		}
+		if err := coder.Encode(msg); err != nil {
+			logrus.Errorf("Unable to encode kernel message: %v", err)
+			return
+		}
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+	}
+}
+
+// parseKmsgRecord parses a /dev/kmsg record of the form
+// "<prefix>,<seq>,<timestamp>,<flags>[,...];<message>\n[ KEY=VALUE\n...]".
+func parseKmsgRecord(record []byte) (*entities.ContainerKernelMessage, error) {
+	sep := bytes.IndexByte(record, ';')
+	if sep < 0 {
+		return nil, errors.Errorf("invalid record %q", string(record))
+	}
+	fields := strings.Split(string(record[:sep]), ",")
+	if len(fields) < 3 {
+		return nil, errors.Errorf("invalid record header %q", string(record[:sep]))
+	}
+	prefix, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid record prefix %q", fields[0])
+	}
+	seq, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid record sequence %q", fields[1])
+	}
+	timestamp, err := strconv.ParseUint(fields[2], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid record timestamp %q", fields[2])
+	}
+	message := record[sep+1:]
+	if end := bytes.IndexByte(message, '\n'); end >= 0 {
+		message = message[:end]
+	}
+	return &entities.ContainerKernelMessage{
+		Level:     prefix & 7,
+		Sequence:  seq,
+		Timestamp: timestamp,
+		Message:   string(message),
+	}, nil
+}
+
+// kmsgMatcher decides whether a kernel message relates to a container. The
+// kernel refers to containers by their cgroup, which contains the container
+// ID, and to their processes by PID.
+type kmsgMatcher struct {
+	patterns []string
+	pids     map[string]bool
+}
+
+func newKmsgMatcher(ctr *libpod.Container) *kmsgMatcher {
+	m := &kmsgMatcher{
+		patterns: []string{ctr.ID()},
+		pids:     make(map[string]bool),
+	}
+	if cgroupPath, err := ctr.CGroupPath(); err == nil && cgroupPath != "" {
+		m.patterns = append(m.patterns, cgroupPath)
+	}
+	if pid, err := ctr.PID(); err == nil && pid > 0 {
+		m.pids[fmt.Sprintf("%d", pid)] = true
+	}
+	return m
+}
+
+func (m *kmsgMatcher) match(message string) bool {
+	for _, p := range m.patterns {
+		if strings.Contains(message, p) {
+			// Remember the processes named by the message, so
+			// that following messages about them (e.g. "Killed
+			// process ...") are matched as well.
+			for _, pid := range kmsgPidRegex.FindAllStringSubmatch(message, -1) {
+				m.pids[pid[1]] = true
+			}
+			return true
+		}
+	}
+	for _, pid := range kmsgPidRegex.FindAllStringSubmatch(message, -1) {
+		if m.pids[pid[1]] {
+			return true
+		}
+	}
+	return false
+}
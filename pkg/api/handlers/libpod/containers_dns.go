@@ -0,0 +1,112 @@
+package libpod
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/containers/podman/v3/pkg/resolvconf"
+	securejoin "github.com/cyphar/filepath-securejoin"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// ContainerDNS reports the resolv.conf in effect in a container and the extra
+// hosts added to it.
+func ContainerDNS(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	report := entities.ContainerDNSReport{
+		ExtraHosts: ctr.HostsAdd(),
+	}
+	if report.ExtraHosts == nil {
+		report.ExtraHosts = []string{}
+	}
+
+	var path string
+	state, err := ctr.State()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if state == define.ContainerStateRunning || state == define.ContainerStatePaused {
+		// Read the file the container sees, it may have been changed from
+		// within.
+		pid, err := ctr.PID()
+		if err != nil {
+			utils.InternalServerError(w, err)
+			return
+		}
+		// The file is under the control of the container, resolve its
+		// symlinks within the root of the container and not the host's.
+		path, err = securejoin.SecureJoin(fmt.Sprintf("/proc/%d/root", pid), "/etc/resolv.conf")
+		if err != nil {
+			utils.InternalServerError(w, errors.Wrapf(err, "error resolving resolv.conf of container %s", ctr.ID()))
+			return
+		}
+		report.Source = "container"
+	} else {
+		bindMounts, err := ctr.BindMounts()
+		if err != nil {
+			utils.InternalServerError(w, err)
+			return
+		}
+		if p, ok := bindMounts["/etc/resolv.conf"]; ok {
+			path = p
+			report.Source = "resolv.conf"
+		}
+	}
+
+	if path == "" {
+		report.Source = "config"
+		for _, server := range ctr.DNSServers() {
+			report.Nameservers = append(report.Nameservers, server.String())
+		}
+		report.Search = ctr.DNSSearch()
+		report.Options = ctr.DNSOption()
+	} else {
+		content, err := readResolvConf(path)
+		if err != nil {
+			utils.InternalServerError(w, errors.Wrapf(err, "error reading resolv.conf of container %s", ctr.ID()))
+			return
+		}
+		report.ResolvConf = string(content)
+		report.Nameservers = resolvconf.GetNameservers(content)
+		report.Search = resolvconf.GetSearchDomains(content)
+		report.Options = resolvconf.GetOptions(content)
+	}
+	for _, list := range []*[]string{&report.Nameservers, &report.Search, &report.Options} {
+		if *list == nil {
+			*list = []string{}
+		}
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+// readResolvConf reads a resolv.conf which was resolved already, without
+// following a symlink swapped in meanwhile or blocking on a FIFO.
+func readResolvConf(path string) ([]byte, error) {
+	f, err := os.OpenFile(path, os.O_RDONLY|unix.O_NOFOLLOW|unix.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, errors.Errorf("%s is not a regular file", path)
+	}
+	return ioutil.ReadAll(f)
+}
@@ -0,0 +1,220 @@
+package libpod
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/containers/podman/v3/pkg/specgen"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+)
+
+const (
+	driftSourceImage     = "image"
+	driftSourceContainer = "container"
+)
+
+// ContainerDrift compares a container against a desired spec and reports
+// the differences.  Only settings given in the desired spec are compared,
+// settings left out of it cannot drift.
+func ContainerDrift(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	var desired specgen.SpecGenerator
+	if err := json.NewDecoder(r.Body).Decode(&desired); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, errors.Wrap(err, "Decode()"))
+		return
+	}
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+
+	config := ctr.Config()
+	imageConfig := &v1.ImageConfig{}
+	if config.RootfsImageID != "" {
+		if img, err := runtime.ImageRuntime().NewFromLocal(config.RootfsImageID); err == nil {
+			if data, err := img.InspectNoSize(r.Context()); err == nil && data.Config != nil {
+				imageConfig = data.Config
+			}
+		}
+	}
+
+	d := drifts{}
+	if desired.Name != "" {
+		d.compare("Name", ctr.Name(), desired.Name, driftSourceContainer)
+	}
+	if desired.Image != "" {
+		// The same image may be referred to by different names.
+		desiredID := desired.Image
+		if img, err := runtime.ImageRuntime().NewFromLocal(desired.Image); err == nil {
+			desiredID = img.ID()
+		}
+		if desiredID != config.RootfsImageID {
+			d.add("Image", config.RootfsImageName, desired.Image, driftSourceContainer)
+		}
+	}
+	if desired.Entrypoint != nil {
+		d.compare("Entrypoint", ctr.Entrypoint(), desired.Entrypoint, driftSource(stringSlicesEqual(ctr.Entrypoint(), imageConfig.Entrypoint)))
+	}
+	if desired.Command != nil {
+		d.compare("Command", ctr.Command(), desired.Command, driftSource(stringSlicesEqual(ctr.Command(), imageConfig.Cmd)))
+	}
+	if desired.WorkDir != "" {
+		imageWorkDir := imageConfig.WorkingDir
+		if imageWorkDir == "" {
+			imageWorkDir = "/"
+		}
+		d.compare("WorkDir", ctr.WorkingDir(), desired.WorkDir, driftSource(ctr.WorkingDir() == imageWorkDir))
+	}
+	if desired.User != "" {
+		d.compare("User", ctr.User(), desired.User, driftSource(ctr.User() == imageConfig.User))
+	}
+	if desired.Hostname != "" {
+		d.compare("Hostname", ctr.Hostname(), desired.Hostname, driftSourceContainer)
+	}
+	if desired.RestartPolicy != "" {
+		d.compare("RestartPolicy", ctr.RestartPolicy(), desired.RestartPolicy, driftSourceContainer)
+	}
+	if desired.RestartRetries != nil {
+		d.compare("RestartRetries", ctr.RestartRetries(), *desired.RestartRetries, driftSourceContainer)
+	}
+
+	if len(desired.Env) > 0 {
+		env := make(map[string]string)
+		if config.Spec.Process != nil {
+			env = envToMap(config.Spec.Process.Env)
+		}
+		imageEnv := envToMap(imageConfig.Env)
+		for key, value := range desired.Env {
+			current, ok := env[key]
+			imageValue, inImage := imageEnv[key]
+			d.compareMapValue("Env."+key, current, ok, value, driftSource(inImage && imageValue == current))
+		}
+	}
+	if len(desired.Labels) > 0 {
+		for key, value := range desired.Labels {
+			current, ok := config.Labels[key]
+			imageValue, inImage := imageConfig.Labels[key]
+			d.compareMapValue("Labels."+key, current, ok, value, driftSource(inImage && imageValue == current))
+		}
+	}
+
+	if desired.ResourceLimits != nil {
+		current := &spec.LinuxResources{}
+		if config.Spec.Linux != nil && config.Spec.Linux.Resources != nil {
+			current = config.Spec.Linux.Resources
+		}
+		if mem := desired.ResourceLimits.Memory; mem != nil {
+			var currentMem spec.LinuxMemory
+			if current.Memory != nil {
+				currentMem = *current.Memory
+			}
+			d.compareInt64("ResourceLimits.Memory.Limit", currentMem.Limit, mem.Limit)
+			d.compareInt64("ResourceLimits.Memory.Reservation", currentMem.Reservation, mem.Reservation)
+			d.compareInt64("ResourceLimits.Memory.Swap", currentMem.Swap, mem.Swap)
+		}
+		if cpu := desired.ResourceLimits.CPU; cpu != nil {
+			var currentCPU spec.LinuxCPU
+			if current.CPU != nil {
+				currentCPU = *current.CPU
+			}
+			if cpu.Shares != nil {
+				d.compare("ResourceLimits.CPU.Shares", derefUint64(currentCPU.Shares), *cpu.Shares, driftSourceContainer)
+			}
+			d.compareInt64("ResourceLimits.CPU.Quota", currentCPU.Quota, cpu.Quota)
+			if cpu.Period != nil {
+				d.compare("ResourceLimits.CPU.Period", derefUint64(currentCPU.Period), *cpu.Period, driftSourceContainer)
+			}
+			if cpu.Cpus != "" {
+				d.compare("ResourceLimits.CPU.Cpus", currentCPU.Cpus, cpu.Cpus, driftSourceContainer)
+			}
+		}
+		if pids := desired.ResourceLimits.Pids; pids != nil {
+			var currentPids int64
+			if current.Pids != nil {
+				currentPids = current.Pids.Limit
+			}
+			d.compare("ResourceLimits.Pids.Limit", currentPids, pids.Limit, driftSourceContainer)
+		}
+	}
+
+	sort.Slice(d, func(i, j int) bool { return d[i].Field < d[j].Field })
+	utils.WriteResponse(w, http.StatusOK, entities.ContainerDriftReport{Drifts: d})
+}
+
+// drifts collects the differences between a container and a desired spec.
+type drifts []entities.ContainerDrift
+
+func (d *drifts) add(field string, current, desired interface{}, source string) {
+	*d = append(*d, entities.ContainerDrift{
+		Field:   field,
+		Current: current,
+		Desired: desired,
+		Source:  source,
+	})
+}
+
+func (d *drifts) compare(field string, current, desired interface{}, source string) {
+	if !reflect.DeepEqual(current, desired) {
+		d.add(field, current, desired, source)
+	}
+}
+
+// compareMapValue compares an entry of a map, current is only set if the
+// container has the key.
+func (d *drifts) compareMapValue(field, current string, ok bool, desired, source string) {
+	switch {
+	case !ok:
+		d.add(field, nil, desired, driftSourceContainer)
+	case current != desired:
+		d.add(field, current, desired, source)
+	}
+}
+
+// compareInt64 compares an optional resource limit, unset current limits are
+// unlimited.
+func (d *drifts) compareInt64(field string, current, desired *int64) {
+	if desired == nil {
+		return
+	}
+	if current == nil {
+		d.add(field, nil, *desired, driftSourceContainer)
+		return
+	}
+	if *current != *desired {
+		d.add(field, *current, *desired, driftSourceContainer)
+	}
+}
+
+func driftSource(fromImage bool) string {
+	if fromImage {
+		return driftSourceImage
+	}
+	return driftSourceContainer
+}
+
+func envToMap(env []string) map[string]string {
+	m := make(map[string]string, len(env))
+	for _, e := range env {
+		if split := strings.SplitN(e, "=", 2); len(split) == 2 {
+			m[split[0]] = split[1]
+		}
+	}
+	return m
+}
+
+func derefUint64(v *uint64) uint64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
@@ -0,0 +1,193 @@
+package libpod
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	securejoin "github.com/cyphar/filepath-securejoin"
+	"github.com/fsnotify/fsnotify"
+	"github.com/gorilla/schema"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// maxFSAuditWatches is the number of directories a file system audit
+// watches at most, as inotify watches are limited per user on the host.
+const maxFSAuditWatches = 512
+
+// errTooManyWatches is returned by watchTree if the directories to watch
+// exceed maxFSAuditWatches.
+var errTooManyWatches = errors.Errorf("more than %d directories to watch, choose a smaller subtree with path", maxFSAuditWatches)
+
+// ContainerFSAudit streams changes to files in the root filesystem of a
+// container, optionally limited to the subtree below the given path.
+func ContainerFSAudit(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Path   string `schema:"path"`
+		Stream bool   `schema:"stream"`
+	}{
+		// override any golang type defaults
+		Path:   "/",
+		Stream: true,
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	if !query.Stream {
+		utils.BadRequest(w, "stream", "false", errors.New("file system audit events can only be streamed"))
+		return
+	}
+
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	mounted, mountPoint, err := ctr.Mounted()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if !mounted {
+		utils.Error(w, "Something went wrong.", http.StatusConflict,
+			errors.Wrapf(define.ErrCtrStateInvalid, "root filesystem of container %s is not mounted", ctr.ID()))
+		return
+	}
+	root, err := securejoin.SecureJoin(mountPoint, query.Path)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if _, err := os.Stat(root); err != nil {
+		if os.IsNotExist(err) {
+			utils.BadRequest(w, "path", query.Path, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		if errors.Is(err, unix.EPERM) || errors.Is(err, unix.EACCES) {
+			utils.Error(w, http.StatusText(http.StatusNotImplemented), http.StatusNotImplemented,
+				errors.Wrapf(define.ErrNotImplemented, "watching file system events is not permitted: %v", err))
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	defer watcher.Close()
+	watches := 0
+	if err := watchTree(watcher, root, &watches); err != nil {
+		if err == errTooManyWatches {
+			utils.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge, err)
+			return
+		}
+		utils.InternalServerError(w, errors.Wrapf(err, "error watching %s in container %s", query.Path, ctr.ID()))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	if flusher != nil {
+		flusher.Flush()
+	}
+	coder := json.NewEncoder(w)
+	coder.SetEscapeHTML(true)
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			logrus.Warnf("Error watching file system of container %s: %v", ctr.ID(), err)
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&fsnotify.Create == fsnotify.Create {
+				// Follow newly created directories.
+				if info, err := os.Lstat(event.Name); err == nil && info.IsDir() {
+					if err := watchTree(watcher, event.Name, &watches); err != nil {
+						logrus.Warnf("Error watching %s: %v", event.Name, err)
+					}
+				}
+			}
+			rel, err := filepath.Rel(mountPoint, event.Name)
+			if err != nil || strings.HasPrefix(rel, "..") {
+				continue
+			}
+			report := entities.ContainerFSAuditEvent{
+				Time: time.Now(),
+				Op:   fsAuditOp(event.Op),
+				Path: filepath.Join("/", rel),
+			}
+			if err := coder.Encode(report); err != nil {
+				logrus.Infof("Unable to write file system audit event: %v", err)
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}
+}
+
+// watchTree adds watches for the directory root and all directories below,
+// counting them in watches.  It stops with errTooManyWatches once
+// maxFSAuditWatches directories are watched.
+func watchTree(watcher *fsnotify.Watcher, root string, watches *int) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if *watches >= maxFSAuditWatches {
+			return errTooManyWatches
+		}
+		if err := watcher.Add(path); err != nil {
+			return err
+		}
+		*watches++
+		return nil
+	})
+}
+
+func fsAuditOp(op fsnotify.Op) string {
+	switch {
+	case op&fsnotify.Create == fsnotify.Create:
+		return "create"
+	case op&fsnotify.Remove == fsnotify.Remove:
+		return "delete"
+	case op&fsnotify.Rename == fsnotify.Rename:
+		return "rename"
+	case op&fsnotify.Write == fsnotify.Write:
+		return "modify"
+	default:
+		return "attrib"
+	}
+}
@@ -0,0 +1,184 @@
+package libpod
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/gorilla/schema"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// ioctl requests from linux/fs.h, _IOWR('X', 119, int) and
+	// _IOWR('X', 120, int).
+	ioctlFIFREEZE = 0xc0045877
+	ioctlFITHAW   = 0xc0045878
+
+	defaultFreezeTimeout = 30 * time.Second
+	maxFreezeTimeout     = 10 * time.Minute
+)
+
+// frozenFS is the frozen filesystem of a container, thawed by its timer at
+// the latest.
+type frozenFS struct {
+	root  *os.File
+	timer *time.Timer
+	thaw  time.Time
+}
+
+// frozenContainers are the containers with a frozen filesystem, by ID.
+var frozenContainers = struct {
+	lock sync.Mutex
+	byID map[string]*frozenFS
+}{
+	byID: make(map[string]*frozenFS),
+}
+
+// thawContainerFS thaws the filesystem of a container, if frozen.  It
+// returns false if the filesystem was not frozen.
+func thawContainerFS(id string) (bool, error) {
+	frozenContainers.lock.Lock()
+	defer frozenContainers.lock.Unlock()
+	fs, ok := frozenContainers.byID[id]
+	if !ok {
+		return false, nil
+	}
+	delete(frozenContainers.byID, id)
+	fs.timer.Stop()
+	defer fs.root.Close()
+	if err := unix.IoctlSetInt(int(fs.root.Fd()), ioctlFITHAW, 0); err != nil {
+		return true, errors.Wrapf(err, "failed to thaw filesystem of container %s", id)
+	}
+	return true, nil
+}
+
+// FreezeContainerFS freezes the root filesystem of a running container, so
+// it can be snapshotted consistently.  Writes block until the filesystem is
+// thawed again, at the latest after the timeout.
+func FreezeContainerFS(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Timeout string `schema:"timeout"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	timeout := defaultFreezeTimeout
+	if query.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(query.Timeout); err != nil {
+			utils.BadRequest(w, "timeout", query.Timeout, err)
+			return
+		}
+		if timeout <= 0 || timeout > maxFreezeTimeout {
+			utils.BadRequest(w, "timeout", query.Timeout, errors.Errorf("timeout must be positive and at most %s", maxFreezeTimeout))
+			return
+		}
+	}
+
+	ctr, ok := lookupRunningContainer(w, r, runtime)
+	if !ok {
+		return
+	}
+	mounted, mountPoint, err := ctr.Mounted()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if !mounted {
+		utils.Error(w, "Something went wrong.", http.StatusConflict, errors.Wrapf(define.ErrCtrStateInvalid, "container %s is not mounted", ctr.ID()))
+		return
+	}
+
+	// Freezing a directory of a filesystem shared with the host, as with
+	// the vfs driver, would freeze the host.
+	var st, parent unix.Stat_t
+	if err := unix.Stat(mountPoint, &st); err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if err := unix.Stat(filepath.Dir(mountPoint), &parent); err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if st.Dev == parent.Dev {
+		utils.Error(w, http.StatusText(http.StatusNotImplemented), http.StatusNotImplemented,
+			errors.Wrapf(define.ErrNotImplemented, "root filesystem of container %s is not a filesystem of its own", ctr.ID()))
+		return
+	}
+
+	frozenContainers.lock.Lock()
+	defer frozenContainers.lock.Unlock()
+	if _, ok := frozenContainers.byID[ctr.ID()]; ok {
+		utils.Error(w, "Something went wrong.", http.StatusConflict, errors.Wrapf(define.ErrCtrStateInvalid, "filesystem of container %s is already frozen", ctr.ID()))
+		return
+	}
+	root, err := os.Open(mountPoint)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if err := unix.IoctlSetInt(int(root.Fd()), ioctlFIFREEZE, 0); err != nil {
+		root.Close()
+		switch err {
+		case unix.EOPNOTSUPP, unix.ENOTTY, unix.EINVAL, unix.EPERM:
+			utils.Error(w, http.StatusText(http.StatusNotImplemented), http.StatusNotImplemented,
+				errors.Wrapf(define.ErrNotImplemented, "root filesystem of container %s cannot be frozen: %v", ctr.ID(), err))
+		case unix.EBUSY:
+			utils.Error(w, "Something went wrong.", http.StatusConflict, errors.Wrapf(define.ErrCtrStateInvalid, "root filesystem of container %s is frozen by someone else", ctr.ID()))
+		default:
+			utils.InternalServerError(w, errors.Wrapf(err, "failed to freeze filesystem of container %s", ctr.ID()))
+		}
+		return
+	}
+
+	id := ctr.ID()
+	fs := &frozenFS{
+		root: root,
+		thaw: time.Now().Add(timeout),
+	}
+	fs.timer = time.AfterFunc(timeout, func() {
+		logrus.Warnf("Thawing filesystem of container %s after %s", id, timeout)
+		if _, err := thawContainerFS(id); err != nil {
+			logrus.Errorf("Unable to thaw filesystem: %v", err)
+		}
+	})
+	frozenContainers.byID[id] = fs
+	utils.WriteResponse(w, http.StatusOK, entities.ContainerFSFreezeReport{Thaw: fs.thaw})
+}
+
+// ThawContainerFS thaws the root filesystem of a container frozen with
+// FreezeContainerFS.
+func ThawContainerFS(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	frozen, err := thawContainerFS(ctr.ID())
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if !frozen {
+		utils.Error(w, "Something went wrong.", http.StatusConflict, errors.Wrapf(define.ErrCtrStateInvalid, "filesystem of container %s is not frozen", ctr.ID()))
+		return
+	}
+	utils.WriteResponse(w, http.StatusNoContent, "")
+}
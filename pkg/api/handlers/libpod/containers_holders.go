@@ -0,0 +1,318 @@
+package libpod
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/containers/podman/v3/pkg/signal"
+	"github.com/gorilla/schema"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// Kinds of processes holding resources of a container.
+const (
+	holderConmon    = "conmon"
+	holderExec      = "exec"
+	holderRuntime   = "runtime"
+	holderPodman    = "podman"
+	holderContainer = "container"
+	holderOther     = "other"
+)
+
+// killableHolders are the kinds of holders killed by KillContainerHolders.
+// Other processes mentioning the container, podman ones included, are only
+// reported: they may work on the container legitimately.
+var killableHolders = map[string]bool{
+	holderConmon:    true,
+	holderExec:      true,
+	holderRuntime:   true,
+	holderContainer: true,
+}
+
+// containerHolders finds the host processes holding resources of a
+// container: its conmon and those of its exec sessions, OCI runtime and
+// podman processes working on it, and the processes in its cgroup.
+func containerHolders(ctr *libpod.Container) (*entities.ContainerHoldersReport, error) {
+	state, err := ctr.State()
+	if err != nil {
+		return nil, err
+	}
+	conmonPid, err := ctr.ConmonPID()
+	if err != nil {
+		return nil, err
+	}
+	report := entities.ContainerHoldersReport{
+		State:       state.String(),
+		ConmonPID:   conmonPid,
+		ConmonAlive: conmonPid > 0 && unix.Kill(conmonPid, 0) == nil,
+		Holders:     []entities.ContainerHolder{},
+	}
+
+	// Processes in the cgroup of the container are only found while its
+	// init process is alive, even if conmon is gone, and only if the
+	// container has a cgroup of its own.
+	inCgroup := make(map[int]bool)
+	initPid, err := ctr.PID()
+	if err != nil {
+		return nil, err
+	}
+	if initPid > 0 && isContainerInit(ctr, initPid, conmonPid, report.ConmonAlive) {
+		pids, err := containerProcesses(ctr)
+		if err != nil && errors.Cause(err) != define.ErrNoCgroups && !os.IsNotExist(errors.Cause(err)) {
+			return nil, err
+		}
+		for _, pid := range pids {
+			inCgroup[pid] = true
+		}
+	}
+
+	entries, err := ioutil.ReadDir("/proc")
+	if err != nil {
+		return nil, err
+	}
+	self := os.Getpid()
+	runtimeName := filepath.Base(ctr.Config().OCIRuntime)
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil || pid == self {
+			continue
+		}
+		cmdline, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/cmdline", pid))
+		if err != nil {
+			// The process is gone or not ours to look at.
+			continue
+		}
+		args := strings.Split(strings.TrimRight(string(cmdline), "\x00"), "\x00")
+		kind := ""
+		if inCgroup[pid] {
+			kind = holderContainer
+		} else if containsArg(args, ctr.ID()) {
+			switch filepath.Base(args[0]) {
+			case "conmon":
+				kind = holderConmon
+				if containsArg(args, "--exec") || containsArg(args, "-e") {
+					kind = holderExec
+				}
+			case "podman":
+				kind = holderPodman
+			case runtimeName, "runc", "crun":
+				kind = holderRuntime
+			default:
+				kind = holderOther
+			}
+		}
+		if kind == "" {
+			continue
+		}
+		report.Holders = append(report.Holders, entities.ContainerHolder{
+			PID:     pid,
+			PPID:    processParent(pid),
+			Kind:    kind,
+			Command: strings.Join(args, " "),
+		})
+	}
+	sort.Slice(report.Holders, func(i, j int) bool { return report.Holders[i].PID < report.Holders[j].PID })
+	return &report, nil
+}
+
+// containsArg reports whether an argument is or ends in value, as for
+// conmon's --cid=<id>.
+func containsArg(args []string, value string) bool {
+	for _, arg := range args {
+		if arg == value || strings.HasSuffix(arg, "="+value) {
+			return true
+		}
+	}
+	return false
+}
+
+// isContainerInit reports whether the process recorded as the init process
+// of the container still is, and not one which reused its PID: while conmon
+// lives, which reaps the processes of the container, it must be a child of
+// conmon, otherwise it must have started no later than the container.
+func isContainerInit(ctr *libpod.Container, pid, conmonPid int, conmonAlive bool) bool {
+	if conmonAlive {
+		return processParent(pid) == conmonPid
+	}
+	started, err := processStartTime(pid)
+	if err != nil {
+		return false
+	}
+	ctrStarted, err := ctr.StartedTime()
+	if err != nil {
+		return false
+	}
+	// The boot time is only known to the second.
+	return !started.After(ctrStarted.Add(time.Second))
+}
+
+// inContainerCgroup reports whether a process is in the cgroup dedicated to
+// the container with the given ID.
+func inContainerCgroup(pid int, id string) bool {
+	data, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.SplitN(line, ":", 3)
+		if len(fields) == 3 && dedicatedCgroup(fields[2], id) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// processStat returns the fields of /proc/<pid>/stat following the command
+// name, starting with the state (field 3).
+func processStat(pid int) ([]string, error) {
+	data, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return nil, err
+	}
+	// The command name may contain spaces.
+	end := bytes.LastIndexByte(data, ')')
+	if end < 0 {
+		return nil, errors.Errorf("invalid stat of PID %d", pid)
+	}
+	return strings.Fields(string(data[end+1:])), nil
+}
+
+func processParent(pid int) int {
+	fields, err := processStat(pid)
+	if err != nil || len(fields) < 2 {
+		return 0
+	}
+	ppid, _ := strconv.Atoi(fields[1])
+	return ppid
+}
+
+// userHZ is the unit of the times in /proc, fixed by the kernel ABI.
+const userHZ = 100
+
+// processStartTime returns when a process started, from its start time
+// (field 22 of stat) in clock ticks since boot.
+func processStartTime(pid int) (time.Time, error) {
+	fields, err := processStat(pid)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(fields) < 20 {
+		return time.Time{}, errors.Errorf("invalid stat of PID %d", pid)
+	}
+	ticks, err := strconv.ParseInt(fields[19], 10, 64)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "invalid start time of PID %d", pid)
+	}
+	boot, err := bootTime()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return boot.Add(time.Duration(ticks) * time.Second / userHZ), nil
+}
+
+func bootTime() (time.Time, error) {
+	data, err := ioutil.ReadFile("/proc/stat")
+	if err != nil {
+		return time.Time{}, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, "btime ") {
+			secs, err := strconv.ParseInt(strings.TrimSpace(strings.TrimPrefix(line, "btime ")), 10, 64)
+			if err != nil {
+				return time.Time{}, errors.Wrapf(err, "invalid boot time %q", line)
+			}
+			return time.Unix(secs, 0), nil
+		}
+	}
+	return time.Time{}, errors.New("no boot time in /proc/stat")
+}
+
+// ContainerHolders lists the host processes holding resources of a
+// container, for recovering containers whose conmon died.
+func ContainerHolders(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	report, err := containerHolders(ctr)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+// KillContainerHolders kills the conmon, OCI runtime and container processes
+// holding resources of a container which is stuck stopping or removing, or
+// whose conmon died.
+func KillContainerHolders(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Signal string `schema:"signal"`
+	}{
+		// override any golang type defaults
+		Signal: "KILL",
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	sig, err := signal.ParseSignalNameOrNumber(query.Signal)
+	if err != nil {
+		utils.BadRequest(w, "signal", query.Signal, err)
+		return
+	}
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	report, err := containerHolders(ctr)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	stuck := report.State == define.ContainerStateStopping.String() || report.State == define.ContainerStateRemoving.String()
+	if !stuck && report.ConmonAlive {
+		utils.Error(w, "Something went wrong.", http.StatusConflict,
+			errors.Wrapf(define.ErrCtrStateInvalid, "container %s is %s with its conmon alive, refusing to kill its holders", ctr.ID(), report.State))
+		return
+	}
+
+	killed := make([]entities.ContainerHolderKill, 0, len(report.Holders))
+	for _, holder := range report.Holders {
+		if !killableHolders[holder.Kind] {
+			continue
+		}
+		// The process may have exited and its PID been reused since the
+		// holders were listed.
+		if holder.Kind == holderContainer && !inContainerCgroup(holder.PID, ctr.ID()) {
+			continue
+		}
+		kill := entities.ContainerHolderKill{PID: holder.PID, Kind: holder.Kind}
+		if err := unix.Kill(holder.PID, sig); err != nil && err != unix.ESRCH {
+			kill.Err = err.Error()
+		}
+		killed = append(killed, kill)
+	}
+	utils.WriteResponse(w, http.StatusOK, killed)
+}
@@ -0,0 +1,77 @@
+package libpod
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/autoupdate"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/containers/podman/v3/pkg/domain/filters"
+	systemdGen "github.com/containers/podman/v3/pkg/systemd/generate"
+	"github.com/pkg/errors"
+)
+
+// runtimeLabels are the labels podman acts upon for existing containers.
+// Changing them is only allowed on containers which are not running, while
+// all other labels are plain metadata.
+var runtimeLabels = map[string]bool{
+	autoupdate.Label:         true,
+	autoupdate.AuthfileLabel: true,
+	systemdGen.EnvVariable:   true,
+}
+
+// LabelContainers adds and removes labels of all containers matching the
+// given filters.  Running containers are skipped with an error unless only
+// metadata labels are changed.
+func LabelContainers(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	var req entities.ContainersLabelBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, errors.Wrap(err, "Decode()"))
+		return
+	}
+	if len(req.Add) == 0 && len(req.Remove) == 0 {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, errors.New("no labels to add or remove given"))
+		return
+	}
+
+	metadataOnly := true
+	for k := range req.Add {
+		if k == "" {
+			utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, errors.New("label keys must not be empty"))
+			return
+		}
+		metadataOnly = metadataOnly && !runtimeLabels[k]
+	}
+	for _, k := range req.Remove {
+		metadataOnly = metadataOnly && !runtimeLabels[k]
+	}
+
+	filterFuncs := make([]libpod.ContainerFilter, 0, len(req.Filters))
+	for k, v := range req.Filters {
+		generatedFunc, err := filters.GenerateContainerFilterFuncs(k, v, runtime)
+		if err != nil {
+			utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, err)
+			return
+		}
+		filterFuncs = append(filterFuncs, generatedFunc)
+	}
+	ctrs, err := runtime.GetContainers(filterFuncs...)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+
+	reports := make([]entities.ContainerLabelReport, 0, len(ctrs))
+	for _, ctr := range ctrs {
+		report := entities.ContainerLabelReport{Id: ctr.ID(), Name: ctr.Name()}
+		if err := runtime.UpdateContainerLabels(context.Background(), ctr, req.Add, req.Remove, metadataOnly); err != nil {
+			report.Err = err.Error()
+		}
+		reports = append(reports, report)
+	}
+	utils.WriteResponse(w, http.StatusOK, reports)
+}
@@ -0,0 +1,208 @@
+package libpod
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/logs"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/containers/podman/v3/pkg/util"
+	"github.com/gorilla/schema"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// LogsFromContainers interleaves the logs of an arbitrary set of containers
+// into a single stream of JSON objects, each tagged with the container it came
+// from.  Containers exiting while following do not end the stream.
+func LogsFromContainers(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	var req entities.ContainersLogsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, errors.Wrap(err, "Decode()"))
+		return
+	}
+	if len(req.Containers) == 0 {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, errors.New("no containers given"))
+		return
+	}
+	tail := int64(-1)
+	if req.Tail != nil {
+		tail = *req.Tail
+	}
+	var since time.Time
+	if req.Since != "" {
+		var err error
+		if since, err = util.ParseInputTime(req.Since); err != nil {
+			utils.BadRequest(w, "since", req.Since, err)
+			return
+		}
+	}
+
+	ctrs := make([]*libpod.Container, 0, len(req.Containers))
+	colors := make(map[string]int, len(req.Containers))
+	for _, name := range req.Containers {
+		ctr, err := runtime.LookupContainer(name)
+		if err != nil {
+			utils.ContainerNotFound(w, name, err)
+			return
+		}
+		if _, ok := colors[ctr.ID()]; ok {
+			continue
+		}
+		colors[ctr.ID()] = len(ctrs)
+		ctrs = append(ctrs, ctr)
+	}
+	names := make(map[string]string, len(ctrs))
+	for _, ctr := range ctrs {
+		names[ctr.ID()] = ctr.Name()
+	}
+
+	var wg sync.WaitGroup
+	options := &logs.LogOptions{
+		Details:   true,
+		Follow:    req.Follow,
+		Since:     since,
+		Tail:      tail,
+		Multi:     true,
+		WaitGroup: &wg,
+	}
+	logChannel := make(chan *logs.LogLine, 64)
+	if err := runtime.Log(r.Context(), ctrs, options, logChannel); err != nil {
+		utils.InternalServerError(w, errors.Wrap(err, "failed to obtain logs"))
+		return
+	}
+	go func() {
+		wg.Wait()
+		close(logChannel)
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	if flusher != nil {
+		flusher.Flush()
+	}
+	coder := json.NewEncoder(w)
+	coder.SetEscapeHTML(true)
+	for line := range logChannel {
+		entry := entities.ContainersLogLine{
+			Container: line.CID,
+			Name:      names[line.CID],
+			Color:     colors[line.CID],
+			Stream:    line.Device,
+			Time:      line.Time,
+			Msg:       line.Msg,
+		}
+		if err := coder.Encode(entry); err != nil {
+			logrus.Infof("Unable to write log line: %v", err)
+			// Drain the channel so the readers can finish.
+			for range logChannel {
+			}
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
+
+// LogsFromContainerSSE streams the logs of a container as Server-Sent Events,
+// one event per line with the stream the line came from as event type.
+func LogsFromContainerSSE(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Follow bool   `schema:"follow"`
+		Since  string `schema:"since"`
+		Tail   string `schema:"tail"`
+	}{
+		// override any golang type defaults
+		Tail: "all",
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+
+	tail := int64(-1)
+	if query.Tail != "all" {
+		if tail, err = strconv.ParseInt(query.Tail, 0, 64); err != nil {
+			utils.BadRequest(w, "tail", query.Tail, err)
+			return
+		}
+	}
+	var since time.Time
+	if query.Since != "" {
+		if since, err = util.ParseInputTime(query.Since); err != nil {
+			utils.BadRequest(w, "since", query.Since, err)
+			return
+		}
+	}
+
+	var wg sync.WaitGroup
+	options := &logs.LogOptions{
+		Details:   true,
+		Follow:    query.Follow,
+		Since:     since,
+		Tail:      tail,
+		WaitGroup: &wg,
+	}
+	logChannel := make(chan *logs.LogLine, 64)
+	if err := runtime.Log(r.Context(), []*libpod.Container{ctr}, options, logChannel); err != nil {
+		utils.InternalServerError(w, errors.Wrapf(err, "failed to obtain logs for container %s", name))
+		return
+	}
+	go func() {
+		wg.Wait()
+		close(logChannel)
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	if flusher != nil {
+		flusher.Flush()
+	}
+	var event strings.Builder
+	for line := range logChannel {
+		event.Reset()
+		event.WriteString("event: ")
+		event.WriteString(line.Device)
+		event.WriteString("\n")
+		// A newline would end the data field, send each line of a
+		// message as a data field of its own.
+		for _, data := range strings.Split(line.Msg, "\n") {
+			event.WriteString("data: ")
+			event.WriteString(data)
+			event.WriteString("\n")
+		}
+		event.WriteString("\n")
+		if _, err := io.WriteString(w, event.String()); err != nil {
+			logrus.Infof("Unable to write log event: %v", err)
+			// Drain the channel so the readers can finish.
+			for range logChannel {
+			}
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package libpod
+
+import (
+	"net/http"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/gorilla/schema"
+	"github.com/pkg/errors"
+)
+
+// MigrateContainerStorage moves the writable layer of a stopped container
+// to another storage location.
+func MigrateContainerStorage(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		GraphRoot string `schema:"graphroot"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	if err := runtime.MigrateContainerStorage(r.Context(), ctr, query.GraphRoot); err != nil {
+		switch errors.Cause(err) {
+		case define.ErrInvalidArg:
+			utils.BadRequest(w, "graphroot", query.GraphRoot, err)
+		case define.ErrCtrStateInvalid:
+			utils.Error(w, "Something went wrong.", http.StatusConflict, err)
+		case define.ErrNotImplemented:
+			utils.Error(w, "storage migration not supported", http.StatusNotImplemented, err)
+		default:
+			utils.InternalServerError(w, err)
+		}
+		return
+	}
+	utils.WriteResponse(w, http.StatusNoContent, "")
+}
@@ -0,0 +1,144 @@
+package libpod
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+)
+
+// defaultPropagation is the propagation of mounts which do not specify one.
+const defaultPropagation = "rprivate"
+
+// ContainerMounts lists the user mounts of a container, including tmpfs
+// mounts, with their propagation.  For running containers, the propagation
+// in effect is read from the kernel as well.
+func ContainerMounts(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	data, err := ctr.Inspect(false)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+
+	mounts := make([]entities.ContainerMount, 0, len(data.Mounts))
+	for _, m := range data.Mounts {
+		mounts = append(mounts, entities.ContainerMount{InspectMount: m})
+	}
+	// Inspect leaves out tmpfs mounts.
+	config := ctr.Config()
+	userVolumes := make(map[string]bool, len(config.UserVolumes))
+	for _, dest := range config.UserVolumes {
+		userVolumes[dest] = true
+	}
+	for _, m := range config.Spec.Mounts {
+		if m.Type != "tmpfs" || !userVolumes[m.Destination] {
+			continue
+		}
+		mount := entities.ContainerMount{InspectMount: define.InspectMount{
+			Type:        "tmpfs",
+			Source:      m.Source,
+			Destination: m.Destination,
+			RW:          true,
+		}}
+		for _, opt := range m.Options {
+			switch opt {
+			case "ro":
+				mount.RW = false
+			case "rw":
+			case "shared", "slave", "private", "rshared", "rslave", "rprivate", "unbindable", "runbindable":
+				mount.Propagation = opt
+			default:
+				mount.Options = append(mount.Options, opt)
+			}
+		}
+		mounts = append(mounts, mount)
+	}
+	for i := range mounts {
+		if mounts[i].Propagation == "" && mounts[i].Type != "image" {
+			mounts[i].Propagation = defaultPropagation
+		}
+	}
+
+	if data.State != nil && (data.State.Running || data.State.Paused) {
+		propagation, err := mountPropagation(data.State.Pid)
+		if err != nil {
+			utils.InternalServerError(w, err)
+			return
+		}
+		for i := range mounts {
+			mounts[i].ActivePropagation = propagation[mounts[i].Destination]
+		}
+	}
+	utils.WriteResponse(w, http.StatusOK, mounts)
+}
+
+// mountPropagation maps the mount points of a process to their propagation
+// type as reported by /proc/<pid>/mountinfo.
+func mountPropagation(pid int) (map[string]string, error) {
+	f, err := os.Open(fmt.Sprintf("/proc/%d/mountinfo", pid))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	propagation := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// ID parent major:minor root mountpoint options [optional...] - type source superoptions
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 7 {
+			continue
+		}
+		mode := "private"
+		for _, field := range fields[6:] {
+			if field == "-" {
+				break
+			}
+			switch {
+			case strings.HasPrefix(field, "shared:"):
+				mode = "shared"
+			case strings.HasPrefix(field, "master:") && mode != "shared":
+				mode = "slave"
+			case field == "unbindable":
+				mode = "unbindable"
+			}
+		}
+		// Later mounts on the same mount point hide earlier ones.
+		propagation[unescapeMountInfo(fields[4])] = mode
+	}
+	return propagation, scanner.Err()
+}
+
+// unescapeMountInfo decodes the octal escapes of white space and backslashes
+// in mountinfo paths.
+func unescapeMountInfo(path string) string {
+	if !strings.Contains(path, `\`) {
+		return path
+	}
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		if path[i] == '\\' && i+3 < len(path) {
+			if c, err := strconv.ParseUint(path[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(path[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,82 @@
+package libpod
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/pkg/errors"
+)
+
+// namespaceTypes are the entries of /proc/<pid>/ns reported for containers.
+var namespaceTypes = []string{"cgroup", "ipc", "mnt", "net", "pid", "user", "uts"}
+
+// ContainerNamespaces reports the namespace inodes and the cgroup path of a
+// running container, to allow correlating kernel events with it.
+func ContainerNamespaces(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	state, err := ctr.State()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if state != define.ContainerStateRunning && state != define.ContainerStatePaused {
+		utils.ContainerNotRunning(w, ctr.ID(), errors.Wrapf(define.ErrCtrStateInvalid, "container %s is not running", ctr.ID()))
+		return
+	}
+	pid, err := ctr.PID()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	cgroupPath, err := ctr.CGroupPath()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+
+	report := entities.ContainerNamespacesReport{
+		PID:        pid,
+		CgroupPath: cgroupPath,
+		Namespaces: make(map[string]uint64, len(namespaceTypes)),
+	}
+	for _, nsType := range namespaceTypes {
+		inode, err := namespaceInode(pid, nsType)
+		if err != nil {
+			if os.IsNotExist(err) {
+				// The kernel does not support this namespace type.
+				continue
+			}
+			utils.InternalServerError(w, err)
+			return
+		}
+		report.Namespaces[nsType] = inode
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+// namespaceInode returns the inode of a namespace of a process, as read from
+// the "<type>:[<inode>]" link in /proc/<pid>/ns.
+func namespaceInode(pid int, nsType string) (uint64, error) {
+	link, err := os.Readlink(fmt.Sprintf("/proc/%d/ns/%s", pid, nsType))
+	if err != nil {
+		return 0, err
+	}
+	prefix := nsType + ":["
+	if !strings.HasPrefix(link, prefix) || !strings.HasSuffix(link, "]") {
+		return 0, errors.Errorf("unexpected namespace link %q for %s namespace of PID %d", link, nsType, pid)
+	}
+	return strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(link, prefix), "]"), 10, 64)
+}
@@ -0,0 +1,141 @@
+package libpod
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/gorilla/schema"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// ContainerNetStats reports the traffic of each network interface of a
+// container, once or per interval.
+func ContainerNetStats(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Stream   bool   `schema:"stream"`
+		Interval string `schema:"interval"`
+	}{
+		// override any golang type defaults
+		Interval: "5s",
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	interval, err := time.ParseDuration(query.Interval)
+	if err != nil || interval < time.Second {
+		if err == nil {
+			err = errors.New("interval must be at least 1s")
+		}
+		utils.BadRequest(w, "interval", query.Interval, err)
+		return
+	}
+	ctr, ok := lookupRunningContainer(w, r, runtime)
+	if !ok {
+		return
+	}
+	pid, err := ctr.PID()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	report, err := netStats(pid)
+	if err != nil {
+		utils.InternalServerError(w, errors.Wrapf(err, "failed to read network statistics of container %s", ctr.ID()))
+		return
+	}
+	if !query.Stream {
+		utils.WriteResponse(w, http.StatusOK, report)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	if flusher != nil {
+		flusher.Flush()
+	}
+	coder := json.NewEncoder(w)
+	coder.SetEscapeHTML(true)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := coder.Encode(report); err != nil {
+			logrus.Infof("Unable to write network statistics: %v", err)
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+		// The process is gone once the container stopped.
+		if report, err = netStats(pid); err != nil {
+			logrus.Debugf("Stopping network statistics of container %s: %v", ctr.ID(), err)
+			return
+		}
+	}
+}
+
+// netStats reads the statistics of the network interfaces in the network
+// namespace of a process from /proc/<pid>/net/dev.
+func netStats(pid int) (*entities.ContainerNetStatsReport, error) {
+	f, err := os.Open(fmt.Sprintf("/proc/%d/net/dev", pid))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	report := entities.ContainerNetStatsReport{
+		Time:       time.Now(),
+		Interfaces: []entities.ContainerNetInterfaceStats{},
+	}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// The first two lines are headers, the others are
+		// "<name>: <8 receive counters> <8 transmit counters>".
+		line := scanner.Text()
+		colon := strings.IndexByte(line, ':')
+		if colon < 0 {
+			continue
+		}
+		fields := strings.Fields(line[colon+1:])
+		if len(fields) < 16 {
+			continue
+		}
+		var counters [16]uint64
+		for i := range counters {
+			if counters[i], err = strconv.ParseUint(fields[i], 10, 64); err != nil {
+				return nil, errors.Wrapf(err, "invalid network statistics %q", line)
+			}
+		}
+		report.Interfaces = append(report.Interfaces, entities.ContainerNetInterfaceStats{
+			Name:      strings.TrimSpace(line[:colon]),
+			RxBytes:   counters[0],
+			RxPackets: counters[1],
+			RxErrors:  counters[2],
+			RxDropped: counters[3],
+			TxBytes:   counters[8],
+			TxPackets: counters[9],
+			TxErrors:  counters[10],
+			TxDropped: counters[11],
+		})
+	}
+	return &report, scanner.Err()
+}
@@ -0,0 +1,106 @@
+package libpod
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/pkg/api/handlers/compat"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/gorilla/schema"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// PauseContainer pauses a container, until the given duration passed if
+// one is given.
+func PauseContainer(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Duration string `schema:"duration"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	if query.Duration == "" {
+		compat.PauseContainer(w, r)
+		return
+	}
+	duration, err := time.ParseDuration(query.Duration)
+	if err != nil {
+		utils.BadRequest(w, "duration", query.Duration, err)
+		return
+	}
+	if duration <= 0 {
+		utils.BadRequest(w, "duration", query.Duration, errors.New("duration must be positive"))
+		return
+	}
+
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	deadline := time.Now().Add(duration)
+	if err := ctr.PauseUntil(deadline); err != nil {
+		if errors.Cause(err) == define.ErrCtrStateInvalid {
+			utils.Error(w, "Something went wrong.", http.StatusConflict, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	scheduleUnpause(runtime, ctr.ID(), deadline)
+	utils.WriteResponse(w, http.StatusOK, entities.ContainerPauseReport{Id: ctr.ID(), UnpauseAt: deadline})
+}
+
+// scheduleUnpause unpauses a container at its deadline, unless it was
+// unpaused or paused with another deadline meanwhile.
+func scheduleUnpause(runtime *libpod.Runtime, id string, deadline time.Time) {
+	time.AfterFunc(time.Until(deadline), func() {
+		ctr, err := runtime.LookupContainer(id)
+		if err != nil {
+			return
+		}
+		unpauseAt, err := ctr.UnpauseAt()
+		if err != nil {
+			logrus.Errorf("Unable to unpause container %s: %v", id, err)
+			return
+		}
+		if !unpauseAt.Equal(deadline) {
+			return
+		}
+		if err := ctr.Unpause(); err != nil && errors.Cause(err) != define.ErrCtrStateInvalid {
+			logrus.Errorf("Unable to unpause container %s: %v", id, err)
+		}
+	})
+}
+
+// ScheduleDeadlineUnpauses schedules the unpausing of the containers paused
+// with a deadline, e.g. by a previous API service.  Containers whose
+// deadline passed are unpaused right away.
+func ScheduleDeadlineUnpauses(runtime *libpod.Runtime) {
+	ctrs, err := runtime.GetContainers(func(c *libpod.Container) bool {
+		state, err := c.State()
+		return err == nil && state == define.ContainerStatePaused
+	})
+	if err != nil {
+		logrus.Errorf("Unable to list paused containers: %v", err)
+		return
+	}
+	for _, ctr := range ctrs {
+		unpauseAt, err := ctr.UnpauseAt()
+		if err != nil || unpauseAt.IsZero() {
+			continue
+		}
+		scheduleUnpause(runtime, ctr.ID(), unpauseAt)
+	}
+}
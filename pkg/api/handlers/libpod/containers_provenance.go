@@ -0,0 +1,205 @@
+package libpod
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// ContainerProvenance reports the command used to create a container, the
+// image it was created from and when.  Containers created through the API do
+// not record a command, for those a `podman run` command is reconstructed
+// from the configuration.
+func ContainerProvenance(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+
+	config := ctr.Config()
+	report := entities.ContainerProvenanceReport{
+		Command:   config.CreateCommand,
+		ImageID:   config.RootfsImageID,
+		ImageName: config.RootfsImageName,
+		Created:   ctr.CreatedTime(),
+	}
+	var imageConfig *v1.ImageConfig
+	if report.ImageID != "" {
+		img, err := runtime.ImageRuntime().NewFromLocal(report.ImageID)
+		if err == nil {
+			report.ImageDigest = img.Digest().String()
+			if data, err := img.InspectNoSize(r.Context()); err == nil {
+				imageConfig = data.Config
+			}
+		}
+	}
+	if imageConfig == nil {
+		imageConfig = &v1.ImageConfig{}
+	}
+	if len(report.Command) == 0 {
+		report.Command = reconstructRunCommand(ctr, config, imageConfig)
+		report.Reconstructed = true
+	}
+	report.CommandLine = shellJoin(report.Command)
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+// reconstructRunCommand derives a `podman run` command from the container
+// configuration.  Settings inherited from the image are left out.
+func reconstructRunCommand(ctr *libpod.Container, config *libpod.ContainerConfig, imageConfig *v1.ImageConfig) []string {
+	cmd := []string{"podman", "run", "--detach", "--name", ctr.Name()}
+
+	if hostname := config.Spec.Hostname; hostname != "" && !strings.HasPrefix(ctr.ID(), hostname) {
+		cmd = append(cmd, "--hostname", hostname)
+	}
+	if user := ctr.User(); user != "" && user != imageConfig.User {
+		cmd = append(cmd, "--user", user)
+	}
+	imageWorkDir := imageConfig.WorkingDir
+	if imageWorkDir == "" {
+		imageWorkDir = "/"
+	}
+	if workDir := ctr.WorkingDir(); workDir != imageWorkDir {
+		cmd = append(cmd, "--workdir", workDir)
+	}
+
+	imageEnv := make(map[string]bool, len(imageConfig.Env))
+	for _, env := range imageConfig.Env {
+		imageEnv[env] = true
+	}
+	if config.Spec.Process != nil {
+		for _, env := range config.Spec.Process.Env {
+			if !imageEnv[env] && !strings.HasPrefix(env, "container=") && !strings.HasPrefix(env, "HOSTNAME=") {
+				cmd = append(cmd, "--env", env)
+			}
+		}
+	}
+
+	labels := make([]string, 0, len(config.Labels))
+	for key, value := range config.Labels {
+		if imageValue, ok := imageConfig.Labels[key]; !ok || imageValue != value {
+			labels = append(labels, key+"="+value)
+		}
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		cmd = append(cmd, "--label", label)
+	}
+
+	for _, port := range config.PortMappings {
+		mapping := fmt.Sprintf("%d:%d", port.HostPort, port.ContainerPort)
+		if port.HostIP != "" {
+			mapping = port.HostIP + ":" + mapping
+		}
+		if port.Protocol != "" && port.Protocol != "tcp" {
+			mapping += "/" + port.Protocol
+		}
+		cmd = append(cmd, "--publish", mapping)
+	}
+
+	userVolumes := make(map[string]bool, len(config.UserVolumes))
+	for _, dest := range config.UserVolumes {
+		userVolumes[dest] = true
+	}
+	for _, vol := range config.NamedVolumes {
+		cmd = append(cmd, "--volume", volumeArg(vol.Name, vol.Dest, vol.Options))
+	}
+	for _, mount := range config.Spec.Mounts {
+		if !userVolumes[mount.Destination] {
+			continue
+		}
+		switch mount.Type {
+		case "bind":
+			cmd = append(cmd, "--volume", volumeArg(mount.Source, mount.Destination, bindOptions(mount.Options)))
+		case "tmpfs":
+			cmd = append(cmd, "--tmpfs", mount.Destination)
+		}
+	}
+
+	if policy := ctr.RestartPolicy(); policy != "" && policy != "no" {
+		if policy == libpod.RestartPolicyOnFailure && ctr.RestartRetries() > 0 {
+			policy += ":" + strconv.FormatUint(uint64(ctr.RestartRetries()), 10)
+		}
+		cmd = append(cmd, "--restart", policy)
+	}
+
+	entrypoint := ctr.Entrypoint()
+	overrideEntrypoint := !stringSlicesEqual(entrypoint, imageConfig.Entrypoint)
+	if overrideEntrypoint {
+		if data, err := json.Marshal(entrypoint); err == nil {
+			cmd = append(cmd, "--entrypoint", string(data))
+		}
+	}
+
+	if config.Rootfs != "" {
+		cmd = append(cmd, "--rootfs", config.Rootfs)
+	} else if image := ctr.RawImageName(); image != "" {
+		cmd = append(cmd, image)
+	} else {
+		cmd = append(cmd, config.RootfsImageName)
+	}
+	if command := ctr.Command(); overrideEntrypoint || !stringSlicesEqual(command, imageConfig.Cmd) {
+		cmd = append(cmd, command...)
+	}
+	return cmd
+}
+
+func volumeArg(source, dest string, options []string) string {
+	arg := source + ":" + dest
+	if len(options) > 0 {
+		arg += ":" + strings.Join(options, ",")
+	}
+	return arg
+}
+
+// bindOptions filters the mount options of a bind mount down to the ones
+// which can be given with --volume.
+func bindOptions(options []string) []string {
+	filtered := []string{}
+	for _, opt := range options {
+		switch opt {
+		case "ro", "z", "Z", "shared", "rshared", "slave", "rslave", "private", "rprivate", "noexec", "nosuid", "nodev":
+			filtered = append(filtered, opt)
+		}
+	}
+	return filtered
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// shellJoin quotes args so the result can be pasted into a shell.
+func shellJoin(args []string) string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		if shellSafe.MatchString(arg) {
+			quoted = append(quoted, arg)
+			continue
+		}
+		quoted = append(quoted, "'"+strings.ReplaceAll(arg, "'", `'\''`)+"'")
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,113 @@
+package libpod
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/gorilla/schema"
+	"github.com/pkg/errors"
+)
+
+// GetRestartBackoff reports the delay of restarts of a container after
+// failures.
+func GetRestartBackoff(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	report, err := restartBackoffReport(ctr)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+// SetRestartBackoff configures the exponential backoff of restarts of a
+// container after failures.  An initial delay of 0 disables the backoff.
+func SetRestartBackoff(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		InitialDelay string  `schema:"initial-delay"`
+		MaxDelay     string  `schema:"max-delay"`
+		Multiplier   float64 `schema:"multiplier"`
+	}{
+		// override any golang type defaults
+		MaxDelay:   "5m",
+		Multiplier: 2,
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+
+	var backoff *libpod.ContainerRestartBackoff
+	if query.InitialDelay == "" {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, errors.New("initial-delay is required"))
+		return
+	}
+	initial, err := time.ParseDuration(query.InitialDelay)
+	if err != nil {
+		utils.BadRequest(w, "initial-delay", query.InitialDelay, err)
+		return
+	}
+	if initial != 0 {
+		maxDelay, err := time.ParseDuration(query.MaxDelay)
+		if err != nil {
+			utils.BadRequest(w, "max-delay", query.MaxDelay, err)
+			return
+		}
+		backoff = &libpod.ContainerRestartBackoff{
+			InitialDelay: initial,
+			MaxDelay:     maxDelay,
+			Multiplier:   query.Multiplier,
+		}
+	}
+
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	if err := runtime.SetContainerRestartBackoff(context.Background(), ctr, backoff); err != nil {
+		if errors.Cause(err) == define.ErrInvalidArg {
+			utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	report, err := restartBackoffReport(ctr)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+func restartBackoffReport(ctr *libpod.Container) (*entities.ContainerRestartBackoffReport, error) {
+	restarts, err := ctr.RestartCount()
+	if err != nil {
+		return nil, err
+	}
+	report := entities.ContainerRestartBackoffReport{RestartCount: restarts}
+	if backoff := ctr.RestartBackoff(); backoff != nil {
+		report.Enabled = true
+		report.InitialDelay = backoff.InitialDelay.String()
+		report.MaxDelay = backoff.MaxDelay.String()
+		report.Multiplier = backoff.Multiplier
+		report.NextDelay = backoff.Delay(restarts).String()
+	}
+	return &report, nil
+}
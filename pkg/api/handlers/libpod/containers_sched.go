@@ -0,0 +1,348 @@
+package libpod
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/cgroups"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/gorilla/schema"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// Scheduling policies as defined in <linux/sched.h>.
+var schedPolicies = map[int]string{
+	0: "other",
+	1: "fifo",
+	2: "rr",
+	3: "batch",
+	5: "idle",
+	6: "deadline",
+}
+
+// settableSchedPolicies are the policies which can be set through the API.
+// Real-time policies are excluded as they need a priority and can starve
+// the host.
+var settableSchedPolicies = map[string]int{
+	"other": 0,
+	"batch": 3,
+	"idle":  5,
+}
+
+// ContainerSched reports the scheduling policy and nice value of the
+// processes of a running container.
+func ContainerSched(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	ctr, ok := lookupRunningContainer(w, r, runtime)
+	if !ok {
+		return
+	}
+	report, err := containerSchedReport(ctr)
+	if err != nil {
+		containerProcessesError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+// UpdateContainerSched sets the scheduling policy and/or the nice value of
+// all processes of a running container.
+func UpdateContainerSched(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Policy string `schema:"policy"`
+		Nice   *int   `schema:"nice"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	policy := -1
+	if query.Policy != "" {
+		p, ok := settableSchedPolicies[strings.ToLower(query.Policy)]
+		if !ok {
+			utils.BadRequest(w, "policy", query.Policy, errors.New("policy must be one of other, batch or idle"))
+			return
+		}
+		policy = p
+	}
+	if query.Nice != nil && (*query.Nice < -20 || *query.Nice > 19) {
+		utils.BadRequest(w, "nice", strconv.Itoa(*query.Nice), errors.New("nice must be between -20 and 19"))
+		return
+	}
+	if policy < 0 && query.Nice == nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.New("at least one of policy or nice must be given"))
+		return
+	}
+
+	ctr, ok := lookupRunningContainer(w, r, runtime)
+	if !ok {
+		return
+	}
+	pids, err := containerProcesses(ctr)
+	if err != nil {
+		containerProcessesError(w, err)
+		return
+	}
+	for _, pid := range pids {
+		// Both the policy and the nice value are per thread.
+		tids, err := processThreads(pid)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			utils.InternalServerError(w, err)
+			return
+		}
+		for _, tid := range tids {
+			if err := setThreadSched(tid, policy, query.Nice); err != nil && err != unix.ESRCH {
+				utils.InternalServerError(w, errors.Wrapf(err, "error setting scheduling of PID %d of container %s", tid, ctr.ID()))
+				return
+			}
+		}
+	}
+	report, err := containerSchedReport(ctr)
+	if err != nil {
+		containerProcessesError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+// containerProcessesError writes the error response of a failure to find the
+// processes of a container, 409 if it has no cgroup of its own.
+func containerProcessesError(w http.ResponseWriter, err error) {
+	if errors.Cause(err) == define.ErrNoCgroups {
+		utils.Error(w, http.StatusText(http.StatusConflict), http.StatusConflict, err)
+		return
+	}
+	utils.InternalServerError(w, err)
+}
+
+// lookupRunningContainer looks up the container named in the request and
+// writes an error response unless it is running.
+func lookupRunningContainer(w http.ResponseWriter, r *http.Request, runtime *libpod.Runtime) (*libpod.Container, bool) {
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return nil, false
+	}
+	state, err := ctr.State()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return nil, false
+	}
+	if state != define.ContainerStateRunning {
+		utils.ContainerNotRunning(w, ctr.ID(), errors.Wrapf(define.ErrCtrStateInvalid, "container %s is not running", ctr.ID()))
+		return nil, false
+	}
+	return ctr, true
+}
+
+func setThreadSched(tid, policy int, nice *int) error {
+	if policy >= 0 {
+		// The static priority must be 0 for all non real-time policies.
+		var param struct{ priority int32 }
+		if _, _, errno := unix.Syscall(unix.SYS_SCHED_SETSCHEDULER, uintptr(tid), uintptr(policy), uintptr(unsafe.Pointer(&param))); errno != 0 {
+			return errno
+		}
+	}
+	if nice != nil {
+		if err := unix.Setpriority(unix.PRIO_PROCESS, tid, *nice); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func containerSchedReport(ctr *libpod.Container) (*entities.ContainerSchedReport, error) {
+	pids, err := containerProcesses(ctr)
+	if err != nil {
+		return nil, err
+	}
+	report := entities.ContainerSchedReport{Processes: make([]entities.ContainerProcessSched, 0, len(pids))}
+	for _, pid := range pids {
+		sched, err := processSched(pid)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		report.Processes = append(report.Processes, sched)
+	}
+	return &report, nil
+}
+
+// containerProcesses returns the PIDs of all processes in the cgroup
+// dedicated to the container and the cgroups below it.  It fails with
+// define.ErrNoCgroups if the container has no cgroup of its own, the
+// processes of shared cgroups are never taken for the container's.
+func containerProcesses(ctr *libpod.Container) ([]int, error) {
+	dir, err := containerCgroupDir(ctr)
+	if err != nil {
+		return nil, err
+	}
+	pids := []int{}
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				// The cgroup was removed meanwhile.
+				return nil
+			}
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		procs, err := ioutil.ReadFile(filepath.Join(path, "cgroup.procs"))
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		for _, field := range strings.Fields(string(procs)) {
+			if pid, err := strconv.Atoi(field); err == nil {
+				pids = append(pids, pid)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading processes of container %s", ctr.ID())
+	}
+	sort.Ints(pids)
+	return pids, nil
+}
+
+// containerCgroupDir returns the directory of the cgroup dedicated to the
+// container, which libpod names after the container, in the cgroup
+// hierarchy: the unified one on cgroup v2, the one of the pids controller on
+// cgroup v1.
+func containerCgroupDir(ctr *libpod.Container) (string, error) {
+	if ctr.Config().NoCgroups || ctr.Config().CgroupsMode == "disabled" {
+		return "", errors.Wrapf(define.ErrNoCgroups, "container %s does not create cgroups", ctr.ID())
+	}
+	pid, err := ctr.PID()
+	if err != nil {
+		return "", err
+	}
+	unified, err := cgroups.IsCgroup2UnifiedMode()
+	if err != nil {
+		return "", err
+	}
+	data, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		return "", errors.Wrapf(err, "error reading cgroup of container %s", ctr.ID())
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.SplitN(line, ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		var hierarchy string
+		switch {
+		case unified && fields[0] == "0" && fields[1] == "":
+			hierarchy = cgroupRoot
+		case !unified && hasController(fields[1], "pids"):
+			hierarchy = filepath.Join(cgroupRoot, fields[1])
+		default:
+			continue
+		}
+		if cgroup := dedicatedCgroup(fields[2], ctr.ID()); cgroup != "" {
+			return filepath.Join(hierarchy, cgroup), nil
+		}
+	}
+	return "", errors.Wrapf(define.ErrNoCgroups, "container %s has no cgroup of its own", ctr.ID())
+}
+
+// cgroupRoot is where the cgroup hierarchies are mounted.
+const cgroupRoot = "/sys/fs/cgroup"
+
+func hasController(controllers, controller string) bool {
+	for _, c := range strings.Split(controllers, ",") {
+		if c == controller {
+			return true
+		}
+	}
+	return false
+}
+
+// dedicatedCgroup returns the part of a cgroup path up to the cgroup libpod
+// created for the container with the given ID, libpod-<id> or
+// libpod-<id>.scope, or "" if the path is not below such a cgroup.
+func dedicatedCgroup(path, id string) string {
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		if part == "libpod-"+id || part == "libpod-"+id+".scope" {
+			return strings.Join(parts[:i+1], "/")
+		}
+	}
+	return ""
+}
+
+func processThreads(pid int) ([]int, error) {
+	entries, err := ioutil.ReadDir(fmt.Sprintf("/proc/%d/task", pid))
+	if err != nil {
+		return nil, err
+	}
+	tids := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		if tid, err := strconv.Atoi(entry.Name()); err == nil {
+			tids = append(tids, tid)
+		}
+	}
+	return tids, nil
+}
+
+// processSched reads the nice value and the scheduling policy of a process
+// from /proc/<pid>/stat.
+func processSched(pid int) (entities.ContainerProcessSched, error) {
+	sched := entities.ContainerProcessSched{PID: pid}
+	data, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return sched, err
+	}
+	// The command name may contain spaces, the fields following it start
+	// with the state (field 3).
+	end := bytes.LastIndexByte(data, ')')
+	if end < 0 {
+		return sched, errors.Errorf("invalid stat of PID %d", pid)
+	}
+	fields := strings.Fields(string(data[end+1:]))
+	const niceField, policyField = 19 - 3, 41 - 3
+	if len(fields) <= policyField {
+		return sched, errors.Errorf("invalid stat of PID %d", pid)
+	}
+	if sched.Nice, err = strconv.Atoi(fields[niceField]); err != nil {
+		return sched, errors.Wrapf(err, "invalid nice value of PID %d", pid)
+	}
+	policy, err := strconv.Atoi(fields[policyField])
+	if err != nil {
+		return sched, errors.Wrapf(err, "invalid scheduling policy of PID %d", pid)
+	}
+	var ok bool
+	if sched.Policy, ok = schedPolicies[policy]; !ok {
+		sched.Policy = strconv.Itoa(policy)
+	}
+	return sched, nil
+}
@@ -0,0 +1,35 @@
+package libpod
+
+import (
+	"net/http"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/pkg/errors"
+)
+
+// SeccompNotify streams the seccomp user notifications raised by a container.
+func SeccompNotify(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	if err := seccompNotifySupported(); err != nil {
+		utils.Error(w, "seccomp notification not supported", http.StatusNotImplemented,
+			errors.Wrapf(err, "cannot stream seccomp notifications of container %s", ctr.ID()))
+		return
+	}
+}
+
+// seccompNotifySupported reports whether seccomp user notifications can be
+// delivered through the API. Receiving them requires the OCI runtime to hand
+// the notification fd of the container's filter to libpod (the SCMP_ACT_NOTIFY
+// action and a seccomp listener socket), neither of which is supported by the
+// runtime-spec podman is currently built against.
+func seccompNotifySupported() error {
+	return errors.Wrap(define.ErrNotImplemented, "the OCI runtime spec in use has no support for seccomp user notification")
+}
@@ -0,0 +1,84 @@
+package libpod
+
+import (
+	"net/http"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/docker/go-units"
+	"github.com/gorilla/schema"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// ContainerShm reports the size and usage of the /dev/shm tmpfs of a running
+// container.
+func ContainerShm(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	ctr, ok := lookupRunningContainer(w, r, runtime)
+	if !ok {
+		return
+	}
+	writeShmReport(w, ctr)
+}
+
+// ResizeContainerShm resizes the /dev/shm tmpfs of a running container
+// without recreating it.
+func ResizeContainerShm(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Size string `schema:"size"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	size, err := units.RAMInBytes(query.Size)
+	if err != nil || size <= 0 {
+		if err == nil {
+			err = errors.New("size must be positive")
+		}
+		utils.BadRequest(w, "size", query.Size, err)
+		return
+	}
+
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	if err := runtime.ResizeContainerShm(r.Context(), ctr, size); err != nil {
+		if errors.Cause(err) == define.ErrCtrStateInvalid {
+			utils.Error(w, "Something went wrong.", http.StatusConflict, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	writeShmReport(w, ctr)
+}
+
+func writeShmReport(w http.ResponseWriter, ctr *libpod.Container) {
+	if ctr.ShmDir() == "" {
+		utils.Error(w, "Something went wrong.", http.StatusConflict,
+			errors.Wrapf(define.ErrCtrStateInvalid, "/dev/shm of container %s is not managed by Libpod", ctr.ID()))
+		return
+	}
+	var fs unix.Statfs_t
+	if err := unix.Statfs(ctr.ShmDir(), &fs); err != nil {
+		utils.InternalServerError(w, errors.Wrapf(err, "error reading usage of shm of container %s", ctr.ID()))
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, entities.ContainerShmReport{
+		Size:      int64(fs.Blocks) * fs.Bsize,
+		Used:      int64(fs.Blocks-fs.Bfree) * fs.Bsize,
+		Available: int64(fs.Bavail) * fs.Bsize,
+	})
+}
@@ -0,0 +1,43 @@
+package libpod
+
+import (
+	"net/http"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/pkg/errors"
+)
+
+// ContainerStartTiming reports where the time of the last successful start
+// of a container went.
+func ContainerStartTiming(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	timing, err := ctr.StartTiming()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if timing == nil {
+		utils.Error(w, "Something went wrong.", http.StatusConflict, errors.Errorf("container %s was never started", ctr.ID()))
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, entities.ContainerStartTimingReport{
+		Id:      ctr.ID(),
+		Started: timing.Started,
+		Total:   timing.Total,
+		Phases: []entities.ContainerStartPhase{
+			{Name: "image-mount", Duration: timing.ImageMount},
+			{Name: "network-setup", Duration: timing.NetworkSetup},
+			{Name: "rootfs-prepare", Duration: timing.RootfsPrepare},
+			{Name: "runtime-create", Duration: timing.RuntimeCreate},
+			{Name: "runtime-start", Duration: timing.RuntimeStart},
+		},
+	})
+}
@@ -0,0 +1,61 @@
+package libpod
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/gorilla/schema"
+	"github.com/pkg/errors"
+)
+
+// ContainerSyscalls streams the number of system calls made by a container
+// per interval, to help building tight seccomp profiles.
+func ContainerSyscalls(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Stream   bool   `schema:"stream"`
+		Interval string `schema:"interval"`
+	}{
+		// override any golang type defaults
+		Stream:   true,
+		Interval: "5s",
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	if interval, err := time.ParseDuration(query.Interval); err != nil || interval < time.Second {
+		if err == nil {
+			err = errors.New("interval must be at least 1s")
+		}
+		utils.BadRequest(w, "interval", query.Interval, err)
+		return
+	}
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	if err := syscallTracingSupported(); err != nil {
+		utils.Error(w, "syscall tracing not supported", http.StatusNotImplemented,
+			errors.Wrapf(err, "cannot count system calls of container %s", ctr.ID()))
+		return
+	}
+}
+
+// syscallTracingSupported reports whether the system calls of a container
+// can be counted.  This needs either seccomp user notifications (see
+// seccompNotifySupported) or loading a BPF program on the raw_syscalls
+// tracepoints, which podman has no support for.
+func syscallTracingSupported() error {
+	if err := seccompNotifySupported(); err == nil {
+		return nil
+	}
+	return errors.Wrap(define.ErrNotImplemented, "neither seccomp user notification nor BPF syscall tracing is available")
+}
@@ -0,0 +1,124 @@
+package libpod
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/containers/storage/pkg/idtools"
+	"github.com/pkg/errors"
+)
+
+// ContainerUserNS reports the UID/GID mappings of the user namespace of a
+// container.  For running containers the mappings are read from the kernel,
+// otherwise they are taken from the container configuration.
+func ContainerUserNS(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+
+	report := entities.ContainerUserNSReport{
+		UserNSContainer: ctr.Config().UserNsCtr,
+	}
+	state, err := ctr.State()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if state == define.ContainerStateRunning || state == define.ContainerStatePaused {
+		pid, err := ctr.PID()
+		if err != nil {
+			utils.InternalServerError(w, err)
+			return
+		}
+		if report.UIDMap, err = readIDMap(fmt.Sprintf("/proc/%d/uid_map", pid)); err != nil {
+			utils.InternalServerError(w, err)
+			return
+		}
+		if report.GIDMap, err = readIDMap(fmt.Sprintf("/proc/%d/gid_map", pid)); err != nil {
+			utils.InternalServerError(w, err)
+			return
+		}
+	} else {
+		mappingCtr := ctr
+		if report.UserNSContainer != "" {
+			if mappingCtr, err = runtime.LookupContainer(report.UserNSContainer); err != nil {
+				utils.InternalServerError(w, errors.Wrapf(err, "error looking up user namespace container %s", report.UserNSContainer))
+				return
+			}
+		}
+		mappings, err := mappingCtr.IDMappings()
+		if err != nil {
+			utils.InternalServerError(w, err)
+			return
+		}
+		report.UIDMap = toContainerIDMappings(mappings.UIDMap)
+		report.GIDMap = toContainerIDMappings(mappings.GIDMap)
+	}
+	report.Enabled = !isIdentityMapping(report.UIDMap) || !isIdentityMapping(report.GIDMap)
+	report.RootUID = rootMapping(report.UIDMap)
+	report.RootGID = rootMapping(report.GIDMap)
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+// readIDMap parses a /proc/<pid>/{uid,gid}_map file.
+func readIDMap(path string) ([]entities.ContainerIDMapping, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	mappings := []entities.ContainerIDMapping{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var m entities.ContainerIDMapping
+		if _, err := fmt.Sscanf(scanner.Text(), "%d %d %d", &m.ContainerID, &m.HostID, &m.Size); err != nil {
+			return nil, errors.Wrapf(err, "error parsing %s", path)
+		}
+		mappings = append(mappings, m)
+	}
+	return mappings, scanner.Err()
+}
+
+func toContainerIDMappings(idMaps []idtools.IDMap) []entities.ContainerIDMapping {
+	mappings := make([]entities.ContainerIDMapping, 0, len(idMaps))
+	for _, m := range idMaps {
+		mappings = append(mappings, entities.ContainerIDMapping{
+			ContainerID: m.ContainerID,
+			HostID:      m.HostID,
+			Size:        m.Size,
+		})
+	}
+	return mappings
+}
+
+// isIdentityMapping returns true if the mappings do not remap any ID, which is
+// the case when no user namespace is in use.
+func isIdentityMapping(mappings []entities.ContainerIDMapping) bool {
+	for _, m := range mappings {
+		if m.ContainerID != m.HostID {
+			return false
+		}
+	}
+	return true
+}
+
+// rootMapping returns the host ID that ID 0 in the container maps to.
+func rootMapping(mappings []entities.ContainerIDMapping) int {
+	for _, m := range mappings {
+		if m.ContainerID == 0 {
+			return m.HostID
+		}
+	}
+	return 0
+}
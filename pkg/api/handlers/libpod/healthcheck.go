@@ -1,16 +1,43 @@
 package libpod
 
 import (
+	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/containers/podman/v3/libpod"
 	"github.com/containers/podman/v3/libpod/define"
 	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/gorilla/schema"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
+// defaultHealthCheckInterval is used when streaming the results of a
+// healthcheck which has no interval configured.
+const defaultHealthCheckInterval = 30 * time.Second
+
 func RunHealthCheck(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Stream bool `schema:"stream"`
+	}{
+		// override any golang type defaults
+	}
+
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
 	name := utils.GetName(r)
+	if query.Stream {
+		streamHealthCheck(w, r, runtime, name)
+		return
+	}
+
 	status, err := runtime.HealthCheck(name)
 	if err != nil {
 		if status == define.HealthCheckContainerNotFound {
@@ -18,7 +45,7 @@ func RunHealthCheck(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if status == define.HealthCheckNotDefined {
-			utils.Error(w, "no healthcheck defined", http.StatusConflict, err)
+			utils.Error(w, "no healthcheck defined", http.StatusBadRequest, err)
 			return
 		}
 		if status == define.HealthCheckContainerStopped {
@@ -28,12 +55,99 @@ func RunHealthCheck(w http.ResponseWriter, r *http.Request) {
 		utils.InternalServerError(w, err)
 		return
 	}
-	hcStatus := define.HealthCheckUnhealthy
-	if status == define.HealthCheckSuccess {
-		hcStatus = define.HealthCheckHealthy
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
 	}
-	report := define.HealthCheckResults{
-		Status: hcStatus,
+	report, err := lastHealthCheckProbe(ctr)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
 	}
 	utils.WriteResponse(w, http.StatusOK, report)
 }
+
+// lastHealthCheckProbe returns the result of the latest run of the
+// healthcheck of the container.
+func lastHealthCheckProbe(ctr *libpod.Container) (*entities.HealthCheckProbeReport, error) {
+	results, err := ctr.GetHealthCheckLog()
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read healthcheck log of container %s", ctr.ID())
+	}
+	if len(results.Log) == 0 {
+		return nil, errors.Errorf("healthcheck log of container %s is empty", ctr.ID())
+	}
+	return &entities.HealthCheckProbeReport{
+		HealthCheckLog: results.Log[len(results.Log)-1],
+		Status:         results.Status,
+	}, nil
+}
+
+// streamHealthCheck runs the healthcheck of the container at its configured
+// interval and writes the result of each probe until the client goes away or
+// the healthcheck can no longer be run.
+func streamHealthCheck(w http.ResponseWriter, r *http.Request, runtime *libpod.Runtime, name string) {
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	if !ctr.HasHealthCheck() {
+		utils.Error(w, "no healthcheck defined", http.StatusBadRequest,
+			errors.Errorf("container %s has no defined healthcheck", ctr.ID()))
+		return
+	}
+	state, err := ctr.State()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if state != define.ContainerStateRunning {
+		utils.ContainerNotRunning(w, ctr.ID(), errors.Errorf("container %s is not running", ctr.ID()))
+		return
+	}
+
+	interval := ctr.HealthCheckConfig().Interval
+	if interval <= 0 {
+		interval = defaultHealthCheckInterval
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+
+	coder := json.NewEncoder(w)
+	coder.SetEscapeHTML(true)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		status, err := runtime.HealthCheck(ctr.ID())
+		if status != define.HealthCheckSuccess && status != define.HealthCheckFailure {
+			// The container went away or stopped; nothing left to probe.
+			logrus.Infof("Stopped streaming healthcheck of container %s: %v", ctr.ID(), err)
+			return
+		}
+		report, err := lastHealthCheckProbe(ctr)
+		if err != nil {
+			logrus.Errorf("Unable to stream healthcheck result: %v", err)
+			return
+		}
+		if err := coder.Encode(report); err != nil {
+			logrus.Errorf("Unable to encode healthcheck result: %v", err)
+			return
+		}
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package libpod
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/cgroups"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// nearOOMPercent is the memory usage in percent of the limit above which a
+// container is considered to be close to being OOM killed.
+const nearOOMPercent = 90
+
+// bufferCloser adds a no-op Close to a bytes.Buffer so it can be used as an
+// attach stream.
+type bufferCloser struct {
+	*bytes.Buffer
+}
+
+func (bufferCloser) Close() error {
+	return nil
+}
+
+// DiagnoseHealthCheck runs the healthcheck of a container once and reports
+// its output and exit code, along with the resource pressure on the
+// container, to explain why a container is unhealthy.  The result is not
+// recorded in the health of the container.
+func DiagnoseHealthCheck(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	command := healthCheckCommand(ctr)
+	if len(command) == 0 {
+		utils.Error(w, "no healthcheck defined", http.StatusBadRequest,
+			errors.Errorf("container %s has no defined healthcheck", ctr.ID()))
+		return
+	}
+	state, err := ctr.State()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if state != define.ContainerStateRunning {
+		utils.ContainerNotRunning(w, ctr.ID(), errors.Errorf("container %s is not running", ctr.ID()))
+		return
+	}
+
+	report := entities.HealthCheckDiagnosis{
+		Command: command,
+		Timeout: ctr.HealthCheckConfig().Timeout,
+	}
+	throttledBefore, throttleErr := cpuThrottledPeriods(ctr)
+
+	var stdout, stderr bytes.Buffer
+	streams := &define.AttachStreams{
+		OutputStream: bufferCloser{&stdout},
+		ErrorStream:  bufferCloser{&stderr},
+		AttachOutput: true,
+		AttachError:  true,
+	}
+	report.Start = time.Now()
+	exitCode, execErr := ctr.Exec(&libpod.ExecConfig{Command: command}, streams, nil)
+	report.End = time.Now()
+	report.Duration = report.End.Sub(report.Start)
+	report.Stdout = stdout.String()
+	report.Stderr = stderr.String()
+	report.ExitCode = exitCode
+	if execErr != nil {
+		switch errors.Cause(execErr) {
+		case define.ErrOCIRuntimeNotFound:
+			report.ExitCode = 127
+		case define.ErrOCIRuntimePermissionDenied:
+			report.ExitCode = 126
+		default:
+			report.ExitCode = 125
+		}
+		if report.Stderr == "" {
+			report.Stderr = execErr.Error()
+		}
+	}
+	report.Status = define.HealthCheckHealthy
+	if report.ExitCode != 0 {
+		report.Status = define.HealthCheckUnhealthy
+	}
+	report.TimedOut = report.Timeout > 0 && report.Duration > report.Timeout
+
+	if stats, err := ctr.GetContainerStats(nil); err == nil {
+		report.MemUsage = stats.MemUsage
+		report.MemLimit = stats.MemLimit
+		report.MemPerc = stats.MemPerc
+		report.NearOOM = stats.MemPerc >= nearOOMPercent
+	} else {
+		logrus.Debugf("Unable to get stats of container %s: %v", ctr.ID(), err)
+	}
+	if throttleErr == nil {
+		if throttledAfter, err := cpuThrottledPeriods(ctr); err == nil && throttledAfter > throttledBefore {
+			report.CPUThrottled = throttledAfter - throttledBefore
+		}
+	} else {
+		logrus.Debugf("Unable to read CPU throttling of container %s: %v", ctr.ID(), throttleErr)
+	}
+	report.Diagnosis = diagnoseHealthCheck(&report)
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+// healthCheckCommand returns the command to run for the healthcheck of a
+// container the same way libpod runs it.
+func healthCheckCommand(ctr *libpod.Container) []string {
+	config := ctr.HealthCheckConfig()
+	if config == nil || len(config.Test) == 0 {
+		return nil
+	}
+	test := config.Test
+	var command []string
+	switch test[0] {
+	case "", "NONE":
+		return nil
+	case "CMD":
+		command = test[1:]
+	case "CMD-SHELL":
+		command = []string{"/bin/sh", "-c", strings.Join(test[1:], " ")}
+	default:
+		command = test
+	}
+	if len(command) == 0 || command[0] == "" {
+		return nil
+	}
+	return command
+}
+
+func diagnoseHealthCheck(report *entities.HealthCheckDiagnosis) []string {
+	diagnosis := []string{}
+	switch {
+	case report.ExitCode == 0:
+		diagnosis = append(diagnosis, "healthcheck passed")
+	case report.ExitCode == 127:
+		diagnosis = append(diagnosis, fmt.Sprintf("healthcheck command %q was not found in the container", report.Command[0]))
+	case report.ExitCode == 126:
+		diagnosis = append(diagnosis, fmt.Sprintf("healthcheck command %q is not executable", report.Command[0]))
+	case report.ExitCode == 125:
+		diagnosis = append(diagnosis, "healthcheck command could not be run")
+	default:
+		diagnosis = append(diagnosis, fmt.Sprintf("healthcheck command exited with code %d", report.ExitCode))
+	}
+	if report.TimedOut {
+		diagnosis = append(diagnosis, fmt.Sprintf("healthcheck took %s, longer than its timeout of %s", report.Duration.Round(time.Millisecond), report.Timeout))
+	}
+	if report.NearOOM {
+		diagnosis = append(diagnosis, fmt.Sprintf("container memory usage is at %.0f%% of its limit", report.MemPerc))
+	}
+	if report.CPUThrottled > 0 {
+		diagnosis = append(diagnosis, fmt.Sprintf("container was CPU throttled in %d periods while the healthcheck ran", report.CPUThrottled))
+	}
+	return diagnosis
+}
+
+// cpuThrottledPeriods reads the number of periods a container was throttled
+// in from the cpu.stat of its cgroup.
+func cpuThrottledPeriods(ctr *libpod.Container) (uint64, error) {
+	cgroupPath, err := ctr.CGroupPath()
+	if err != nil {
+		return 0, err
+	}
+	unified, err := cgroups.IsCgroup2UnifiedMode()
+	if err != nil {
+		return 0, err
+	}
+	statPath := filepath.Join("/sys/fs/cgroup", cgroupPath, "cpu.stat")
+	if !unified {
+		statPath = filepath.Join("/sys/fs/cgroup/cpu", cgroupPath, "cpu.stat")
+	}
+	content, err := ioutil.ReadFile(statPath)
+	if err != nil {
+		return 0, err
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == "nr_throttled" {
+			return strconv.ParseUint(fields[1], 10, 64)
+		}
+	}
+	return 0, errors.Errorf("no nr_throttled in %s", statPath)
+}
@@ -147,7 +147,8 @@ func PruneImages(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 		}
-		// dangling is special and not implemented in the libpod side of things
+		// all and dangling are special and not implemented in the libpod side of things
+		delete(query.Filters, "all")
 		delete(query.Filters, "dangling")
 		for k, v := range query.Filters {
 			libpodFilters = append(libpodFilters, fmt.Sprintf("%s=%s", k, v[0]))
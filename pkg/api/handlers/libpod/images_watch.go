@@ -0,0 +1,146 @@
+package libpod
+
+import (
+	"encoding/json"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/events"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/gorilla/schema"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// watchedImageEvents are the image events reported when watching images.
+var watchedImageEvents = map[events.Status]bool{
+	events.Pull:   true,
+	events.Push:   true,
+	events.Tag:    true,
+	events.Untag:  true,
+	events.Remove: true,
+}
+
+// imageNameMatches returns true if the pattern matches the name, or the
+// name without tag or digest.
+func imageNameMatches(pattern, name string) bool {
+	if name == "" {
+		return false
+	}
+	if matched, _ := path.Match(pattern, name); matched {
+		return true
+	}
+	repo := name
+	if i := strings.Index(repo, "@"); i >= 0 {
+		repo = repo[:i]
+	}
+	if i := strings.LastIndex(repo, ":"); i > strings.LastIndex(repo, "/") {
+		repo = repo[:i]
+	}
+	matched, _ := path.Match(pattern, repo)
+	return matched
+}
+
+// imageEventMatches returns true if the event is about an image matching
+// the pattern.  Events name a single image name, images tagged or untagged
+// are matched by all their names.
+func imageEventMatches(runtime *libpod.Runtime, pattern string, e *events.Event) bool {
+	if !watchedImageEvents[e.Status] {
+		return false
+	}
+	if imageNameMatches(pattern, e.Name) {
+		return true
+	}
+	if e.ID == "" || (e.Status != events.Tag && e.Status != events.Untag) {
+		return false
+	}
+	img, err := runtime.ImageRuntime().NewFromLocal(e.ID)
+	if err != nil {
+		return false
+	}
+	for _, name := range img.Names() {
+		if imageNameMatches(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// WatchImages streams the events of images matching a reference pattern
+// being pulled, pushed, tagged, untagged or removed.
+func WatchImages(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Reference string `schema:"reference"`
+		Stream    bool   `schema:"stream"`
+	}{
+		// override any golang type defaults
+		Stream: true,
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	if query.Reference == "" {
+		utils.BadRequest(w, "reference", query.Reference, errors.New("reference must be set"))
+		return
+	}
+	if _, err := path.Match(query.Reference, ""); err != nil {
+		utils.BadRequest(w, "reference", query.Reference, err)
+		return
+	}
+
+	eventChannel := make(chan *events.Event)
+	errorChannel := make(chan error)
+	go func() {
+		readOpts := events.ReadOptions{
+			// Without streaming, the past events are reported.
+			FromStart:    !query.Stream,
+			Stream:       query.Stream,
+			Filters:      []string{"type=" + string(events.Image)},
+			EventChannel: eventChannel,
+		}
+		errorChannel <- runtime.Events(r.Context(), readOpts)
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	if flusher != nil {
+		flusher.Flush()
+	}
+	coder := json.NewEncoder(w)
+	coder.SetEscapeHTML(true)
+	for {
+		select {
+		case err := <-errorChannel:
+			if err != nil {
+				logrus.Errorf("Unable to read image events: %v", err)
+			}
+			return
+		case evt, ok := <-eventChannel:
+			if !ok {
+				// Events were read, waiting for the reader to return.
+				eventChannel = nil
+				continue
+			}
+			if evt == nil || !imageEventMatches(runtime, query.Reference, evt) {
+				continue
+			}
+			if err := coder.Encode(entities.ConvertToEntitiesEvent(*evt)); err != nil {
+				logrus.Infof("Unable to write image event: %v", err)
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,620 @@
+package libpod
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/http"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/containernetworking/cni/libcni"
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/libpod/network"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/containers/podman/v3/pkg/rootless"
+	"github.com/pkg/errors"
+)
+
+// The CNI plugins install their rules with iptables, the nft backend of
+// iptables included.
+const firewallBackend = "iptables"
+
+var (
+	firewallBuiltinChains = map[string]bool{
+		"PREROUTING": true, "INPUT": true, "FORWARD": true, "OUTPUT": true, "POSTROUTING": true,
+	}
+	firewallTables = map[string]bool{"filter": true, "nat": true, "mangle": true, "raw": true}
+
+	// firewallRuleShapes are the rules the CNI bridge, firewall and portmap
+	// plugins install for a network, as printed by iptables-save, by table.
+	// The words in angle brackets stand for the parts which vary, see
+	// matchesWord; a word repeated in a shape stands for the same value.
+	firewallRuleShapes = map[string][][]string{
+		"nat": firewallShapes(
+			"-A POSTROUTING -s <host> -m comment --comment <comment> -j <chain>",
+			"-A <chain> -d <subnet> -m comment --comment <comment> -j ACCEPT",
+			"-A <chain> ! -d <multicast> -m comment --comment <comment> -j MASQUERADE",
+			"-A CNI-HOSTPORT-DNAT -p <proto> -m comment --comment <dnatcomment> -m multiport --dports <ports> -j <dnchain>",
+			"-A <dnchain> -s <subnet> -p <proto> -m <proto> --dport <port> -j CNI-HOSTPORT-SETMARK",
+			"-A <dnchain> -s <subnet> -d <hostaddr> -p <proto> -m <proto> --dport <port> -j CNI-HOSTPORT-SETMARK",
+			"-A <dnchain> -s <loopback> -p <proto> -m <proto> --dport <port> -j CNI-HOSTPORT-SETMARK",
+			"-A <dnchain> -s <loopback> -d <hostaddr> -p <proto> -m <proto> --dport <port> -j CNI-HOSTPORT-SETMARK",
+			"-A <dnchain> -p <proto> -m <proto> --dport <port> -j DNAT --to-destination <destination>",
+			"-A <dnchain> -d <hostaddr> -p <proto> -m <proto> --dport <port> -j DNAT --to-destination <destination>",
+		),
+		"filter": firewallShapes(
+			"-A CNI-FORWARD -d <host> -m conntrack --ctstate RELATED,ESTABLISHED -j ACCEPT",
+			"-A CNI-FORWARD -s <host> -j ACCEPT",
+		),
+	}
+
+	// firewallChainName matches the names of the per container chains of
+	// the CNI plugins, a hash of the network and container truncated to the
+	// 28 characters iptables allows.
+	firewallChainName   = regexp.MustCompile(`^CNI-[0-9a-f]{24}$`)
+	firewallDNChainName = regexp.MustCompile(`^CNI-DN-[0-9a-f]{21}$`)
+	firewallPorts       = regexp.MustCompile(`^[0-9]+(:[0-9]+)?(,[0-9]+(:[0-9]+)?)*$`)
+	firewallPort        = regexp.MustCompile(`^[0-9]+(:[0-9]+)?$`)
+)
+
+func firewallShapes(shapes ...string) [][]string {
+	split := make([][]string, 0, len(shapes))
+	for _, shape := range shapes {
+		split = append(split, strings.Fields(shape))
+	}
+	return split
+}
+
+// firewallNetwork is what the rules of a network are recognized by.
+type firewallNetwork struct {
+	name    string
+	subnets []*net.IPNet
+}
+
+// firewallNetworkFromConfig reads the name and subnets of a CNI bridge
+// network, the only driver the CNI plugins install firewall rules for.
+func firewallNetworkFromConfig(runtime *libpod.Runtime, nameOrID string) (*firewallNetwork, error) {
+	config, err := runtime.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	path, err := network.GetCNIConfigPathByNameOrID(config, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	conf, err := libcni.ConfListFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+	fn := firewallNetwork{name: conf.Name}
+	for _, plugin := range conf.Plugins {
+		if plugin.Network.Type != network.DefaultNetworkDriver {
+			continue
+		}
+		var bridge network.HostLocalBridge
+		if err := json.Unmarshal(plugin.Bytes, &bridge); err != nil {
+			return nil, err
+		}
+		for _, ranges := range bridge.IPAM.Ranges {
+			for _, r := range ranges {
+				_, subnet, err := net.ParseCIDR(r.Subnet)
+				if err != nil {
+					return nil, errors.Wrapf(err, "invalid subnet of network %s", conf.Name)
+				}
+				fn.subnets = append(fn.subnets, subnet)
+			}
+		}
+	}
+	if len(fn.subnets) == 0 {
+		return nil, errors.Wrapf(define.ErrNotImplemented, "network %s is not a bridge network with firewall rules", conf.Name)
+	}
+	return &fn, nil
+}
+
+// families returns the iptables families of the subnets.
+func (fn *firewallNetwork) families() []string {
+	families := []string{}
+	for _, family := range []string{"ipv4", "ipv6"} {
+		for _, subnet := range fn.subnets {
+			if (subnet.IP.To4() == nil) == (family == "ipv6") {
+				families = append(families, family)
+				break
+			}
+		}
+	}
+	return families
+}
+
+// contains returns true if an address or network of a rule is in one
+// of the subnets.
+func (fn *firewallNetwork) contains(addr string) bool {
+	if i := strings.IndexByte(addr, ':'); i >= 0 && strings.Count(addr, ":") == 1 {
+		// IPv4 address with a port, as in --to-destination.
+		addr = addr[:i]
+	}
+	ip, ipNet, err := net.ParseCIDR(addr)
+	if err != nil {
+		if ip = net.ParseIP(addr); ip == nil {
+			return false
+		}
+	}
+	for _, subnet := range fn.subnets {
+		if !subnet.Contains(ip) {
+			continue
+		}
+		if ipNet != nil {
+			ones, _ := ipNet.Mask.Size()
+			subnetOnes, _ := subnet.Mask.Size()
+			if ones < subnetOnes {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// mentions returns true if a rule is about the network, as it carries the
+// comment the CNI plugins tag rules of the network with or matches
+// addresses of its subnets.
+func (fn *firewallNetwork) mentions(rule string) bool {
+	if strings.Contains(rule, `name: \"`+fn.name+`\" id: \"`) {
+		return true
+	}
+	fields := strings.Fields(rule)
+	for i := 1; i < len(fields); i++ {
+		switch fields[i-1] {
+		case "-s", "--source", "-d", "--destination", "--to-destination", "--to-source":
+			if i >= 2 && fields[i-2] == "!" {
+				continue
+			}
+			if fn.contains(fields[i]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// firewallRule is an -A line of iptables-save.
+type firewallRule struct {
+	chain string
+	line  string
+}
+
+// target returns the chain or target a rule jumps or goes to.
+func (r firewallRule) target() string {
+	fields := strings.Fields(r.line)
+	for i := 1; i < len(fields); i++ {
+		if fields[i-1] == "-j" || fields[i-1] == "--jump" || fields[i-1] == "-g" || fields[i-1] == "--goto" {
+			return fields[i]
+		}
+	}
+	return ""
+}
+
+// firewallTable is a table of iptables-save.
+type firewallTable struct {
+	chains map[string]bool
+	rules  []firewallRule
+}
+
+func firewallCommand(family, command string) string {
+	if family == "ipv6" {
+		return "ip6" + strings.TrimPrefix(command, "ip")
+	}
+	return command
+}
+
+// saveFirewall reads the rules currently in effect, by table.
+func saveFirewall(family string) (map[string]*firewallTable, error) {
+	path, err := exec.LookPath(firewallCommand(family, "iptables-save"))
+	if err != nil {
+		return nil, errors.Wrapf(define.ErrNotImplemented, "%v", err)
+	}
+	out, err := exec.Command(path).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			err = errors.Errorf("%v: %s", err, bytes.TrimSpace(exitErr.Stderr))
+		}
+		return nil, errors.Wrapf(err, "failed to save %s firewall rules", family)
+	}
+	tables := make(map[string]*firewallTable)
+	var table *firewallTable
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "*"):
+			table = &firewallTable{chains: make(map[string]bool)}
+			tables[line[1:]] = table
+		case table == nil:
+		case strings.HasPrefix(line, ":"):
+			if fields := strings.Fields(line[1:]); len(fields) > 0 {
+				table.chains[fields[0]] = true
+			}
+		case strings.HasPrefix(line, "-A "):
+			if fields := strings.Fields(line); len(fields) > 1 {
+				table.rules = append(table.rules, firewallRule{chain: fields[1], line: line})
+			}
+		}
+	}
+	return tables, scanner.Err()
+}
+
+// networkFirewall extracts the rules of a network from a table: rules
+// mentioning the network, and the rules of the chains only those rules
+// jump to, as the per container chains of the CNI plugins.
+func (fn *firewallNetwork) networkFirewall(table *firewallTable) ([]string, []string) {
+	jumpers := make(map[string][]bool)
+	for _, rule := range table.rules {
+		if target := rule.target(); table.chains[target] {
+			jumpers[target] = append(jumpers[target], fn.mentions(rule.line))
+		}
+	}
+	chains := make(map[string]bool)
+	for chain, mentioned := range jumpers {
+		if firewallBuiltinChains[chain] || !strings.HasPrefix(chain, "CNI-") {
+			continue
+		}
+		own := true
+		for _, m := range mentioned {
+			own = own && m
+		}
+		if own {
+			chains[chain] = true
+		}
+	}
+	rules := []string{}
+	for _, rule := range table.rules {
+		if chains[rule.chain] || fn.mentions(rule.line) {
+			rules = append(rules, rule.line)
+		}
+	}
+	chainList := make([]string, 0, len(chains))
+	for chain := range chains {
+		chainList = append(chainList, chain)
+	}
+	sort.Strings(chainList)
+	return chainList, rules
+}
+
+// validate verifies restored rules are rules the CNI plugins install for
+// the network, in the per container chains declared with them or in effect.
+// current is the table in effect, if any.
+func (fn *firewallNetwork) validate(t entities.NetworkFirewallTable, current *firewallTable) error {
+	if !firewallTables[t.Name] {
+		return errors.Errorf("unsupported table %q", t.Name)
+	}
+	if t.Family != "ipv4" && t.Family != "ipv6" {
+		return errors.Errorf("unsupported family %q of table %s", t.Family, t.Name)
+	}
+	chains := make(map[string]bool, len(t.Chains))
+	for _, chain := range t.Chains {
+		if !firewallChainName.MatchString(chain) && !firewallDNChainName.MatchString(chain) {
+			return errors.Errorf("chain %q of table %s is not a CNI chain", chain, t.Name)
+		}
+		chains[chain] = true
+	}
+	if current != nil {
+		for chain := range current.chains {
+			chains[chain] = true
+		}
+	}
+	for _, line := range t.Rules {
+		if strings.ContainsAny(line, "\n\r") {
+			return errors.Errorf("invalid rule %q in table %s", line, t.Name)
+		}
+		words, err := firewallWords(line)
+		if err != nil {
+			return errors.Wrapf(err, "invalid rule %q in table %s", line, t.Name)
+		}
+		if !fn.matchesShape(t.Name, t.Family, words, chains) {
+			return errors.Errorf("rule %q is not a rule the CNI plugins install for network %s", line, fn.name)
+		}
+	}
+	return nil
+}
+
+// firewallWords splits a rule of iptables-save into its words, keeping the
+// quotes of quoted words as in --comment "name: \"podman\" id: \"...\"".
+func firewallWords(line string) ([]string, error) {
+	words := []string{}
+	var word strings.Builder
+	inWord, quoted, escaped := false, false, false
+	for _, c := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && c == '\\':
+			escaped = true
+		case c == '"':
+			quoted = !quoted
+		case !quoted && (c == ' ' || c == '\t'):
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+			continue
+		}
+		word.WriteRune(c)
+		inWord = true
+	}
+	if quoted {
+		return nil, errors.New("unterminated quote")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// matchesShape returns true if the words of a rule are one of the rules the
+// CNI plugins install for the network in the table.
+func (fn *firewallNetwork) matchesShape(table, family string, words []string, chains map[string]bool) bool {
+	for _, shape := range firewallRuleShapes[table] {
+		if len(shape) != len(words) {
+			continue
+		}
+		values := make(map[string]string)
+		matches := true
+		for i, part := range shape {
+			if !strings.HasPrefix(part, "<") {
+				matches = part == words[i]
+			} else if value, ok := values[part]; ok {
+				matches = value == words[i]
+			} else {
+				values[part] = words[i]
+				matches = fn.matchesWord(part, family, words[i], chains)
+			}
+			if !matches {
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesWord returns true if a word of a rule is what a placeholder of a
+// rule shape stands for.
+func (fn *firewallNetwork) matchesWord(placeholder, family, word string, chains map[string]bool) bool {
+	ipv6 := family == "ipv6"
+	switch placeholder {
+	case "<host>":
+		// An address of a container.
+		ip, ipNet, err := net.ParseCIDR(word)
+		if err != nil || (ip.To4() == nil) != ipv6 {
+			return false
+		}
+		ones, bits := ipNet.Mask.Size()
+		return ones == bits && fn.contains(word)
+	case "<hostaddr>":
+		// A host address ports are published on.
+		ip, ipNet, err := net.ParseCIDR(word)
+		if err != nil || (ip.To4() == nil) != ipv6 {
+			return false
+		}
+		ones, bits := ipNet.Mask.Size()
+		return ones == bits
+	case "<subnet>":
+		for _, subnet := range fn.subnets {
+			if word == subnet.String() {
+				return true
+			}
+		}
+		return false
+	case "<destination>":
+		host, port, err := net.SplitHostPort(word)
+		if err != nil || !firewallPort.MatchString(port) {
+			return false
+		}
+		ip := net.ParseIP(host)
+		return ip != nil && (ip.To4() == nil) == ipv6 && fn.contains(host)
+	case "<loopback>":
+		return (!ipv6 && word == "127.0.0.1/32") || (ipv6 && word == "::1/128")
+	case "<multicast>":
+		return (!ipv6 && word == "224.0.0.0/4") || (ipv6 && word == "ff00::/8")
+	case "<chain>":
+		return firewallChainName.MatchString(word) && chains[word]
+	case "<dnchain>":
+		return firewallDNChainName.MatchString(word) && chains[word]
+	case "<comment>":
+		return isFirewallComment("", fn.name, word)
+	case "<dnatcomment>":
+		return isFirewallComment("dnat ", fn.name, word)
+	case "<proto>":
+		return word == "tcp" || word == "udp" || word == "sctp"
+	case "<ports>":
+		return firewallPorts.MatchString(word)
+	case "<port>":
+		return firewallPort.MatchString(word)
+	}
+	return false
+}
+
+// isFirewallComment returns true if a quoted comment is the one the CNI
+// plugins tag the rules of a container of the network with, as printed by
+// iptables-save.
+func isFirewallComment(prefix, network, comment string) bool {
+	start := `"` + prefix + `name: \"` + network + `\" id: \"`
+	end := `\""`
+	if !strings.HasPrefix(comment, start) || !strings.HasSuffix(comment, end) || len(comment) <= len(start)+len(end) {
+		return false
+	}
+	id := comment[len(start) : len(comment)-len(end)]
+	return strings.Trim(id, "0123456789abcdef") == ""
+}
+
+// lookupFirewallNetwork writes the error response if the network has no
+// firewall rules to look at.
+func lookupFirewallNetwork(w http.ResponseWriter, r *http.Request) (*firewallNetwork, bool) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	name := utils.GetName(r)
+	fn, err := firewallNetworkFromConfig(runtime, name)
+	if err != nil {
+		switch errors.Cause(err) {
+		case define.ErrNoSuchNetwork:
+			utils.Error(w, "No such network: "+name, http.StatusNotFound, err)
+		case define.ErrNotImplemented:
+			utils.Error(w, http.StatusText(http.StatusNotImplemented), http.StatusNotImplemented, err)
+		default:
+			utils.InternalServerError(w, err)
+		}
+		return nil, false
+	}
+	if rootless.IsRootless() {
+		utils.Error(w, http.StatusText(http.StatusNotImplemented), http.StatusNotImplemented,
+			errors.Wrap(define.ErrNotImplemented, "firewall rules of rootless networks are in the rootless network namespace"))
+		return nil, false
+	}
+	return fn, true
+}
+
+func firewallError(w http.ResponseWriter, err error) {
+	if errors.Cause(err) == define.ErrNotImplemented {
+		utils.Error(w, http.StatusText(http.StatusNotImplemented), http.StatusNotImplemented, err)
+		return
+	}
+	utils.InternalServerError(w, err)
+}
+
+// ExportNetworkFirewall reports the firewall rules in effect for a network.
+func ExportNetworkFirewall(w http.ResponseWriter, r *http.Request) {
+	fn, ok := lookupFirewallNetwork(w, r)
+	if !ok {
+		return
+	}
+	report := entities.NetworkFirewallReport{
+		Network: fn.name,
+		Backend: firewallBackend,
+		Tables:  []entities.NetworkFirewallTable{},
+	}
+	for _, subnet := range fn.subnets {
+		report.Subnets = append(report.Subnets, subnet.String())
+	}
+	for _, family := range fn.families() {
+		tables, err := saveFirewall(family)
+		if err != nil {
+			firewallError(w, err)
+			return
+		}
+		names := make([]string, 0, len(tables))
+		for name := range tables {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			chains, rules := fn.networkFirewall(tables[name])
+			if len(rules) == 0 {
+				continue
+			}
+			report.Tables = append(report.Tables, entities.NetworkFirewallTable{
+				Name:   name,
+				Family: family,
+				Chains: chains,
+				Rules:  rules,
+			})
+		}
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+// RestoreNetworkFirewall installs the firewall rules of a network exported
+// before, e.g. after the rules were flushed.  Rules already in effect are
+// left alone.
+func RestoreNetworkFirewall(w http.ResponseWriter, r *http.Request) {
+	var req entities.NetworkFirewallReport
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, errors.Wrap(err, "Decode()"))
+		return
+	}
+	fn, ok := lookupFirewallNetwork(w, r)
+	if !ok {
+		return
+	}
+	if req.Network != "" && req.Network != fn.name {
+		utils.BadRequest(w, "Network", req.Network, errors.Errorf("rules are of network %s, not %s", req.Network, fn.name))
+		return
+	}
+
+	saved := make(map[string]map[string]*firewallTable)
+	for _, t := range req.Tables {
+		if _, ok := saved[t.Family]; !ok && (t.Family == "ipv4" || t.Family == "ipv6") {
+			tables, err := saveFirewall(t.Family)
+			if err != nil {
+				firewallError(w, err)
+				return
+			}
+			saved[t.Family] = tables
+		}
+		if err := fn.validate(t, saved[t.Family][t.Name]); err != nil {
+			utils.BadRequest(w, "Tables", t.Name, err)
+			return
+		}
+	}
+
+	report := entities.NetworkFirewallRestoreReport{}
+	inputs := make(map[string]*bytes.Buffer)
+	for _, t := range req.Tables {
+		current := saved[t.Family][t.Name]
+		present := make(map[string]bool)
+		if current == nil {
+			current = &firewallTable{chains: make(map[string]bool)}
+		}
+		for _, rule := range current.rules {
+			present[rule.line] = true
+		}
+		var table bytes.Buffer
+		for _, chain := range t.Chains {
+			if !current.chains[chain] {
+				table.WriteString(":" + chain + " - [0:0]\n")
+			}
+		}
+		for _, line := range t.Rules {
+			if present[line] {
+				report.Present++
+				continue
+			}
+			table.WriteString(line + "\n")
+			report.Applied++
+		}
+		if table.Len() == 0 {
+			continue
+		}
+		if inputs[t.Family] == nil {
+			inputs[t.Family] = &bytes.Buffer{}
+		}
+		inputs[t.Family].WriteString("*" + t.Name + "\n")
+		inputs[t.Family].Write(table.Bytes())
+		inputs[t.Family].WriteString("COMMIT\n")
+	}
+	for _, family := range []string{"ipv4", "ipv6"} {
+		input, ok := inputs[family]
+		if !ok {
+			continue
+		}
+		path, err := exec.LookPath(firewallCommand(family, "iptables-restore"))
+		if err != nil {
+			firewallError(w, errors.Wrapf(define.ErrNotImplemented, "%v", err))
+			return
+		}
+		cmd := exec.Command(path, "--noflush")
+		cmd.Stdin = input
+		if out, err := cmd.CombinedOutput(); err != nil {
+			utils.InternalServerError(w, errors.Wrapf(err, "failed to restore %s firewall rules of network %s: %s", family, fn.name, bytes.TrimSpace(out)))
+			return
+		}
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
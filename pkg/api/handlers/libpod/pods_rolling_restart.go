@@ -0,0 +1,155 @@
+package libpod
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/gorilla/schema"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// PodRollingRestart restarts the containers of a pod one after the other,
+// waiting for each to become healthy before moving on to the next one.  The
+// progress is streamed and the restart is aborted as soon as a container
+// fails to restart or to become healthy.
+func PodRollingRestart(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Interval string `schema:"interval"`
+	}{
+		// override any golang type defaults
+		Interval: "5s",
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	interval, err := time.ParseDuration(query.Interval)
+	if err != nil || interval < 0 {
+		if err == nil {
+			err = errors.New("interval must not be negative")
+		}
+		utils.BadRequest(w, "interval", query.Interval, err)
+		return
+	}
+
+	name := utils.GetName(r)
+	pod, err := runtime.LookupPod(name)
+	if err != nil {
+		utils.PodNotFound(w, name, err)
+		return
+	}
+	allCtrs, err := pod.AllContainers()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	ctrs := make([]*libpod.Container, 0, len(allCtrs))
+	for _, ctr := range allCtrs {
+		// Restarting the infra container would take down the whole pod.
+		if !ctr.IsInfra() {
+			ctrs = append(ctrs, ctr)
+		}
+	}
+	sort.Slice(ctrs, func(i, j int) bool {
+		return ctrs[i].CreatedTime().Before(ctrs[j].CreatedTime())
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	if flusher != nil {
+		flusher.Flush()
+	}
+	coder := json.NewEncoder(w)
+	coder.SetEscapeHTML(true)
+	report := func(ctr *libpod.Container, status string, err error) bool {
+		progress := entities.PodRollingRestartReport{
+			Time:      time.Now(),
+			Container: ctr.ID(),
+			Name:      ctr.Name(),
+			Status:    status,
+		}
+		if err != nil {
+			progress.Error = err.Error()
+		}
+		if err := coder.Encode(progress); err != nil {
+			logrus.Infof("Unable to write rolling restart progress of pod %s: %v", pod.ID(), err)
+			return false
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return true
+	}
+
+	for i, ctr := range ctrs {
+		if i > 0 {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(interval):
+			}
+		}
+		if !report(ctr, "restarting", nil) {
+			return
+		}
+		if err := ctr.RestartWithTimeout(r.Context(), ctr.StopTimeout()); err != nil {
+			report(ctr, "failed", err)
+			return
+		}
+		if !report(ctr, "restarted", nil) {
+			return
+		}
+		if !ctr.HasHealthCheck() {
+			continue
+		}
+		if err := waitHealthy(r.Context(), runtime, ctr); err != nil {
+			report(ctr, "failed", err)
+			return
+		}
+		if !report(ctr, "healthy", nil) {
+			return
+		}
+	}
+}
+
+// waitHealthy runs the healthcheck of a container at its configured interval
+// until it passes, or until the container has exhausted its start period and
+// retries.
+func waitHealthy(ctx context.Context, runtime *libpod.Runtime, ctr *libpod.Container) error {
+	config := ctr.HealthCheckConfig()
+	interval := config.Interval
+	if interval <= 0 {
+		interval = defaultHealthCheckInterval
+	}
+	deadline := time.Now().Add(config.StartPeriod + interval*time.Duration(config.Retries+1))
+	for {
+		status, err := runtime.HealthCheck(ctr.ID())
+		switch status {
+		case define.HealthCheckSuccess:
+			return nil
+		case define.HealthCheckFailure:
+		default:
+			return errors.Wrapf(err, "error running healthcheck of container %s", ctr.ID())
+		}
+		if time.Now().Add(interval).After(deadline) {
+			return errors.Errorf("container %s did not become healthy after restart", ctr.ID())
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package libpod
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/api/server/idle"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/containers/podman/v3/pkg/specgen"
+	"github.com/gorilla/schema"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultSpecValidateDebounce = 250 * time.Millisecond
+	maxSpecValidateDebounce     = 10 * time.Second
+)
+
+// validateSpec checks a spec the way creating a container from it would,
+// without creating it, and reports every problem found.
+func validateSpec(runtime *libpod.Runtime, data []byte) []entities.SpecValidationIssue {
+	issues := []entities.SpecValidationIssue{}
+	add := func(field, format string, args ...interface{}) {
+		issues = append(issues, entities.SpecValidationIssue{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	var sg specgen.SpecGenerator
+	if err := json.Unmarshal(data, &sg); err != nil {
+		add("", "invalid spec: %v", err)
+		return issues
+	}
+	if err := sg.Validate(); err != nil {
+		add("", "%v", err)
+	}
+
+	if sg.Name != "" {
+		if !define.NameRegex.MatchString(sg.Name) {
+			add("name", "%v", errors.Wrapf(define.RegexError, "invalid name %q", sg.Name))
+		} else if ctr, err := runtime.LookupContainer(sg.Name); err == nil && ctr.Name() == sg.Name {
+			add("name", "name %q is already in use by container %s", sg.Name, ctr.ID())
+		}
+	}
+	if sg.Image != "" {
+		if _, err := runtime.ImageRuntime().NewFromLocal(sg.Image); err != nil {
+			add("image", "image %s not found locally", sg.Image)
+		}
+	}
+	if sg.Pod != "" {
+		if _, err := runtime.LookupPod(sg.Pod); err != nil {
+			add("pod", "pod %s not found", sg.Pod)
+		}
+	}
+
+	var used *usedHostPorts
+	for i, m := range sg.PortMappings {
+		if m.HostPort == 0 {
+			continue
+		}
+		if used == nil {
+			var err error
+			if used, err = collectUsedHostPorts(runtime, ""); err != nil {
+				add("portmappings", "unable to check host ports: %v", err)
+				break
+			}
+		}
+		count := m.Range
+		if count == 0 {
+			count = 1
+		}
+		protocols := strings.Split(m.Protocol, ",")
+		if m.Protocol == "" {
+			protocols = []string{"tcp"}
+		}
+		field := fmt.Sprintf("portmappings[%d]", i)
+		for _, protocol := range protocols {
+			protocol = strings.TrimSpace(strings.ToLower(protocol))
+			for port := int(m.HostPort); port < int(m.HostPort)+int(count) && port <= 0xffff; port++ {
+				if _, reason := used.conflict(m.HostIP, int32(port), protocol); reason != "" {
+					add(field, "host %s port %d: %s", protocol, port, reason)
+				}
+			}
+		}
+	}
+	return issues
+}
+
+// ValidateSpecStream validates specs sent over a WebSocket as they are
+// edited.  Every message is a complete spec, the issues of the latest one
+// are sent back once no newer spec arrived for the debounce interval.
+func ValidateSpecStream(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Debounce string `schema:"debounce"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	debounce := defaultSpecValidateDebounce
+	if query.Debounce != "" {
+		var err error
+		if debounce, err = time.ParseDuration(query.Debounce); err != nil {
+			utils.BadRequest(w, "debounce", query.Debounce, err)
+			return
+		}
+		if debounce < 0 || debounce > maxSpecValidateDebounce {
+			utils.BadRequest(w, "debounce", query.Debounce, errors.Errorf("debounce must be between 0 and %s", maxSpecValidateDebounce))
+			return
+		}
+	}
+	if err := utils.CheckWebsocketUpgrade(r); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, err)
+		return
+	}
+
+	ws, err := utils.UpgradeWebsocket(w, r)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	// The connection was hijacked, we have to signal it's being closed.
+	t := r.Context().Value("idletracker").(*idle.Tracker)
+	defer t.Close()
+	defer ws.Close()
+
+	specs := make(chan []byte)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		defer close(specs)
+		for {
+			data, err := ws.ReadMessage()
+			if err != nil {
+				if err != utils.ErrWebsocketClosed {
+					logrus.Debugf("Spec validation stream ended: %v", err)
+				}
+				return
+			}
+			select {
+			case specs <- data:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var (
+		latest   []byte
+		revision int
+		validate <-chan time.Time
+	)
+	for {
+		select {
+		case data, ok := <-specs:
+			if !ok {
+				return
+			}
+			latest = data
+			revision++
+			validate = time.After(debounce)
+		case <-validate:
+			validate = nil
+			issues := validateSpec(runtime, latest)
+			report := entities.SpecValidationReport{
+				Revision: revision,
+				Valid:    len(issues) == 0,
+				Issues:   issues,
+			}
+			b, err := json.Marshal(report)
+			if err != nil {
+				logrus.Errorf("Unable to marshal spec validation report: %v", err)
+				return
+			}
+			if err := ws.WriteText(b); err != nil {
+				logrus.Debugf("Unable to send spec validation report: %v", err)
+				return
+			}
+		}
+	}
+}
@@ -63,6 +63,13 @@ type swagRestartPodResponse struct {
 	Body entities.PodRestartReport
 }
 
+// Rolling restart of pod
+// swagger:response PodRollingRestartReport
+type swagRollingRestartPodResponse struct {
+	// in:body
+	Body entities.PodRollingRestartReport
+}
+
 // Start pod
 // swagger:response PodStartReport
 type swagStartPodResponse struct {
@@ -119,6 +126,251 @@ type swagNetworkCreateReport struct {
 	Body entities.NetworkCreateReport
 }
 
+// Container dependencies
+// swagger:response ContainerDependencies
+type swagContainerDependencies struct {
+	// in:body
+	Body entities.ContainerDependencyReport
+}
+
+// Create containers
+// swagger:response ContainerCreateBatchResponse
+type swagContainerCreateBatchResponse struct {
+	// in:body
+	Body entities.ContainerCreateBatchResponse
+}
+
+// Container namespaces
+// swagger:response ContainerNamespaces
+type swagContainerNamespaces struct {
+	// in:body
+	Body entities.ContainerNamespacesReport
+}
+
+// Container user namespace
+// swagger:response ContainerUserNS
+type swagContainerUserNS struct {
+	// in:body
+	Body entities.ContainerUserNSReport
+}
+
+// Container file system audit event
+// swagger:response ContainerFSAudit
+type swagContainerFSAudit struct {
+	// in:body
+	Body entities.ContainerFSAuditEvent
+}
+
+// Container scheduling
+// swagger:response ContainerSched
+type swagContainerSched struct {
+	// in:body
+	Body entities.ContainerSchedReport
+}
+
+// Container provenance
+// swagger:response ContainerProvenance
+type swagContainerProvenance struct {
+	// in:body
+	Body entities.ContainerProvenanceReport
+}
+
+// Container mounts
+// swagger:response ContainerMounts
+type swagContainerMounts struct {
+	// in:body
+	Body []entities.ContainerMount
+}
+
+// Container name resolution
+// swagger:response ContainerDNS
+type swagContainerDNS struct {
+	// in:body
+	Body entities.ContainerDNSReport
+}
+
+// Healthcheck diagnosis
+// swagger:response HealthCheckDiagnosis
+type swagHealthCheckDiagnosis struct {
+	// in:body
+	Body entities.HealthCheckDiagnosis
+}
+
+// Port reservation
+// swagger:response PortReservation
+type swagPortReservation struct {
+	// in:body
+	Body entities.PortReservation
+}
+
+// Container syscall statistics
+// swagger:response ContainerSyscalls
+type swagContainerSyscalls struct {
+	// in:body
+	Body entities.ContainerSyscallStats
+}
+
+// Container capabilities
+// swagger:response ContainerCapabilities
+type swagContainerCapabilities struct {
+	// in:body
+	Body entities.ContainerCapabilitiesReport
+}
+
+// Log lines of a set of containers
+// swagger:response ContainersLogs
+type swagContainersLogs struct {
+	// in:body
+	Body entities.ContainersLogLine
+}
+
+// Container port conflicts
+// swagger:response ContainerCheckPorts
+type swagContainerCheckPorts struct {
+	// in:body
+	Body entities.ContainerCheckPortsReport
+}
+
+// Container label changes
+// swagger:response ContainersLabelBatch
+type swagContainersLabelBatch struct {
+	// in:body
+	Body []entities.ContainerLabelReport
+}
+
+// Container restart backoff
+// swagger:response ContainerRestartBackoff
+type swagContainerRestartBackoff struct {
+	// in:body
+	Body entities.ContainerRestartBackoffReport
+}
+
+// Container core dumps
+// swagger:response ContainerCoreDumps
+type swagContainerCoreDumps struct {
+	// in:body
+	Body []entities.ContainerCoreDump
+}
+
+// Frozen container filesystem
+// swagger:response ContainerFSFreeze
+type swagContainerFSFreeze struct {
+	// in:body
+	Body entities.ContainerFSFreezeReport
+}
+
+// Container configuration drift
+// swagger:response ContainerDrift
+type swagContainerDrift struct {
+	// in:body
+	Body entities.ContainerDriftReport
+}
+
+// Uploaded build context
+// swagger:response BuildContext
+type swagBuildContext struct {
+	// in:body
+	Body entities.BuildContextReport
+}
+
+// Processes holding container resources
+// swagger:response ContainerHolders
+type swagContainerHolders struct {
+	// in:body
+	Body entities.ContainerHoldersReport
+}
+
+// Killed processes holding container resources
+// swagger:response ContainerHoldersKill
+type swagContainerHoldersKill struct {
+	// in:body
+	Body []entities.ContainerHolderKill
+}
+
+// Container auto removal
+// swagger:response ContainerAutoRemove
+type swagContainerAutoRemove struct {
+	// in:body
+	Body entities.ContainerAutoRemoveReport
+}
+
+// Container network interface statistics
+// swagger:response ContainerNetStats
+type swagContainerNetStats struct {
+	// in:body
+	Body entities.ContainerNetStatsReport
+}
+
+// Container paused until a deadline
+// swagger:response ContainerPause
+type swagContainerPause struct {
+	// in:body
+	Body entities.ContainerPauseReport
+}
+
+// Container shm usage
+// swagger:response ContainerShm
+type swagContainerShm struct {
+	// in:body
+	Body entities.ContainerShmReport
+}
+
+// Container start timing
+// swagger:response ContainerStartTiming
+type swagContainerStartTiming struct {
+	// in:body
+	Body entities.ContainerStartTimingReport
+}
+
+// Spec validation stream message
+// swagger:response SpecValidation
+type swagSpecValidation struct {
+	// in:body
+	Body entities.SpecValidationReport
+}
+
+// Group resource usage
+// swagger:response GroupUsage
+type swagGroupUsage struct {
+	// in:body
+	Body entities.GroupUsageReport
+}
+
+// Failed requests
+// swagger:response SystemFailures
+type swagSystemFailures struct {
+	// in:body
+	Body []entities.SystemFailureReport
+}
+
+// Pull limits
+// swagger:response PullLimits
+type swagPullLimits struct {
+	// in:body
+	Body entities.PullLimitsReport
+}
+
+// System check
+// swagger:response SystemCheck
+type swagSystemCheck struct {
+	// in:body
+	Body entities.SystemCheckReport
+}
+
+// Network firewall rules
+// swagger:response NetworkFirewall
+type swagNetworkFirewall struct {
+	// in:body
+	Body entities.NetworkFirewallReport
+}
+
+// Network firewall restore
+// swagger:response NetworkFirewallRestore
+type swagNetworkFirewallRestore struct {
+	// in:body
+	Body entities.NetworkFirewallRestoreReport
+}
+
 func ServeSwagger(w http.ResponseWriter, r *http.Request) {
 	path := DefaultPodmanSwaggerSpec
 	if p, found := os.LookupEnv("PODMAN_SWAGGER_SPEC"); found {
@@ -0,0 +1,296 @@
+package libpod
+
+import (
+	"bufio"
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/libpod/network"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/containers/podman/v3/pkg/rootless"
+	"github.com/containers/storage"
+	"github.com/gorilla/schema"
+	"github.com/pkg/errors"
+)
+
+// cniNetworksDir is where the host-local IPAM plugin of CNI stores the
+// addresses allocated to containers, one file per address holding the ID
+// of the container.
+const cniNetworksDir = "/var/lib/cni/networks"
+
+const (
+	checkSeverityError   = "error"
+	checkSeverityWarning = "warning"
+)
+
+// systemCheck collects the findings of a consistency check.
+type systemCheck struct {
+	ctx    context.Context
+	repair bool
+	report entities.SystemCheckReport
+}
+
+// add adds a finding and returns its index.
+func (sc *systemCheck) add(severity, kind, id, message string) int {
+	sc.report.Findings = append(sc.report.Findings, entities.SystemCheckFinding{
+		Severity: severity,
+		Kind:     kind,
+		ID:       id,
+		Message:  message,
+	})
+	if severity == checkSeverityError {
+		sc.report.Errors++
+	} else {
+		sc.report.Warnings++
+	}
+	return len(sc.report.Findings) - 1
+}
+
+func (sc *systemCheck) repaired(finding int, err error) {
+	if err != nil {
+		sc.report.Findings[finding].RepairError = err.Error()
+		return
+	}
+	sc.report.Findings[finding].Repaired = true
+	sc.report.Repaired++
+}
+
+// removeBrokenContainer removes a container whose storage is broken from
+// the database.  Running containers are left alone, removing them would kill
+// them.
+func (sc *systemCheck) removeBrokenContainer(runtime *libpod.Runtime, ctr *libpod.Container, finding int) {
+	state, err := ctr.State()
+	if err != nil {
+		sc.repaired(finding, err)
+		return
+	}
+	if state == define.ContainerStateRunning || state == define.ContainerStatePaused {
+		sc.repaired(finding, errors.Wrapf(define.ErrCtrStateInvalid, "container is %s, not removing it", state))
+		return
+	}
+	err = runtime.RemoveContainer(sc.ctx, ctr, true, false)
+	// Removal reports errors cleaning up the broken storage even though
+	// the container was removed from the database.
+	if _, lookupErr := runtime.LookupContainer(ctr.ID()); errors.Cause(lookupErr) == define.ErrNoSuchCtr {
+		err = nil
+	} else if err == nil {
+		err = errors.Errorf("container %s is still in the database", ctr.ID())
+	}
+	sc.repaired(finding, err)
+}
+
+// checkContainers verifies the containers of the database have their
+// storage, and the storage containers have a container in the database.
+func (sc *systemCheck) checkContainers(runtime *libpod.Runtime, ctrs []*libpod.Container) error {
+	store := runtime.GetStore()
+	known := make(map[string]bool, len(ctrs))
+	for _, ctr := range ctrs {
+		known[ctr.ID()] = true
+		if ctr.Config().Rootfs != "" {
+			// The root filesystem is not in storage.
+			continue
+		}
+		storageCtr, err := store.Container(ctr.ID())
+		if err != nil {
+			if errors.Cause(err) != storage.ErrContainerUnknown {
+				return err
+			}
+			finding := sc.add(checkSeverityError, "missing-storage", ctr.ID(), "container "+ctr.Name()+" has no storage container")
+			if sc.repair {
+				sc.removeBrokenContainer(runtime, ctr, finding)
+			}
+			continue
+		}
+		if _, err := store.Layer(storageCtr.LayerID); err != nil {
+			if errors.Cause(err) != storage.ErrLayerUnknown {
+				return err
+			}
+			finding := sc.add(checkSeverityError, "missing-layer", ctr.ID(), "container "+ctr.Name()+" references missing layer "+storageCtr.LayerID)
+			if sc.repair {
+				sc.removeBrokenContainer(runtime, ctr, finding)
+			}
+			continue
+		}
+		if storageCtr.ImageID != "" {
+			if _, err := store.Image(storageCtr.ImageID); err != nil && errors.Cause(err) == storage.ErrImageUnknown {
+				sc.add(checkSeverityWarning, "missing-image", ctr.ID(), "container "+ctr.Name()+" references missing image "+storageCtr.ImageID)
+			}
+		}
+	}
+
+	storageCtrs, err := store.Containers()
+	if err != nil {
+		return err
+	}
+	for _, storageCtr := range storageCtrs {
+		if !known[storageCtr.ID] {
+			// Other tools, as buildah, have storage containers of
+			// their own, these are not removed.
+			sc.add(checkSeverityWarning, "external-storage", storageCtr.ID, "storage container has no container in the database")
+		}
+	}
+	return nil
+}
+
+// checkPods verifies pods and their members refer to each other.
+func (sc *systemCheck) checkPods(runtime *libpod.Runtime, ctrs []*libpod.Container) error {
+	for _, ctr := range ctrs {
+		if podID := ctr.PodID(); podID != "" {
+			if _, err := runtime.LookupPod(podID); err != nil {
+				if errors.Cause(err) != define.ErrNoSuchPod {
+					return err
+				}
+				sc.add(checkSeverityError, "dangling-pod-member", ctr.ID(), "container "+ctr.Name()+" is a member of missing pod "+podID)
+			}
+		}
+	}
+	pods, err := runtime.GetAllPods()
+	if err != nil {
+		return err
+	}
+	for _, pod := range pods {
+		ids, err := pod.AllContainersByID()
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if _, err := runtime.LookupContainer(id); errors.Cause(err) == define.ErrNoSuchCtr {
+				sc.add(checkSeverityError, "missing-pod-member", pod.ID(), "pod "+pod.Name()+" has missing member container "+id)
+			}
+		}
+		if pod.HasInfraContainer() {
+			infraID, err := pod.InfraContainerID()
+			if err != nil {
+				return err
+			}
+			if infraID == "" {
+				continue
+			}
+			if _, err := runtime.LookupContainer(infraID); errors.Cause(err) == define.ErrNoSuchCtr {
+				sc.add(checkSeverityError, "missing-infra", pod.ID(), "pod "+pod.Name()+" has missing infra container "+infraID)
+			}
+		}
+	}
+	return nil
+}
+
+// checkNetworkAllocations looks for addresses allocated by CNI to
+// containers which are not in the database, as left after crashes.  These
+// keep the addresses from being allocated again.  Only the networks of this
+// podman are checked, but other podman instances with another database may
+// use them as well: the allocations are reported and never removed.
+func (sc *systemCheck) checkNetworkAllocations(runtime *libpod.Runtime, ctrs []*libpod.Container) error {
+	if rootless.IsRootless() {
+		// The allocations of rootless containers are in the rootless
+		// network namespace.
+		return nil
+	}
+	known := make(map[string]bool, len(ctrs))
+	for _, ctr := range ctrs {
+		known[ctr.ID()] = true
+	}
+	config, err := runtime.GetConfig()
+	if err != nil {
+		return err
+	}
+	networks, err := network.GetNetworkNamesFromFileSystem(config)
+	if err != nil {
+		return err
+	}
+	for _, name := range networks {
+		dir := filepath.Join(cniNetworksDir, name)
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				// Nothing was allocated in the network yet.
+				continue
+			}
+			return err
+		}
+		for _, file := range files {
+			// Allocations are named after the address, the plugin has
+			// lock and last_reserved_ip files besides.
+			if !file.Mode().IsRegular() || !isIPFileName(file.Name()) {
+				continue
+			}
+			path := filepath.Join(dir, file.Name())
+			id, err := readAllocationOwner(path)
+			if err != nil {
+				return err
+			}
+			if id == "" || known[id] {
+				continue
+			}
+			sc.add(checkSeverityWarning, "orphaned-allocation", id,
+				"address "+file.Name()+" of network "+name+" is allocated to container "+id+" which is not in the database")
+		}
+	}
+	return nil
+}
+
+func isIPFileName(name string) bool {
+	return net.ParseIP(name) != nil
+}
+
+func readAllocationOwner(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	if scanner.Scan() {
+		return scanner.Text(), nil
+	}
+	return "", scanner.Err()
+}
+
+// SystemCheck checks the database and storage for inconsistencies, as left
+// after crashes, and with repair set repairs what can be repaired safely:
+// containers with broken storage are removed unless running.
+func SystemCheck(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Repair bool `schema:"repair"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+
+	sc := systemCheck{
+		ctx:    r.Context(),
+		repair: query.Repair,
+		report: entities.SystemCheckReport{Findings: []entities.SystemCheckFinding{}},
+	}
+	ctrs, err := runtime.GetAllContainers()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if err := sc.checkPods(runtime, ctrs); err != nil {
+		utils.InternalServerError(w, errors.Wrap(err, "failed to check pods"))
+		return
+	}
+	if err := sc.checkNetworkAllocations(runtime, ctrs); err != nil {
+		utils.InternalServerError(w, errors.Wrap(err, "failed to check network allocations"))
+		return
+	}
+	// Containers are checked last, as repairs remove containers.
+	if err := sc.checkContainers(runtime, ctrs); err != nil {
+		utils.InternalServerError(w, errors.Wrap(err, "failed to check containers"))
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, sc.report)
+}
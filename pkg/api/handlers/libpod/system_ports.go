@@ -0,0 +1,94 @@
+package libpod
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/gorilla/schema"
+	"github.com/pkg/errors"
+)
+
+// maxPortReservationTTL limits how long ports can be held without creating a
+// container.
+const maxPortReservationTTL = time.Hour
+
+// ReservePorts reserves host ports for a container to be created.  Either the
+// given ports or count free ports are bound by the service until the
+// container created with the reservation is started, the reservation is
+// released or it expires.
+func ReservePorts(w http.ResponseWriter, r *http.Request) {
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Ports    []uint16 `schema:"port"`
+		Count    int      `schema:"count"`
+		Protocol string   `schema:"protocol"`
+		TTL      string   `schema:"ttl"`
+	}{
+		// override any golang type defaults
+		Protocol: "tcp",
+		TTL:      "1m",
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	if query.Protocol != "tcp" && query.Protocol != "udp" {
+		utils.BadRequest(w, "protocol", query.Protocol, errors.New("protocol must be tcp or udp"))
+		return
+	}
+	ttl, err := time.ParseDuration(query.TTL)
+	if err != nil || ttl <= 0 || ttl > maxPortReservationTTL {
+		if err == nil {
+			err = errors.Errorf("ttl must be positive and at most %s", maxPortReservationTTL)
+		}
+		utils.BadRequest(w, "ttl", query.TTL, err)
+		return
+	}
+	if len(query.Ports) > 0 && query.Count > 0 {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.New("port and count are mutually exclusive"))
+		return
+	}
+	if len(query.Ports) == 0 {
+		if query.Count == 0 {
+			query.Count = 1
+		}
+		if query.Count < 0 || query.Count > 1024 {
+			utils.BadRequest(w, "count", strconv.Itoa(query.Count), errors.New("count must be between 1 and 1024"))
+			return
+		}
+		// Port 0 makes the kernel pick a free port.
+		query.Ports = make([]uint16, query.Count)
+	}
+
+	res, err := utils.ReservePorts(query.Protocol, query.Ports, ttl)
+	if err != nil {
+		utils.Error(w, "Something went wrong.", http.StatusConflict, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusCreated, res)
+}
+
+// ReleasePorts releases a port reservation which is no longer needed.
+func ReleasePorts(w http.ResponseWriter, r *http.Request) {
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Token string `schema:"token"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	if utils.ReleasePortReservation(query.Token) == nil {
+		utils.Error(w, "Something went wrong.", http.StatusNotFound,
+			errors.Errorf("no port reservation %q, it may have expired", query.Token))
+		return
+	}
+	utils.WriteResponse(w, http.StatusNoContent, "")
+}
@@ -0,0 +1,73 @@
+package libpod
+
+import (
+	"net/http"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/docker/go-units"
+	"github.com/gorilla/schema"
+	"github.com/pkg/errors"
+)
+
+func pullLimitsReport(runtime *libpod.Runtime) entities.PullLimitsReport {
+	limiter := runtime.ImageRuntime().PullLimiter
+	maxDownloads, bandwidth := limiter.Limits()
+	active, waiting := limiter.Downloads()
+	return entities.PullLimitsReport{
+		MaxConcurrentDownloads: maxDownloads,
+		Bandwidth:              bandwidth,
+		ActiveDownloads:        active,
+		WaitingDownloads:       waiting,
+	}
+}
+
+// GetPullLimits returns the limits shared by all pulls of the service, and
+// the downloads in progress.
+func GetPullLimits(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	utils.WriteResponse(w, http.StatusOK, pullLimitsReport(runtime))
+}
+
+// SetPullLimits sets the maximum number of concurrent layer downloads and
+// the bandwidth shared by all pulls of the service.  Limits not given are
+// kept.
+func SetPullLimits(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		MaxConcurrentDownloads *int   `schema:"maxConcurrentDownloads"`
+		Bandwidth              string `schema:"bandwidth"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+
+	limiter := runtime.ImageRuntime().PullLimiter
+	maxDownloads, bandwidth := limiter.Limits()
+	if query.MaxConcurrentDownloads != nil {
+		if *query.MaxConcurrentDownloads < 0 {
+			utils.BadRequest(w, "maxConcurrentDownloads", "", errors.New("maxConcurrentDownloads must not be negative"))
+			return
+		}
+		maxDownloads = *query.MaxConcurrentDownloads
+	}
+	if query.Bandwidth != "" {
+		b, err := units.RAMInBytes(query.Bandwidth)
+		if err != nil || b < 0 {
+			if err == nil {
+				err = errors.New("bandwidth must not be negative")
+			}
+			utils.BadRequest(w, "bandwidth", query.Bandwidth, err)
+			return
+		}
+		bandwidth = b
+	}
+	limiter.SetLimits(maxDownloads, bandwidth)
+	utils.WriteResponse(w, http.StatusOK, pullLimitsReport(runtime))
+}
@@ -0,0 +1,177 @@
+package libpod
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/containers/podman/v3/pkg/domain/filters"
+	"github.com/gorilla/schema"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// parseUsageFilters accepts the filters as JSON, as other endpoints do, and
+// as key=value pairs, e.g. filters=label=app=web.
+func parseUsageFilters(values []string) (map[string][]string, error) {
+	parsed := make(map[string][]string)
+	for _, value := range values {
+		if strings.HasPrefix(strings.TrimSpace(value), "{") {
+			f := map[string][]string{}
+			if err := json.Unmarshal([]byte(value), &f); err != nil {
+				return nil, err
+			}
+			for k, v := range f {
+				parsed[k] = append(parsed[k], v...)
+			}
+			continue
+		}
+		split := strings.SplitN(value, "=", 2)
+		if len(split) != 2 || split[0] == "" {
+			return nil, errors.Errorf("invalid filter %q, must be key=value", value)
+		}
+		parsed[split[0]] = append(parsed[split[0]], split[1])
+	}
+	return parsed, nil
+}
+
+// groupUsage sums the stats of the running containers matching the filters.
+// The previous stats of the containers are updated, so CPU usage is
+// computed over the interval when streaming.
+func groupUsage(runtime *libpod.Runtime, filterFuncs []libpod.ContainerFilter, previous map[string]*define.ContainerStats) (*entities.GroupUsageReport, error) {
+	ctrs, err := runtime.GetContainers(filterFuncs...)
+	if err != nil {
+		return nil, err
+	}
+	report := entities.GroupUsageReport{
+		Time:  time.Now(),
+		Stats: []define.ContainerStats{},
+	}
+	seen := make(map[string]bool, len(ctrs))
+	for _, ctr := range ctrs {
+		prev, ok := previous[ctr.ID()]
+		if !ok {
+			prev = &define.ContainerStats{}
+		}
+		stats, err := ctr.GetContainerStats(prev)
+		if err != nil {
+			// The container stopped or was removed meanwhile.
+			cause := errors.Cause(err)
+			if cause == define.ErrCtrRemoved || cause == define.ErrNoSuchCtr || cause == define.ErrCtrStateInvalid {
+				continue
+			}
+			return nil, err
+		}
+		seen[ctr.ID()] = true
+		previous[ctr.ID()] = stats
+		report.Stats = append(report.Stats, *stats)
+		report.Containers++
+		report.CPU += stats.CPU
+		report.CPUNano += stats.CPUNano
+		report.MemUsage += stats.MemUsage
+		report.NetInput += stats.NetInput
+		report.NetOutput += stats.NetOutput
+		report.BlockInput += stats.BlockInput
+		report.BlockOutput += stats.BlockOutput
+		report.PIDs += stats.PIDs
+	}
+	for id := range previous {
+		if !seen[id] {
+			delete(previous, id)
+		}
+	}
+	return &report, nil
+}
+
+// GroupUsage reports the summed resource usage of the running containers
+// matching the filters, once or per interval.
+func GroupUsage(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Filters  []string `schema:"filters"`
+		Stream   bool     `schema:"stream"`
+		Interval string   `schema:"interval"`
+	}{
+		// override any golang type defaults
+		Interval: "5s",
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	interval, err := time.ParseDuration(query.Interval)
+	if err != nil || interval < time.Second {
+		if err == nil {
+			err = errors.New("interval must be at least 1s")
+		}
+		utils.BadRequest(w, "interval", query.Interval, err)
+		return
+	}
+	filterMap, err := parseUsageFilters(query.Filters)
+	if err != nil {
+		utils.BadRequest(w, "filters", strings.Join(query.Filters, ","), err)
+		return
+	}
+	filterFuncs := make([]libpod.ContainerFilter, 0, len(filterMap)+1)
+	for k, v := range filterMap {
+		generatedFunc, err := filters.GenerateContainerFilterFuncs(k, v, runtime)
+		if err != nil {
+			utils.BadRequest(w, "filters", k, err)
+			return
+		}
+		filterFuncs = append(filterFuncs, generatedFunc)
+	}
+	runningOnly, err := filters.GenerateContainerFilterFuncs("status", []string{define.ContainerStateRunning.String()}, runtime)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	filterFuncs = append(filterFuncs, runningOnly)
+
+	previous := make(map[string]*define.ContainerStats)
+	report, err := groupUsage(runtime, filterFuncs, previous)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if !query.Stream {
+		utils.WriteResponse(w, http.StatusOK, report)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	if flusher != nil {
+		flusher.Flush()
+	}
+	coder := json.NewEncoder(w)
+	coder.SetEscapeHTML(true)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := coder.Encode(report); err != nil {
+			logrus.Infof("Unable to write group usage: %v", err)
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+		if report, err = groupUsage(runtime, filterFuncs, previous); err != nil {
+			logrus.Errorf("Unable to get group usage: %v", err)
+			return
+		}
+	}
+}
@@ -2,6 +2,7 @@ package libpod
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/url"
 
@@ -13,8 +14,10 @@ import (
 	"github.com/containers/podman/v3/pkg/domain/filters"
 	"github.com/containers/podman/v3/pkg/domain/infra/abi"
 	"github.com/containers/podman/v3/pkg/domain/infra/abi/parse"
+	"github.com/containers/storage/pkg/archive"
 	"github.com/gorilla/schema"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 func CreateVolume(w http.ResponseWriter, r *http.Request) {
@@ -222,3 +225,127 @@ func ExistsVolume(w http.ResponseWriter, r *http.Request) {
 	}
 	utils.WriteResponse(w, http.StatusNoContent, "")
 }
+
+// ExportVolume streams the contents of a volume as a tar archive
+func ExportVolume(w http.ResponseWriter, r *http.Request) {
+	var (
+		runtime = r.Context().Value("runtime").(*libpod.Runtime)
+		decoder = r.Context().Value("decoder").(*schema.Decoder)
+	)
+	query := struct {
+		Compress bool `schema:"compress"`
+	}{
+		// override any golang type defaults
+	}
+
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	name := utils.GetName(r)
+	vol, err := runtime.LookupVolume(name)
+	if err != nil {
+		utils.VolumeNotFound(w, name, err)
+		return
+	}
+	mountPoint, err := vol.Mount()
+	if err != nil {
+		utils.InternalServerError(w, errors.Wrapf(err, "failed to mount volume %s", vol.Name()))
+		return
+	}
+	defer func() {
+		if err := vol.Unmount(); err != nil {
+			logrus.Errorf("failed to unmount volume %s: %v", vol.Name(), err)
+		}
+	}()
+
+	compression := archive.Uncompressed
+	contentType := "application/x-tar"
+	if query.Compress {
+		compression = archive.Gzip
+		contentType = "application/gzip"
+	}
+	tarStream, err := archive.TarWithOptions(mountPoint, &archive.TarOptions{Compression: compression})
+	if err != nil {
+		utils.InternalServerError(w, errors.Wrapf(err, "failed to archive volume %s", vol.Name()))
+		return
+	}
+	defer tarStream.Close()
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, tarStream); err != nil {
+		logrus.Errorf("unable to stream volume %s: %q", vol.Name(), err)
+	}
+}
+
+// ImportVolume extracts a tar archive from the request body into a volume
+func ImportVolume(w http.ResponseWriter, r *http.Request) {
+	var (
+		runtime = r.Context().Value("runtime").(*libpod.Runtime)
+		decoder = r.Context().Value("decoder").(*schema.Decoder)
+	)
+	query := struct {
+		Force bool `schema:"force"`
+	}{
+		// override any golang type defaults
+	}
+
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	name := utils.GetName(r)
+	vol, err := runtime.LookupVolume(name)
+	if err != nil {
+		utils.VolumeNotFound(w, name, err)
+		return
+	}
+
+	// Writing underneath a running container may corrupt its data, so
+	// refuse unless the client insists.
+	if !query.Force {
+		ctrIDs, err := vol.VolumeInUse()
+		if err != nil {
+			utils.InternalServerError(w, err)
+			return
+		}
+		for _, id := range ctrIDs {
+			ctr, err := runtime.GetContainer(id)
+			if err != nil {
+				utils.InternalServerError(w, err)
+				return
+			}
+			state, err := ctr.State()
+			if err != nil {
+				utils.InternalServerError(w, err)
+				return
+			}
+			if state == define.ContainerStateRunning || state == define.ContainerStatePaused {
+				utils.Error(w, "volumes being used", http.StatusConflict,
+					errors.Wrapf(define.ErrVolumeBeingUsed, "volume %s is in use by running container %s", vol.Name(), ctr.ID()))
+				return
+			}
+		}
+	}
+
+	mountPoint, err := vol.Mount()
+	if err != nil {
+		utils.InternalServerError(w, errors.Wrapf(err, "failed to mount volume %s", vol.Name()))
+		return
+	}
+	defer func() {
+		if err := vol.Unmount(); err != nil {
+			logrus.Errorf("failed to unmount volume %s: %v", vol.Name(), err)
+		}
+	}()
+
+	// Untar detects and handles compressed archives on its own.
+	if err := archive.Untar(r.Body, mountPoint, nil); err != nil {
+		utils.InternalServerError(w, errors.Wrapf(err, "failed to import into volume %s", vol.Name()))
+		return
+	}
+	utils.WriteResponse(w, http.StatusNoContent, "")
+}
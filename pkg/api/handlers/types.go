@@ -261,6 +261,15 @@ func ImageDataToImageInspect(ctx context.Context, l *libpodImage.Image) (*ImageI
 			rootfs.Layers = append(rootfs.Layers, string(layer))
 		}
 	}
+	graphDriver := docker.GraphDriverData{}
+	if info.GraphDriver != nil {
+		graphDriver.Name = info.GraphDriver.Name
+		graphDriver.Data = info.GraphDriver.Data
+	}
+	parent := info.Parent
+	if parent != "" {
+		parent = "sha256:" + parent
+	}
 	dockerImageInspect := docker.ImageInspect{
 		Architecture:  info.Architecture,
 		Author:        info.Author,
@@ -268,12 +277,12 @@ func ImageDataToImageInspect(ctx context.Context, l *libpodImage.Image) (*ImageI
 		Config:        &config,
 		Created:       l.Created().Format(time.RFC3339Nano),
 		DockerVersion: info.Version,
-		GraphDriver:   docker.GraphDriverData{},
+		GraphDriver:   graphDriver,
 		ID:            fmt.Sprintf("sha256:%s", l.ID()),
 		Metadata:      docker.ImageMetadata{},
 		Os:            info.Os,
-		OsVersion:     info.Version,
-		Parent:        info.Parent,
+		OsVersion:     "",
+		Parent:        parent,
 		RepoDigests:   info.RepoDigests,
 		RepoTags:      info.RepoTags,
 		RootFS:        rootfs,
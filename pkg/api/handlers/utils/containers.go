@@ -194,12 +194,29 @@ var notRunningStates = []define.ContainerStatus{
 	define.ContainerStateConfigured,
 }
 
+func isRemovedError(err error) bool {
+	cause := errors.Cause(err)
+	return cause == define.ErrNoSuchCtr || cause == define.ErrCtrRemoved
+}
+
+// waitRemoved waits for the container to be removed, and returns its exit
+// code, which is captured when it stops as it is gone with the container.
 func waitRemoved(ctrWait containerWaitFn) (int32, error) {
-	code, err := ctrWait(define.ContainerStateUnknown)
-	if err != nil && errors.Cause(err) == define.ErrNoSuchCtr {
-		return code, nil
+	code, err := ctrWait(notRunningStates...)
+	if err != nil {
+		if isRemovedError(err) {
+			return 0, nil
+		}
+		return code, err
 	}
-	return code, err
+	if code < 0 {
+		// The container was never started.
+		code = 0
+	}
+	if _, err := ctrWait(define.ContainerStateUnknown); err != nil && !isRemovedError(err) {
+		return -1, err
+	}
+	return code, nil
 }
 
 func waitNextExit(ctrWait containerWaitFn) (int32, error) {
@@ -3,6 +3,7 @@ package utils
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/containers/podman/v3/libpod/define"
 	"github.com/containers/podman/v3/pkg/errorhandling"
@@ -22,6 +23,9 @@ var (
 // apiMessage and code must match the container API, and are sent to client
 // err is logged on the system running the podman service
 func Error(w http.ResponseWriter, apiMessage string, code int, err error) {
+	if err == nil {
+		err = errors.New(apiMessage)
+	}
 	// Log detailed message of what happened to machine running podman service
 	log.Infof("Request Failed(%s): %s", http.StatusText(code), err.Error())
 	em := errorhandling.ErrorModel{
@@ -35,56 +39,72 @@ func Error(w http.ResponseWriter, apiMessage string, code int, err error) {
 func VolumeNotFound(w http.ResponseWriter, name string, err error) {
 	if errors.Cause(err) != define.ErrNoSuchVolume {
 		InternalServerError(w, err)
+		return
 	}
 	msg := fmt.Sprintf("No such volume: %s", name)
-	Error(w, msg, http.StatusNotFound, err)
+	notFound(w, msg, name, err)
 }
 
 func ContainerNotFound(w http.ResponseWriter, name string, err error) {
 	if errors.Cause(err) != define.ErrNoSuchCtr {
 		InternalServerError(w, err)
+		return
 	}
 	msg := fmt.Sprintf("No such container: %s", name)
-	Error(w, msg, http.StatusNotFound, err)
+	notFound(w, msg, name, err)
 }
 
 func ImageNotFound(w http.ResponseWriter, name string, err error) {
 	if errors.Cause(err) != define.ErrNoSuchImage {
 		InternalServerError(w, err)
+		return
 	}
 	msg := fmt.Sprintf("No such image: %s", name)
-	Error(w, msg, http.StatusNotFound, err)
+	notFound(w, msg, name, err)
 }
 
 func NetworkNotFound(w http.ResponseWriter, name string, err error) {
 	if errors.Cause(err) != define.ErrNoSuchNetwork {
 		InternalServerError(w, err)
+		return
 	}
 	msg := fmt.Sprintf("No such network: %s", name)
-	Error(w, msg, http.StatusNotFound, err)
+	notFound(w, msg, name, err)
 }
 
 func PodNotFound(w http.ResponseWriter, name string, err error) {
 	if errors.Cause(err) != define.ErrNoSuchPod {
 		InternalServerError(w, err)
+		return
 	}
 	msg := fmt.Sprintf("No such pod: %s", name)
-	Error(w, msg, http.StatusNotFound, err)
+	notFound(w, msg, name, err)
 }
 
 func SessionNotFound(w http.ResponseWriter, name string, err error) {
 	if errors.Cause(err) != define.ErrNoSuchExecSession {
 		InternalServerError(w, err)
+		return
 	}
 	msg := fmt.Sprintf("No such exec session: %s", name)
-	Error(w, msg, http.StatusNotFound, err)
+	notFound(w, msg, name, err)
 }
 
 func SecretNotFound(w http.ResponseWriter, nameOrID string, err error) {
 	if errors.Cause(err).Error() != "no such secret" {
 		InternalServerError(w, err)
+		return
 	}
 	msg := fmt.Sprintf("No such secret: %s", nameOrID)
+	notFound(w, msg, nameOrID, err)
+}
+
+// notFound reports a 404, the message naming what was not found even when
+// the error does not.
+func notFound(w http.ResponseWriter, msg string, name string, err error) {
+	if !strings.Contains(err.Error(), name) {
+		err = errors.Wrap(err, msg)
+	}
 	Error(w, msg, http.StatusNotFound, err)
 }
 
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/pkg/errorhandling"
+	"github.com/pkg/errors"
+)
+
+func TestContainerNotFound(t *testing.T) {
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{errors.Wrapf(define.ErrNoSuchCtr, "no container with name or ID %q found", "nonesuch"), http.StatusNotFound},
+		// the message names the container even when the error does not
+		{define.ErrNoSuchCtr, http.StatusNotFound},
+		{errors.New("broken database"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		rr := httptest.NewRecorder()
+		ContainerNotFound(rr, "nonesuch", tt.err)
+		if rr.Code != tt.code {
+			t.Errorf("%v: returned %d, expected %d", tt.err, rr.Code, tt.code)
+		}
+		if ct := rr.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("%v: returned content type %q", tt.err, ct)
+		}
+		var em errorhandling.ErrorModel
+		if err := json.NewDecoder(rr.Body).Decode(&em); err != nil {
+			t.Fatalf("%v: %v", tt.err, err)
+		}
+		if !strings.Contains(em.Message, tt.err.Error()) {
+			t.Errorf("%v: message %q is not the error", tt.err, em.Message)
+		}
+		if tt.code == http.StatusNotFound && !strings.Contains(em.Message, "nonesuch") {
+			t.Errorf("%v: message %q does not name the container", tt.err, em.Message)
+		}
+	}
+}
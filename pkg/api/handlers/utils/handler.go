@@ -31,6 +31,46 @@ func IsLibpodRequest(r *http.Request) bool {
 	return len(split) >= 3 && split[2] == "libpod"
 }
 
+const (
+	// DockerMediaType requests the Docker compatible shape of a response
+	DockerMediaType = "application/vnd.docker.v1+json"
+	// LibpodMediaType requests the libpod shape of a response
+	LibpodMediaType = "application/vnd.libpod.v1+json"
+)
+
+// WantsLibpodFormat returns true if the response to the request should use
+// the libpod shape rather than the Docker compatible one.  Clients choose the
+// shape with the DockerMediaType or LibpodMediaType in the Accept header,
+// otherwise it follows the endpoint (see IsLibpodRequest).
+func WantsLibpodFormat(r *http.Request) bool {
+	var (
+		libpodQ, dockerQ = -1.0, -1.0
+	)
+	for _, header := range r.Header["Accept"] {
+		for _, accept := range strings.Split(header, ",") {
+			params := strings.Split(accept, ";")
+			q := 1.0
+			for _, param := range params[1:] {
+				if v := strings.TrimSpace(param); strings.HasPrefix(v, "q=") {
+					if _, err := fmt.Sscanf(v, "q=%g", &q); err != nil {
+						q = 0
+					}
+				}
+			}
+			switch strings.ToLower(strings.TrimSpace(params[0])) {
+			case LibpodMediaType:
+				libpodQ = q
+			case DockerMediaType:
+				dockerQ = q
+			}
+		}
+	}
+	if libpodQ <= 0 && dockerQ <= 0 {
+		return IsLibpodRequest(r)
+	}
+	return libpodQ > dockerQ
+}
+
 // SupportedVersion validates that the version provided by client is included in the given condition
 // https://github.com/blang/semver#ranges provides the details for writing conditions
 // If a version is not given in URL path, ErrVersionNotGiven is returned
@@ -138,3 +138,32 @@ func TestEqualVersion(t *testing.T) {
 			rr.Body.String(), expected)
 	}
 }
+
+func TestWantsLibpodFormat(t *testing.T) {
+	tests := []struct {
+		path   string
+		accept string
+		libpod bool
+	}{
+		{"/v1.40/containers/json", "", false},
+		{"/v1.40/libpod/containers/json", "", true},
+		{"/v1.40/containers/json", "application/json", false},
+		{"/v1.40/containers/json", LibpodMediaType, true},
+		{"/v1.40/libpod/containers/json", DockerMediaType, false},
+		{"/v1.40/containers/json", DockerMediaType + ";q=0.5, " + LibpodMediaType, true},
+		{"/v1.40/libpod/containers/json", LibpodMediaType + ";q=0.2, " + DockerMediaType + ";q=0.9", false},
+		{"/v1.40/libpod/containers/json", DockerMediaType + ";q=0", true},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest("GET", tt.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tt.accept != "" {
+			req.Header.Set("Accept", tt.accept)
+		}
+		if got := WantsLibpodFormat(req); got != tt.libpod {
+			t.Errorf("WantsLibpodFormat(%s, Accept: %q) = %v, want %v", tt.path, tt.accept, got, tt.libpod)
+		}
+	}
+}
@@ -3,7 +3,6 @@ package utils
 import (
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/containers/image/v5/docker"
 	"github.com/containers/image/v5/storage"
@@ -87,7 +86,9 @@ func GetImages(w http.ResponseWriter, r *http.Request) ([]*image.Image, error) {
 
 	if len(queryFilters) > 0 {
 		for k, v := range queryFilters {
-			filters = append(filters, fmt.Sprintf("%s=%s", k, strings.Join(v, "=")))
+			for _, val := range v {
+				filters = append(filters, fmt.Sprintf("%s=%s", k, val))
+			}
 		}
 		images, err = runtime.ImageRuntime().GetImagesWithFilters(filters)
 		if err != nil {
@@ -0,0 +1,289 @@
+package utils
+
+import (
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containers/podman/v3/libpod"
+	"github.com/containers/podman/v3/libpod/define"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/containers/podman/v3/pkg/specgen"
+	"github.com/containers/storage/pkg/stringid"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// portReservation holds host ports open until a container is created with
+// them, so that no other process can bind them meanwhile.
+type portReservation struct {
+	entities.PortReservation
+	listeners []io.Closer
+	timer     *time.Timer
+	// claimed is set while a container is created with the reservation.
+	claimed bool
+}
+
+func (p *portReservation) close() {
+	if p.timer != nil {
+		p.timer.Stop()
+	}
+	for _, l := range p.listeners {
+		if err := l.Close(); err != nil {
+			logrus.Warnf("Unable to release reserved port: %v", err)
+		}
+	}
+	p.listeners = nil
+}
+
+// portReservations are the port reservations of the API service, by token,
+// and the ones used by containers which were not started yet, by container
+// ID.  The ports of the latter are not bound by the service, so that the
+// container can bind them however it is started, but other creates cannot
+// map them.
+var portReservations = struct {
+	lock        sync.Mutex
+	byToken     map[string]*portReservation
+	byContainer map[string]*portReservation
+}{
+	byToken:     make(map[string]*portReservation),
+	byContainer: make(map[string]*portReservation),
+}
+
+// ReservePorts binds the given host ports, port 0 binds a free port, until
+// a container is created with the reservation, the reservation is released
+// or it expires after ttl.
+func ReservePorts(protocol string, ports []uint16, ttl time.Duration) (*entities.PortReservation, error) {
+	res := &portReservation{PortReservation: entities.PortReservation{
+		Token:    stringid.GenerateRandomID(),
+		Protocol: protocol,
+		Ports:    make([]uint16, 0, len(ports)),
+	}}
+	for _, port := range ports {
+		listener, bound, err := bindPort(protocol, port)
+		if err != nil {
+			res.close()
+			return nil, errors.Wrapf(err, "unable to reserve %s port %d", protocol, port)
+		}
+		res.listeners = append(res.listeners, listener)
+		res.Ports = append(res.Ports, bound)
+	}
+	res.Expires = time.Now().Add(ttl)
+	res.timer = time.AfterFunc(ttl, func() {
+		if released := ReleasePortReservation(res.Token); released != nil {
+			logrus.Infof("Port reservation %s expired", res.Token)
+		}
+	})
+
+	portReservations.lock.Lock()
+	portReservations.byToken[res.Token] = res
+	portReservations.lock.Unlock()
+	return &res.PortReservation, nil
+}
+
+// ReleasePortReservation removes a reservation and unbinds its ports.  It
+// returns nil if there is no such reservation.
+func ReleasePortReservation(token string) *entities.PortReservation {
+	portReservations.lock.Lock()
+	res, ok := portReservations.byToken[token]
+	delete(portReservations.byToken, token)
+	portReservations.lock.Unlock()
+	if !ok {
+		return nil
+	}
+	res.close()
+	return &res.PortReservation
+}
+
+func bindPort(protocol string, port uint16) (io.Closer, uint16, error) {
+	addr := ":" + strconv.Itoa(int(port))
+	if protocol == "udp" {
+		conn, err := net.ListenPacket("udp", addr)
+		if err != nil {
+			return nil, 0, err
+		}
+		return conn, uint16(conn.LocalAddr().(*net.UDPAddr).Port), nil
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, 0, err
+	}
+	return listener, uint16(listener.Addr().(*net.TCPAddr).Port), nil
+}
+
+// containerNotStarted returns whether the container with the given ID
+// exists and was not started yet.
+func containerNotStarted(runtime *libpod.Runtime) func(ctrID string) bool {
+	return func(ctrID string) bool {
+		ctr, err := runtime.LookupContainer(ctrID)
+		if err != nil {
+			return false
+		}
+		state, err := ctr.State()
+		return err == nil && (state == define.ContainerStateConfigured || state == define.ContainerStateCreated)
+	}
+}
+
+// pruneContainerPorts drops the reserved ports of containers which were
+// started or removed.  It is called without the lock held as looking up the
+// containers waits for their locks.
+func pruneContainerPorts(notStarted func(ctrID string) bool) {
+	portReservations.lock.Lock()
+	ids := make([]string, 0, len(portReservations.byContainer))
+	for id := range portReservations.byContainer {
+		ids = append(ids, id)
+	}
+	portReservations.lock.Unlock()
+	for _, id := range ids {
+		if !notStarted(id) {
+			portReservations.lock.Lock()
+			delete(portReservations.byContainer, id)
+			portReservations.lock.Unlock()
+		}
+	}
+}
+
+// reservedPorts returns all reservations, the held ones and the ones of
+// containers not started yet.  The lock must be held.
+func reservedPorts() []*portReservation {
+	all := make([]*portReservation, 0, len(portReservations.byToken)+len(portReservations.byContainer))
+	for _, res := range portReservations.byToken {
+		all = append(all, res)
+	}
+	for _, res := range portReservations.byContainer {
+		all = append(all, res)
+	}
+	return all
+}
+
+// CheckReservedPorts returns an error if a port mapping uses a host port
+// reserved by a reservation other than the given one, or used by a
+// container created with a reservation which was not started yet.
+func CheckReservedPorts(runtime *libpod.Runtime, mappings []specgen.PortMapping, token string) error {
+	return checkReservedPorts(mappings, token, containerNotStarted(runtime))
+}
+
+func checkReservedPorts(mappings []specgen.PortMapping, token string, notStarted func(ctrID string) bool) error {
+	pruneContainerPorts(notStarted)
+	portReservations.lock.Lock()
+	defer portReservations.lock.Unlock()
+	for _, res := range reservedPorts() {
+		if token != "" && res.Token == token {
+			continue
+		}
+		for _, m := range mappings {
+			if m.HostPort == 0 || !mappingHasProtocol(m, res.Protocol) {
+				continue
+			}
+			count := m.Range
+			if count == 0 {
+				count = 1
+			}
+			for _, port := range res.Ports {
+				if port >= m.HostPort && int(port) < int(m.HostPort)+int(count) {
+					return errors.Errorf("host %s port %d is reserved", res.Protocol, port)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// PortReserved returns true if a port reservation holds the host port, or
+// a container other than ctrID created with a reservation which was not
+// started yet uses it.
+func PortReserved(runtime *libpod.Runtime, ctrID string, port uint16, protocol string) bool {
+	return portReserved(ctrID, port, protocol, containerNotStarted(runtime))
+}
+
+func portReserved(ctrID string, port uint16, protocol string, notStarted func(ctrID string) bool) bool {
+	pruneContainerPorts(notStarted)
+	portReservations.lock.Lock()
+	defer portReservations.lock.Unlock()
+	for _, res := range reservedPorts() {
+		if res == portReservations.byContainer[ctrID] {
+			continue
+		}
+		if res.Protocol != protocol {
+			continue
+		}
+		for _, p := range res.Ports {
+			if p == port {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ClaimPortReservation claims the reservation for a container about to be
+// created, and assigns the reserved ports to the mappings without a host
+// port.  The ports stay bound: once the container is created the reservation
+// is passed to it with HoldPortReservation, if the create fails the claim is
+// undone with UnclaimPortReservation.
+func ClaimPortReservation(mappings []specgen.PortMapping, token string) error {
+	portReservations.lock.Lock()
+	defer portReservations.lock.Unlock()
+	res, ok := portReservations.byToken[token]
+	if !ok {
+		return errors.Errorf("no port reservation %q, it may have expired", token)
+	}
+	if res.claimed {
+		return errors.Errorf("port reservation %q is already used", token)
+	}
+	res.claimed = true
+	free := res.Ports
+	for i := range mappings {
+		m := &mappings[i]
+		if len(free) == 0 {
+			break
+		}
+		if m.HostPort == 0 && m.Range <= 1 && mappingHasProtocol(*m, res.Protocol) {
+			m.HostPort = free[0]
+			free = free[1:]
+		}
+	}
+	return nil
+}
+
+// UnclaimPortReservation makes a reservation claimed by a failed create
+// usable again.
+func UnclaimPortReservation(token string) {
+	portReservations.lock.Lock()
+	defer portReservations.lock.Unlock()
+	if res, ok := portReservations.byToken[token]; ok {
+		res.claimed = false
+	}
+}
+
+// HoldPortReservation passes a claimed reservation to the created container.
+// Its ports are unbound, as the container may be started by any process,
+// and no longer expire: they stay reserved against other creates until the
+// container is started or removed.
+func HoldPortReservation(token, ctrID string) {
+	portReservations.lock.Lock()
+	res, ok := portReservations.byToken[token]
+	if ok {
+		delete(portReservations.byToken, token)
+		portReservations.byContainer[ctrID] = res
+	}
+	portReservations.lock.Unlock()
+	if ok {
+		res.close()
+	}
+}
+
+func mappingHasProtocol(m specgen.PortMapping, protocol string) bool {
+	if m.Protocol == "" {
+		return protocol == "tcp"
+	}
+	for _, p := range strings.Split(m.Protocol, ",") {
+		if strings.TrimSpace(strings.ToLower(p)) == protocol {
+			return true
+		}
+	}
+	return false
+}
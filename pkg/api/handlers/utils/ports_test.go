@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/containers/podman/v3/pkg/specgen"
+)
+
+func TestPortReservationLifecycle(t *testing.T) {
+	res, err := ReservePorts("tcp", []uint16{0}, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ReleasePortReservation(res.Token)
+	port := res.Ports[0]
+	addr := ":" + strconv.Itoa(int(port))
+	bound := func() bool {
+		l, err := net.Listen("tcp", addr)
+		if err != nil {
+			return true
+		}
+		l.Close()
+		return false
+	}
+
+	notStarted := map[string]bool{}
+	isNotStarted := func(ctrID string) bool { return notStarted[ctrID] }
+	grab := []specgen.PortMapping{{ContainerPort: 80, HostPort: port}}
+	mappings := []specgen.PortMapping{{ContainerPort: 80}}
+	if err := checkReservedPorts(grab, "", isNotStarted); err == nil {
+		t.Error("reserved port not refused without the token")
+	}
+	if err := ClaimPortReservation(mappings, res.Token); err != nil {
+		t.Fatal(err)
+	}
+	if mappings[0].HostPort != port {
+		t.Errorf("mapping got host port %d, expected %d", mappings[0].HostPort, port)
+	}
+	if err := ClaimPortReservation(mappings, res.Token); err == nil {
+		t.Error("reservation claimed twice")
+	}
+
+	// A failed create gives the reservation back.
+	UnclaimPortReservation(res.Token)
+	if !bound() {
+		t.Fatal("port released by a failed create")
+	}
+	if err := ClaimPortReservation(mappings, res.Token); err != nil {
+		t.Fatalf("reservation not usable after a failed create: %v", err)
+	}
+
+	// The port is unbound for the created container to bind it, but stays
+	// reserved against other creates until it starts.
+	notStarted["ctr"] = true
+	HoldPortReservation(res.Token, "ctr")
+	if bound() {
+		t.Error("port still bound by the service after the container was created")
+	}
+	if ReleasePortReservation(res.Token) != nil {
+		t.Error("reservation left after the container was created")
+	}
+	if err := checkReservedPorts(grab, "", isNotStarted); err == nil {
+		t.Error("port of a container not started yet not refused")
+	}
+	if !portReserved("other", port, "tcp", isNotStarted) {
+		t.Error("port of a container not started yet not reported as reserved")
+	}
+	if portReserved("ctr", port, "tcp", isNotStarted) {
+		t.Error("port of a container reported as reserved for itself")
+	}
+	notStarted["ctr"] = false
+	if err := checkReservedPorts(grab, "", isNotStarted); err != nil {
+		t.Errorf("port of a started container still reserved: %v", err)
+	}
+}
@@ -0,0 +1,260 @@
+package utils
+
+import (
+	"bufio"
+	"crypto/sha1" // nolint:gosec
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// The parts of RFC 6455 needed to exchange messages with clients over a
+// WebSocket, there is no WebSocket library vendored.
+const (
+	websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+	websocketOpContinuation = 0x0
+	websocketOpText         = 0x1
+	websocketOpBinary       = 0x2
+	websocketOpClose        = 0x8
+	websocketOpPing         = 0x9
+	websocketOpPong         = 0xa
+
+	// websocketMaxMessage limits the size of messages read from clients.
+	websocketMaxMessage = 1 << 20
+)
+
+// ErrWebsocketClosed is returned when reading from a WebSocket closed by
+// the client.
+var ErrWebsocketClosed = errors.New("websocket closed by client")
+
+// WebsocketConn is the server side of a WebSocket connection.
+type WebsocketConn struct {
+	conn net.Conn
+	buf  *bufio.ReadWriter
+	// writeLock serializes writing messages, pings are answered while
+	// reading.
+	writeLock sync.Mutex
+	// pending is the part of the message read by Read not returned yet.
+	pending []byte
+}
+
+// isWebsocketUpgrade returns true if the request asks to upgrade the
+// connection to a WebSocket.
+func isWebsocketUpgrade(r *http.Request) bool {
+	return headerHasToken(r.Header, "Connection", "upgrade") &&
+		headerHasToken(r.Header, "Upgrade", "websocket")
+}
+
+func headerHasToken(h http.Header, key, token string) bool {
+	for _, value := range h.Values(key) {
+		for _, t := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CheckWebsocketUpgrade verifies the request is a WebSocket upgrade this
+// server can complete.
+func CheckWebsocketUpgrade(r *http.Request) error {
+	if !isWebsocketUpgrade(r) {
+		return errors.New("request is not a websocket upgrade")
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		return errors.Errorf("unsupported websocket version %q, only 13 is supported", r.Header.Get("Sec-WebSocket-Version"))
+	}
+	if r.Header.Get("Sec-WebSocket-Key") == "" {
+		return errors.New("missing Sec-WebSocket-Key header")
+	}
+	return nil
+}
+
+// UpgradeWebsocket hijacks the connection of a request verified with
+// CheckWebsocketUpgrade and completes the opening handshake.  If the
+// connection was hijacked, it is closed on errors.
+func UpgradeWebsocket(w http.ResponseWriter, r *http.Request) (*WebsocketConn, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		return nil, errors.New("unable to hijack connection")
+	}
+	conn, buf, err := hijacker.Hijack()
+	if err != nil {
+		return nil, errors.Wrap(err, "error hijacking connection")
+	}
+
+	sum := sha1.Sum([]byte(key + websocketGUID)) // nolint:gosec
+	accept := base64.StdEncoding.EncodeToString(sum[:])
+	if _, err := buf.WriteString("HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + accept + "\r\n\r\n"); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := buf.Flush(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &WebsocketConn{conn: conn, buf: buf}, nil
+}
+
+// ReadMessage reads the next text or binary message, answering pings in
+// between.  It returns ErrWebsocketClosed when the client closes the
+// connection.
+func (c *WebsocketConn) ReadMessage() ([]byte, error) {
+	var message []byte
+	started := false
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+		switch opcode {
+		case websocketOpPing:
+			if err := c.writeFrame(websocketOpPong, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case websocketOpPong:
+			continue
+		case websocketOpClose:
+			// Echo the status code as required, the connection is
+			// closed afterwards.
+			if len(payload) > 2 {
+				payload = payload[:2]
+			}
+			_ = c.writeFrame(websocketOpClose, payload)
+			return nil, ErrWebsocketClosed
+		case websocketOpText, websocketOpBinary:
+			if started {
+				return nil, errors.New("websocket message interrupted by a new message")
+			}
+			started = true
+		case websocketOpContinuation:
+			if !started {
+				return nil, errors.New("websocket continuation frame without a message")
+			}
+		default:
+			return nil, errors.Errorf("unknown websocket opcode %#x", opcode)
+		}
+		if len(message)+len(payload) > websocketMaxMessage {
+			return nil, errors.Errorf("websocket message exceeds %d bytes", websocketMaxMessage)
+		}
+		message = append(message, payload...)
+		if fin {
+			return message, nil
+		}
+	}
+}
+
+func (c *WebsocketConn) readFrame() (bool, byte, []byte, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.buf, header[:]); err != nil {
+		return false, 0, nil, err
+	}
+	fin := header[0]&0x80 != 0
+	opcode := header[0] & 0x0f
+	if header[1]&0x80 == 0 {
+		return false, 0, nil, errors.New("websocket frame from client is not masked")
+	}
+	length := uint64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.buf, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.buf, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if length > websocketMaxMessage {
+		return false, 0, nil, errors.Errorf("websocket frame exceeds %d bytes", websocketMaxMessage)
+	}
+	var mask [4]byte
+	if _, err := io.ReadFull(c.buf, mask[:]); err != nil {
+		return false, 0, nil, err
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.buf, payload); err != nil {
+		return false, 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return fin, opcode, payload, nil
+}
+
+// Read reads the messages of the client as a stream, for input sent in
+// messages of any size.  It returns ErrWebsocketClosed when the client
+// closes the connection, and must not be mixed with ReadMessage.
+func (c *WebsocketConn) Read(p []byte) (int, error) {
+	for len(c.pending) == 0 {
+		message, err := c.ReadMessage()
+		if err != nil {
+			return 0, err
+		}
+		c.pending = message
+	}
+	n := copy(p, c.pending)
+	c.pending = c.pending[n:]
+	return n, nil
+}
+
+// WriteText sends a text message.
+func (c *WebsocketConn) WriteText(message []byte) error {
+	return c.writeFrame(websocketOpText, message)
+}
+
+// Write sends p as a binary message.
+func (c *WebsocketConn) Write(p []byte) (int, error) {
+	if err := c.writeFrame(websocketOpBinary, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (c *WebsocketConn) writeFrame(opcode byte, payload []byte) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+	header := []byte{0x80 | opcode, 0}
+	switch {
+	case len(payload) < 126:
+		header[1] = byte(len(payload))
+	case len(payload) <= 0xffff:
+		header[1] = 126
+		header = append(header, 0, 0)
+		binary.BigEndian.PutUint16(header[2:], uint16(len(payload)))
+	default:
+		header[1] = 127
+		header = append(header, make([]byte, 8)...)
+		binary.BigEndian.PutUint64(header[2:], uint64(len(payload)))
+	}
+	if _, err := c.buf.Write(header); err != nil {
+		return err
+	}
+	if _, err := c.buf.Write(payload); err != nil {
+		return err
+	}
+	return c.buf.Flush()
+}
+
+// Close sends a normal closure and closes the connection.
+func (c *WebsocketConn) Close() error {
+	_ = c.writeFrame(websocketOpClose, []byte{0x03, 0xe8})
+	return c.conn.Close()
+}
@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeClientFrame writes a masked frame, as clients do.
+func writeClientFrame(t *testing.T, conn net.Conn, opcode byte, payload string) {
+	mask := []byte{1, 2, 3, 4}
+	frame := []byte{0x80 | opcode, 0x80 | byte(len(payload))}
+	frame = append(frame, mask...)
+	for i := 0; i < len(payload); i++ {
+		frame = append(frame, payload[i]^mask[i%4])
+	}
+	if _, err := conn.Write(frame); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// readServerFrame reads an unmasked frame of at most 125 bytes.
+func readServerFrame(t *testing.T, r *bufio.Reader) (byte, string) {
+	var header [2]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		t.Fatal(err)
+	}
+	payload := make([]byte, header[1]&0x7f)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		t.Fatal(err)
+	}
+	return header[0] & 0x0f, string(payload)
+}
+
+func TestWebsocketStream(t *testing.T) {
+	done := make(chan error, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := CheckWebsocketUpgrade(r); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ws, err := UpgradeWebsocket(w, r)
+		if err != nil {
+			done <- err
+			return
+		}
+		defer ws.Close()
+		// Echo the stream in reads of 3 bytes
+		buf := make([]byte, 3)
+		for {
+			n, err := ws.Read(buf)
+			if err != nil {
+				done <- err
+				return
+			}
+			if _, err := ws.Write(buf[:n]); err != nil {
+				done <- err
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("plain request returned %d, expected %d", resp.StatusCode, http.StatusBadRequest)
+	}
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(srv.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(10 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.WriteString(conn, "GET / HTTP/1.1\r\nHost: localhost\r\n"+
+		"Upgrade: websocket\r\nConnection: Upgrade\r\n"+
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n\r\n"); err != nil {
+		t.Fatal(err)
+	}
+	r := bufio.NewReader(conn)
+	resp, err = http.ReadResponse(r, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("upgrade returned %d, expected %d", resp.StatusCode, http.StatusSwitchingProtocols)
+	}
+	// The example of RFC 6455
+	if accept := resp.Header.Get("Sec-WebSocket-Accept"); accept != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("Sec-WebSocket-Accept is %q", accept)
+	}
+
+	// Pings are answered, and are not part of the stream.
+	writeClientFrame(t, conn, websocketOpPing, "keepalive")
+	writeClientFrame(t, conn, websocketOpBinary, "hello")
+	if opcode, payload := readServerFrame(t, r); opcode != websocketOpPong || payload != "keepalive" {
+		t.Errorf("got frame %#x %q, expected the pong", opcode, payload)
+	}
+	var echoed string
+	for len(echoed) < len("hello") {
+		opcode, payload := readServerFrame(t, r)
+		if opcode != websocketOpBinary {
+			t.Fatalf("got frame %#x %q, expected a binary message", opcode, payload)
+		}
+		echoed += payload
+	}
+	if echoed != "hello" {
+		t.Errorf("echoed %q, expected %q", echoed, "hello")
+	}
+
+	writeClientFrame(t, conn, websocketOpClose, "\x03\xe8")
+	if opcode, payload := readServerFrame(t, r); opcode != websocketOpClose || payload != "\x03\xe8" {
+		t.Errorf("got frame %#x %q, expected the close to be echoed", opcode, payload)
+	}
+	if err := <-done; err != ErrWebsocketClosed {
+		t.Errorf("reading the closed stream returned %v, expected %v", err, ErrWebsocketClosed)
+	}
+}
@@ -1,21 +1,41 @@
 package server
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"runtime"
+	"strconv"
+	"time"
 
+	"github.com/blang/semver"
 	"github.com/containers/podman/v3/pkg/api/handlers/utils"
 	"github.com/containers/podman/v3/pkg/auth"
 	"github.com/containers/podman/v3/version"
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
 // APIHandler is a wrapper to enhance HandlerFunc's and remove redundant code
 func (s *APIServer) APIHandler(h http.HandlerFunc) http.HandlerFunc {
+	return s.apiHandler(h, s.writeTimeout)
+}
+
+// StreamingAPIHandler is APIHandler for the endpoints streaming or hijacking
+// their response, they are exempt from the write timeout of the server.
+func (s *APIServer) StreamingAPIHandler(h http.HandlerFunc) http.HandlerFunc {
+	return s.apiHandler(h, 0)
+}
+
+func (s *APIServer) apiHandler(h http.HandlerFunc, writeTimeout time.Duration) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		rid := uuid.New().String()
+		start := time.Now()
+		sw := &statusWriter{ResponseWriter: w, server: s}
 		// http.Server hides panics, we want to see them and fix the cause.
 		defer func() {
 			err := recover()
@@ -23,14 +43,20 @@ func (s *APIServer) APIHandler(h http.HandlerFunc) http.HandlerFunc {
 				buf := make([]byte, 1<<20)
 				n := runtime.Stack(buf, true)
 				logrus.Warnf("Recovering from API handler panic: %v, %s", err, buf[:n])
-				// Try to inform client things went south... won't work if handler already started writing response body
-				utils.InternalServerError(w, fmt.Errorf("%v", err))
+				// Inform client things went south... unless handler already started writing response
+				if sw.status == 0 && !sw.hijacked {
+					utils.InternalServerError(sw, fmt.Errorf("%v", err))
+				}
 			}
+			status := strconv.Itoa(sw.Status())
+			if sw.hijacked {
+				status = "hijacked"
+			}
+			logrus.Infof("APIHandler(%s) -- %s %s END status %s in %s", rid, r.Method, r.URL.String(), status, time.Since(start))
 		}()
 
 		// Wrapper to hide some boiler plate
 		fn := func(w http.ResponseWriter, r *http.Request) {
-			rid := uuid.New().String()
 			logrus.Infof("APIHandler(%s) -- %s %s BEGIN", rid, r.Method, r.URL.String())
 			if logrus.IsLevelEnabled(logrus.DebugLevel) {
 				for k, v := range r.Header {
@@ -45,8 +71,34 @@ func (s *APIServer) APIHandler(h http.HandlerFunc) http.HandlerFunc {
 			// Set in case handler wishes to correlate logging events
 			r.Header.Set("X-Reference-Id", rid)
 
-			if err := r.ParseForm(); err != nil {
+			// The deadline is set on each request, a streaming one may
+			// follow another on a keep-alive connection.
+			if conn, ok := r.Context().Value("conn").(net.Conn); ok {
+				var deadline time.Time
+				if writeTimeout > 0 {
+					deadline = time.Now().Add(writeTimeout)
+				}
+				if err := conn.SetWriteDeadline(deadline); err != nil {
+					logrus.Debugf("APIHandler(%s) -- unable to set write deadline: %v", rid, err)
+				}
+			}
+
+			// Only the query is parsed into the form, r.ParseForm() would
+			// consume form encoded bodies, which handlers read themselves.
+			form, err := url.ParseQuery(r.URL.RawQuery)
+			if err != nil {
 				logrus.Infof("Failed Request: unable to parse form: %q (%s)", err, rid)
+				utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+					errors.Wrapf(err, "failed to parse query parameters"))
+				return
+			}
+			r.Form = form
+
+			apiVersion, err := negotiateVersion(r)
+			if err != nil {
+				logrus.Infof("Failed Request: %s (%s)", err, rid)
+				utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, err)
+				return
 			}
 
 			// TODO: Use r.ConnContext when ported to go 1.13
@@ -54,6 +106,7 @@ func (s *APIServer) APIHandler(h http.HandlerFunc) http.HandlerFunc {
 			c = context.WithValue(c, "runtime", s.Runtime)            // nolint
 			c = context.WithValue(c, "shutdownFunc", s.Shutdown)      // nolint
 			c = context.WithValue(c, "idletracker", s.idleTracker)    // nolint
+			c = context.WithValue(c, "apiVersion", apiVersion)        // nolint
 			r = r.WithContext(c)
 
 			cv := version.APIVersion[version.Compat][version.CurrentAPI]
@@ -64,10 +117,90 @@ func (s *APIServer) APIHandler(h http.HandlerFunc) http.HandlerFunc {
 			w.Header().Set("Server", "Libpod/"+lv+" ("+runtime.GOOS+")")
 
 			h(w, r)
-			logrus.Debugf("APIHandler(%s) -- %s %s END", rid, r.Method, r.URL.String())
 		}
-		fn(w, r)
+		fn(sw, r)
+	}
+}
+
+// negotiateVersion returns the API version of the request, the current one
+// when the path has no version.  Versions of the Docker API out of the
+// supported range are rejected as Docker does, libpod clients negotiate the
+// version themselves.
+func negotiateVersion(r *http.Request) (semver.Version, error) {
+	tree := version.Compat
+	if utils.IsLibpodRequest(r) {
+		tree = version.Libpod
+	}
+	current := version.APIVersion[tree][version.CurrentAPI]
+	v, err := utils.SupportedVersionWithDefaults(r)
+	switch {
+	case errors.Is(err, utils.ErrVersionNotGiven):
+		return current, nil
+	case errors.Is(err, utils.ErrVersionNotSupported):
+		if tree == version.Libpod {
+			return v, nil
+		}
+		if v.GT(current) {
+			return v, errors.Errorf("client version %d.%d is too new. Maximum supported API version is %d.%d",
+				v.Major, v.Minor, current.Major, current.Minor)
+		}
+		minimal := version.APIVersion[tree][version.MinimalAPI]
+		return v, errors.Errorf("client version %d.%d is too old. Minimum supported API version is %d.%d, please upgrade your client to a newer version",
+			v.Major, v.Minor, minimal.Major, minimal.Minor)
+	}
+	return v, err
+}
+
+// statusWriter records the status of the response for logging.  Handlers
+// streaming or hijacking the connection still find the Flusher and Hijacker
+// of the wrapped writer, hijacked connections are closed on shutdown.
+type statusWriter struct {
+	http.ResponseWriter
+	server   *APIServer
+	status   int
+	hijacked bool
+}
+
+func (sw *statusWriter) WriteHeader(status int) {
+	if sw.status == 0 {
+		sw.status = status
+	}
+	sw.ResponseWriter.WriteHeader(status)
+}
+
+func (sw *statusWriter) Write(b []byte) (int, error) {
+	if sw.status == 0 {
+		sw.status = http.StatusOK
+	}
+	return sw.ResponseWriter.Write(b)
+}
+
+// Status returns the status of the response, http.Server replies 200 to
+// handlers writing nothing.
+func (sw *statusWriter) Status() int {
+	if sw.status == 0 {
+		return http.StatusOK
+	}
+	return sw.status
+}
+
+func (sw *statusWriter) Flush() {
+	if flusher, ok := sw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (sw *statusWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := sw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	conn, buf, err := hijacker.Hijack()
+	if err == nil {
+		sw.hijacked = true
+		conn = sw.server.trackHijacked(conn)
 	}
+	return conn, buf, err
 }
 
 // VersionedPath prepends the version parsing code
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/containers/podman/v3/version"
+	"github.com/gorilla/mux"
+)
+
+func TestAPIHandlerRecoversPanic(t *testing.T) {
+	s := &APIServer{}
+	router := http.NewServeMux()
+	router.HandleFunc("/panic", s.APIHandler(func(w http.ResponseWriter, r *http.Request) {
+		panic("deliberate panic")
+	}))
+	router.HandleFunc("/ok", s.APIHandler(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/panic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("panicking handler returned %d, expected %d", resp.StatusCode, http.StatusInternalServerError)
+	}
+
+	resp, err = http.Get(srv.URL + "/ok")
+	if err != nil {
+		t.Fatalf("server did not survive panic: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("handler after panic returned %d, expected %d", resp.StatusCode, http.StatusNoContent)
+	}
+}
+
+func TestAPIHandlerBadQuery(t *testing.T) {
+	s := &APIServer{}
+	called := false
+	handler := s.APIHandler(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+
+	req := httptest.NewRequest("POST", "/images/foo/tag?repo=%zz", nil)
+	rr := httptest.NewRecorder()
+	handler(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("malformed query returned %d, expected %d", rr.Code, http.StatusBadRequest)
+	}
+	if called {
+		t.Error("handler called for malformed query")
+	}
+}
+
+func TestAPIHandlerVersion(t *testing.T) {
+	s := &APIServer{}
+	router := mux.NewRouter()
+	var got semver.Version
+	handler := s.APIHandler(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Context().Value("apiVersion").(semver.Version)
+	})
+	router.HandleFunc(VersionedPath("/containers/json"), handler)
+	router.HandleFunc("/containers/json", handler)
+	router.HandleFunc(VersionedPath("/libpod/containers/json"), handler)
+
+	current := version.APIVersion[version.Compat][version.CurrentAPI]
+	tests := []struct {
+		path    string
+		code    int
+		version semver.Version
+	}{
+		{"/containers/json", http.StatusOK, current},
+		{"/v1.40/containers/json", http.StatusOK, semver.MustParse("1.40.0")},
+		{"/v1.30/containers/json", http.StatusOK, semver.MustParse("1.30.0")},
+		{"/v1.99/containers/json", http.StatusBadRequest, semver.Version{}},
+		{"/v1.12/containers/json", http.StatusBadRequest, semver.Version{}},
+		// libpod clients newer than the server negotiate themselves
+		{"/v99.0.0/libpod/containers/json", http.StatusOK, semver.MustParse("99.0.0")},
+	}
+	for _, tt := range tests {
+		got = semver.Version{}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest("GET", tt.path, nil))
+		if rr.Code != tt.code {
+			t.Errorf("%s returned %d, expected %d", tt.path, rr.Code, tt.code)
+		}
+		if !got.EQ(tt.version) {
+			t.Errorf("%s negotiated version %s, expected %s", tt.path, got, tt.version)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// maxFailureBody is the size of the largest request body recorded, larger
+// requests are not recorded.
+const maxFailureBody = 1 << 20
+
+// failureLog keeps the last failed mutating requests, so they can be replayed
+// once the cause is fixed.
+type failureLog struct {
+	lock     sync.Mutex
+	max      int
+	next     int
+	failures []entities.SystemFailureReport
+}
+
+// RecordFailures enables recording the last max failed create, start and stop
+// requests of containers, 0 disables it.
+func (s *APIServer) RecordFailures(max int) {
+	s.failures.lock.Lock()
+	defer s.failures.lock.Unlock()
+	s.failures.max = max
+	s.failures.trim()
+}
+
+// trim drops the oldest failures beyond the maximum, the caller must hold the
+// lock.
+func (l *failureLog) trim() {
+	if drop := len(l.failures) - l.max; drop > 0 {
+		l.failures = append([]entities.SystemFailureReport{}, l.failures[drop:]...)
+	}
+}
+
+func (l *failureLog) add(failure entities.SystemFailureReport) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.max <= 0 {
+		return
+	}
+	l.next++
+	failure.Id = strconv.Itoa(l.next)
+	l.failures = append(l.failures, failure)
+	l.trim()
+}
+
+func (l *failureLog) list() []entities.SystemFailureReport {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return append([]entities.SystemFailureReport{}, l.failures...)
+}
+
+func (l *failureLog) get(id string) (entities.SystemFailureReport, bool) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	for _, failure := range l.failures {
+		if failure.Id == id {
+			return failure, true
+		}
+	}
+	return entities.SystemFailureReport{}, false
+}
+
+// update replaces the failure of the same ID, unless it was removed since.
+func (l *failureLog) update(failure entities.SystemFailureReport) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	for i := range l.failures {
+		if l.failures[i].Id == failure.Id {
+			l.failures[i] = failure
+			return
+		}
+	}
+}
+
+func (l *failureLog) remove(id string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	for i := range l.failures {
+		if l.failures[i].Id == id {
+			l.failures = append(l.failures[:i], l.failures[i+1:]...)
+			return
+		}
+	}
+}
+
+// failureWriter keeps the status of the response, and its body if the
+// request failed.
+type failureWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (fw *failureWriter) WriteHeader(status int) {
+	if fw.status == 0 {
+		fw.status = status
+	}
+	fw.ResponseWriter.WriteHeader(status)
+}
+
+func (fw *failureWriter) Write(b []byte) (int, error) {
+	if fw.status == 0 {
+		fw.status = http.StatusOK
+	}
+	if fw.failed() && fw.body.Len() < maxFailureBody {
+		fw.body.Write(b)
+	}
+	return fw.ResponseWriter.Write(b)
+}
+
+func (fw *failureWriter) failed() bool {
+	return fw.status >= http.StatusInternalServerError
+}
+
+// cause returns the error message of the response.
+func (fw *failureWriter) cause() string {
+	var e struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(fw.body.Bytes(), &e); err == nil && e.Message != "" {
+		return e.Message
+	}
+	return string(bytes.TrimSpace(fw.body.Bytes()))
+}
+
+// recordFailures records the requests of the mutating handler h which fail
+// with a server error.  Replayed requests are not recorded again, the replay
+// updates their failure.
+func (s *APIServer) recordFailures(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.failures.lock.Lock()
+		enabled := s.failures.max > 0
+		s.failures.lock.Unlock()
+		if !enabled || r.Context().Value("replay") != nil {
+			h(w, r)
+			return
+		}
+
+		body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxFailureBody+1))
+		if err != nil {
+			utils.InternalServerError(w, errors.Wrap(err, "failed to read request body"))
+			return
+		}
+		r.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+
+		fw := &failureWriter{ResponseWriter: w}
+		h(fw, r)
+		if !fw.failed() || len(body) > maxFailureBody {
+			return
+		}
+		s.failures.add(entities.SystemFailureReport{
+			Time:   time.Now(),
+			Method: r.Method,
+			URL:    r.URL.RequestURI(),
+			Body:   string(body),
+			Status: fw.status,
+			Error:  fw.cause(),
+		})
+	}
+}
+
+// listFailures reports the recorded failures, oldest first.
+func (s *APIServer) listFailures(w http.ResponseWriter, r *http.Request) {
+	utils.WriteResponse(w, http.StatusOK, s.failures.list())
+}
+
+// replayFailure sends a recorded request again, and answers its response.
+// The failure is forgotten once the request succeeds.
+func (s *APIServer) replayFailure(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	failure, ok := s.failures.get(id)
+	if !ok {
+		utils.Error(w, "Something went wrong.", http.StatusNotFound, errors.Errorf("no failure with ID %s", id))
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "replay", failure.Id) // nolint
+	req, err := http.NewRequestWithContext(ctx, failure.Method, failure.URL, bytes.NewReader([]byte(failure.Body)))
+	if err != nil {
+		utils.InternalServerError(w, errors.Wrapf(err, "failed to replay failure %s", id))
+		return
+	}
+	req.RemoteAddr = r.RemoteAddr
+	req.Host = r.Host
+	logrus.Infof("Replaying failure %s: %s %s", id, failure.Method, failure.URL)
+	fw := &failureWriter{ResponseWriter: w}
+	s.Server.Handler.ServeHTTP(fw, req)
+	if fw.status >= http.StatusBadRequest {
+		failure.Time = time.Now()
+		failure.Status = fw.status
+		failure.Replays++
+		if fw.failed() {
+			failure.Error = fw.cause()
+		}
+		s.failures.update(failure)
+		return
+	}
+	s.failures.remove(id)
+}
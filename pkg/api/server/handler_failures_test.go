@@ -0,0 +1,93 @@
+package server
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/containers/podman/v3/pkg/api/handlers/utils"
+	"github.com/containers/podman/v3/pkg/domain/entities"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+func TestRecordFailures(t *testing.T) {
+	s := &APIServer{}
+	router := mux.NewRouter()
+	s.Server.Handler = router
+	broken := true
+	var bodies []string
+	router.HandleFunc("/start", s.APIHandler(s.recordFailures(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if broken {
+			utils.InternalServerError(w, errors.New("start failed"))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))).Methods(http.MethodPost)
+	router.HandleFunc("/failures", s.APIHandler(s.listFailures)).Methods(http.MethodGet)
+	router.HandleFunc("/failures/{id}/replay", s.APIHandler(s.replayFailure)).Methods(http.MethodPost)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return rr
+	}
+	list := func() []entities.SystemFailureReport {
+		var failures []entities.SystemFailureReport
+		if err := json.Unmarshal(do(http.MethodGet, "/failures", "").Body.Bytes(), &failures); err != nil {
+			t.Fatal(err)
+		}
+		return failures
+	}
+
+	// Recording is opt-in
+	do(http.MethodPost, "/start?n=0", "{}")
+	if failures := list(); len(failures) != 0 {
+		t.Fatalf("recorded %d failures while disabled", len(failures))
+	}
+
+	s.RecordFailures(2)
+	for _, q := range []string{"1", "2", "3"} {
+		if rr := do(http.MethodPost, "/start?n="+q, `{"n":`+q+`}`); rr.Code != http.StatusInternalServerError {
+			t.Fatalf("failing request returned %d", rr.Code)
+		}
+	}
+	failures := list()
+	if len(failures) != 2 {
+		t.Fatalf("recorded %d failures, expected the last 2", len(failures))
+	}
+	first := failures[0]
+	if first.Method != http.MethodPost || first.URL != "/start?n=2" || first.Body != `{"n":2}` ||
+		first.Status != http.StatusInternalServerError || first.Error != "start failed" {
+		t.Errorf("unexpected failure %+v", first)
+	}
+
+	if rr := do(http.MethodPost, "/failures/nonesuch/replay", ""); rr.Code != http.StatusNotFound {
+		t.Errorf("replaying an unknown failure returned %d", rr.Code)
+	}
+	// A failing replay updates the failure instead of recording another
+	if rr := do(http.MethodPost, "/failures/"+first.Id+"/replay", ""); rr.Code != http.StatusInternalServerError {
+		t.Errorf("failing replay returned %d", rr.Code)
+	}
+	failures = list()
+	if len(failures) != 2 || failures[0].Id != first.Id || failures[0].Replays != 1 {
+		t.Errorf("unexpected failures after a failing replay %+v", failures)
+	}
+
+	broken = false
+	if rr := do(http.MethodPost, "/failures/"+first.Id+"/replay", ""); rr.Code != http.StatusNoContent {
+		t.Errorf("replay returned %d", rr.Code)
+	}
+	if last := bodies[len(bodies)-1]; last != `{"n":2}` {
+		t.Errorf("replay sent body %q", last)
+	}
+	failures = list()
+	if len(failures) != 1 || failures[0].URL != "/start?n=3" {
+		t.Errorf("unexpected failures after a replay %+v", failures)
+	}
+}
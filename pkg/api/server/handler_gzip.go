@@ -0,0 +1,138 @@
+package server
+
+import (
+	"bufio"
+	"compress/gzip"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// compressedTypes are the content types of payloads compressed already,
+// these are sent as they are.
+var compressedTypes = []string{
+	"application/gzip",
+	"application/x-gzip",
+	"application/x-bzip2",
+	"application/x-xz",
+	"application/zstd",
+}
+
+// gzipHandler compresses the responses to clients accepting gzip.  Requests
+// upgrading the connection are left alone, the handlers hijack it.
+func gzipHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead || r.Header.Get("Upgrade") != "" || !acceptsGzip(r) {
+			h.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Add("Vary", "Accept-Encoding")
+		gw := &gzipWriter{ResponseWriter: w}
+		defer gw.close()
+		h.ServeHTTP(gw, r)
+	})
+}
+
+// acceptsGzip returns true if the Accept-Encoding header of the request
+// accepts gzip.
+func acceptsGzip(r *http.Request) bool {
+	for _, header := range r.Header["Accept-Encoding"] {
+		for _, encoding := range strings.Split(header, ",") {
+			params := strings.Split(encoding, ";")
+			if strings.ToLower(strings.TrimSpace(params[0])) != "gzip" {
+				continue
+			}
+			for _, param := range params[1:] {
+				if v := strings.TrimSpace(param); strings.HasPrefix(v, "q=") {
+					if q, err := strconv.ParseFloat(strings.TrimPrefix(v, "q="), 64); err != nil || q <= 0 {
+						return false
+					}
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// gzipWriter compresses the response unless it is encoded already, has no
+// body, or the connection is hijacked.  Whether to compress is decided when
+// the handler starts writing the response.
+type gzipWriter struct {
+	http.ResponseWriter
+	gz       *gzip.Writer
+	decided  bool
+	hijacked bool
+}
+
+func (gw *gzipWriter) decide(status int) {
+	gw.decided = true
+	header := gw.Header()
+	if status == http.StatusNoContent || status == http.StatusNotModified || header.Get("Content-Encoding") != "" {
+		return
+	}
+	contentType := strings.ToLower(header.Get("Content-Type"))
+	for _, t := range compressedTypes {
+		if strings.HasPrefix(contentType, t) {
+			return
+		}
+	}
+	header.Set("Content-Encoding", "gzip")
+	header.Del("Content-Length")
+	gw.gz = gzip.NewWriter(gw.ResponseWriter)
+}
+
+func (gw *gzipWriter) WriteHeader(status int) {
+	// Informational responses precede the response.
+	if !gw.decided && status >= http.StatusOK {
+		gw.decide(status)
+	}
+	gw.ResponseWriter.WriteHeader(status)
+}
+
+func (gw *gzipWriter) Write(b []byte) (int, error) {
+	if !gw.decided {
+		// http.Server would sniff the type of the compressed body.
+		if gw.Header().Get("Content-Type") == "" {
+			gw.Header().Set("Content-Type", http.DetectContentType(b))
+		}
+		gw.WriteHeader(http.StatusOK)
+	}
+	if gw.gz != nil {
+		return gw.gz.Write(b)
+	}
+	return gw.ResponseWriter.Write(b)
+}
+
+// Flush sends what was compressed so far, for streaming handlers.
+func (gw *gzipWriter) Flush() {
+	if gw.gz != nil {
+		if err := gw.gz.Flush(); err != nil {
+			return
+		}
+	}
+	if flusher, ok := gw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (gw *gzipWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := gw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	conn, buf, err := hijacker.Hijack()
+	if err == nil {
+		gw.hijacked = true
+	}
+	return conn, buf, err
+}
+
+func (gw *gzipWriter) close() {
+	if gw.gz != nil && !gw.hijacked {
+		gw.gz.Close()
+	}
+}
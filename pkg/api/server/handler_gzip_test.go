@@ -0,0 +1,56 @@
+package server
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGzipHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		contentType    string
+		gzipped        bool
+	}{
+		{"gzip accepted", "deflate, gzip", "application/json", true},
+		{"gzip refused", "gzip;q=0", "application/json", false},
+		{"gzip not accepted", "", "application/json", false},
+		{"compressed already", "gzip", "application/x-gzip", false},
+	}
+	for _, tt := range tests {
+		handler := gzipHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", tt.contentType)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("first")) // nolint
+			w.(http.Flusher).Flush()
+			w.Write([]byte(" second")) // nolint
+		}))
+		req := httptest.NewRequest("GET", "/version", nil)
+		if tt.acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		if got := rr.Header().Get("Content-Encoding") == "gzip"; got != tt.gzipped {
+			t.Errorf("%s: gzip encoded %t, expected %t", tt.name, got, tt.gzipped)
+			continue
+		}
+		body := rr.Body.Bytes()
+		if tt.gzipped {
+			gz, err := gzip.NewReader(rr.Body)
+			if err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+			if body, err = ioutil.ReadAll(gz); err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+		}
+		if string(body) != "first second" {
+			t.Errorf("%s: body %q", tt.name, body)
+		}
+	}
+}
@@ -30,11 +30,11 @@ func (s *APIServer) registerArchiveHandlers(r *mux.Router) error {
 	//   - in: query
 	//     name: noOverwriteDirNonDir
 	//     type: string
-	//     description: if unpacking the given content would cause an existing directory to be replaced with a non-directory and vice versa (1 or true)
+	//     description: fail if unpacking the given content would cause an existing directory to be replaced with a non-directory (1 or true)
 	//   - in: query
 	//     name: copyUIDGID
 	//     type: string
-	//     description: copy UID/GID maps to the dest file or di (1 or true)
+	//     description: change the ownership of the copied files to the user of the container, instead of keeping the ownership in the archive (1 or true)
 	//   - in: body
 	//     name: request
 	//     description: tarfile of files to copy into the container
@@ -83,9 +83,9 @@ func (s *APIServer) registerArchiveHandlers(r *mux.Router) error {
 	//      $ref: "#/responses/NoSuchContainer"
 	//    500:
 	//      $ref: "#/responses/InternalError"
-	r.HandleFunc(VersionedPath("/containers/{name}/archive"), s.APIHandler(compat.Archive)).Methods(http.MethodGet, http.MethodPut, http.MethodHead)
+	r.HandleFunc(VersionedPath("/containers/{name}/archive"), s.StreamingAPIHandler(compat.Archive)).Methods(http.MethodGet, http.MethodPut, http.MethodHead)
 	// Added non version path to URI to support docker non versioned paths
-	r.HandleFunc("/containers/{name}/archive", s.APIHandler(compat.Archive)).Methods(http.MethodGet, http.MethodPut, http.MethodHead)
+	r.HandleFunc("/containers/{name}/archive", s.StreamingAPIHandler(compat.Archive)).Methods(http.MethodGet, http.MethodPut, http.MethodHead)
 
 	/*
 		Libpod
@@ -115,6 +115,15 @@ func (s *APIServer) registerArchiveHandlers(r *mux.Router) error {
 	//     type: boolean
 	//     description: pause the container while copying (defaults to true)
 	//     default: true
+	//   - in: query
+	//     name: noOverwriteDirNonDir
+	//     type: boolean
+	//     description: fail if unpacking the given content would cause an existing directory to be replaced with a non-directory
+	//   - in: query
+	//     name: copyUIDGID
+	//     type: boolean
+	//     description: change the ownership of the copied files to the user of the container, instead of keeping the ownership in the archive
+	//     default: true
 	//   - in: body
 	//     name: request
 	//     description: tarfile of files to copy into the container
@@ -163,7 +172,7 @@ func (s *APIServer) registerArchiveHandlers(r *mux.Router) error {
 	//      $ref: "#/responses/NoSuchContainer"
 	//    500:
 	//      $ref: "#/responses/InternalError"
-	r.HandleFunc(VersionedPath("/libpod/containers/{name}/archive"), s.APIHandler(compat.Archive)).Methods(http.MethodGet, http.MethodPut, http.MethodHead)
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/archive"), s.StreamingAPIHandler(compat.Archive)).Methods(http.MethodGet, http.MethodPut, http.MethodHead)
 
 	return nil
 }
@@ -21,6 +21,13 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//      name: name
 	//      type: string
 	//      description: container name
+	//    - in: query
+	//      name: port-reservation
+	//      type: string
+	//      description: |
+	//        token of a port reservation (see /libpod/system/ports/reserve) to use; the reserved
+	//        ports are assigned to port bindings without a host port, and stay reserved for the
+	//        container until it is started or removed
 	//   responses:
 	//     201:
 	//       $ref: "#/responses/ContainerCreateResponse"
@@ -32,9 +39,9 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//       $ref: "#/responses/ConflictError"
 	//     500:
 	//       $ref: "#/responses/InternalError"
-	r.HandleFunc(VersionedPath("/containers/create"), s.APIHandler(compat.CreateContainer)).Methods(http.MethodPost)
+	r.HandleFunc(VersionedPath("/containers/create"), s.APIHandler(s.recordFailures(compat.CreateContainer))).Methods(http.MethodPost)
 	// Added non version path to URI to support docker non versioned paths
-	r.HandleFunc("/containers/create", s.APIHandler(compat.CreateContainer)).Methods(http.MethodPost)
+	r.HandleFunc("/containers/create", s.APIHandler(s.recordFailures(compat.CreateContainer))).Methods(http.MethodPost)
 	// swagger:operation GET /containers/json compat listContainers
 	// ---
 	// tags:
@@ -42,6 +49,11 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	// summary: List containers
 	// description: Returns a list of containers
 	// parameters:
+	//  - in: header
+	//    name: Accept
+	//    type: string
+	//    enum: ["application/vnd.docker.v1+json", "application/vnd.libpod.v1+json"]
+	//    description: return the Docker compatible or the libpod shape of the response, defaults to the shape of the endpoint
 	//  - in: query
 	//    name: all
 	//    type: boolean
@@ -164,6 +176,11 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	// summary: Inspect container
 	// description: Return low-level information about a container.
 	// parameters:
+	//  - in: header
+	//    name: Accept
+	//    type: string
+	//    enum: ["application/vnd.docker.v1+json", "application/vnd.libpod.v1+json"]
+	//    description: return the Docker compatible or the libpod shape of the response, defaults to the shape of the endpoint
 	//  - in: path
 	//    name: name
 	//    type: string
@@ -214,6 +231,8 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	// responses:
 	//   204:
 	//     description: no error
+	//   400:
+	//     $ref: "#/responses/BadParamError"
 	//   404:
 	//     $ref: "#/responses/NoSuchContainer"
 	//   409:
@@ -274,9 +293,9 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//      $ref: "#/responses/NoSuchContainer"
 	//   500:
 	//      $ref: "#/responses/InternalError"
-	r.HandleFunc(VersionedPath("/containers/{name}/logs"), s.APIHandler(compat.LogsFromContainer)).Methods(http.MethodGet)
+	r.HandleFunc(VersionedPath("/containers/{name}/logs"), s.StreamingAPIHandler(compat.LogsFromContainer)).Methods(http.MethodGet)
 	// Added non version path to URI to support docker non versioned paths
-	r.HandleFunc("/containers/{name}/logs", s.APIHandler(compat.LogsFromContainer)).Methods(http.MethodGet)
+	r.HandleFunc("/containers/{name}/logs", s.StreamingAPIHandler(compat.LogsFromContainer)).Methods(http.MethodGet)
 	// swagger:operation POST /containers/{name}/pause compat pauseContainer
 	// ---
 	// tags:
@@ -351,13 +370,17 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//     description: no error
 	//   304:
 	//     $ref: "#/responses/ContainerAlreadyStartedError"
+	//   400:
+	//     $ref: "#/responses/BadParamError"
 	//   404:
 	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
 	//   500:
 	//     $ref: "#/responses/InternalError"
-	r.HandleFunc(VersionedPath("/containers/{name}/start"), s.APIHandler(compat.StartContainer)).Methods(http.MethodPost)
+	r.HandleFunc(VersionedPath("/containers/{name}/start"), s.APIHandler(s.recordFailures(compat.StartContainer))).Methods(http.MethodPost)
 	// Added non version path to URI to support docker non versioned paths
-	r.HandleFunc("/containers/{name}/start", s.APIHandler(compat.StartContainer)).Methods(http.MethodPost)
+	r.HandleFunc("/containers/{name}/start", s.APIHandler(s.recordFailures(compat.StartContainer))).Methods(http.MethodPost)
 	// swagger:operation GET /containers/{name}/stats compat statsContainer
 	// ---
 	// tags:
@@ -384,9 +407,9 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//     $ref: "#/responses/NoSuchContainer"
 	//   500:
 	//     $ref: "#/responses/InternalError"
-	r.HandleFunc(VersionedPath("/containers/{name}/stats"), s.APIHandler(compat.StatsContainer)).Methods(http.MethodGet)
+	r.HandleFunc(VersionedPath("/containers/{name}/stats"), s.StreamingAPIHandler(compat.StatsContainer)).Methods(http.MethodGet)
 	// Added non version path to URI to support docker non versioned paths
-	r.HandleFunc("/containers/{name}/stats", s.APIHandler(compat.StatsContainer)).Methods(http.MethodGet)
+	r.HandleFunc("/containers/{name}/stats", s.StreamingAPIHandler(compat.StatsContainer)).Methods(http.MethodGet)
 	// swagger:operation POST /containers/{name}/stop compat stopContainer
 	// ---
 	// tags:
@@ -414,9 +437,9 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//     $ref: "#/responses/NoSuchContainer"
 	//   500:
 	//     $ref: "#/responses/InternalError"
-	r.HandleFunc(VersionedPath("/containers/{name}/stop"), s.APIHandler(compat.StopContainer)).Methods(http.MethodPost)
+	r.HandleFunc(VersionedPath("/containers/{name}/stop"), s.APIHandler(s.recordFailures(compat.StopContainer))).Methods(http.MethodPost)
 	// Added non version path to URI to support docker non versioned paths
-	r.HandleFunc("/containers/{name}/stop", s.APIHandler(compat.StopContainer)).Methods(http.MethodPost)
+	r.HandleFunc("/containers/{name}/stop", s.APIHandler(s.recordFailures(compat.StopContainer))).Methods(http.MethodPost)
 	// swagger:operation GET /containers/{name}/top compat topContainer
 	// ---
 	// tags:
@@ -439,6 +462,8 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//     $ref: "#/responses/DocsContainerTopResponse"
 	//   404:
 	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
 	//   500:
 	//     $ref: "#/responses/InternalError"
 	r.HandleFunc(VersionedPath("/containers/{name}/top"), s.APIHandler(compat.TopContainer)).Methods(http.MethodGet)
@@ -468,6 +493,39 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	r.HandleFunc(VersionedPath("/containers/{name}/unpause"), s.APIHandler(compat.UnpauseContainer)).Methods(http.MethodPost)
 	// Added non version path to URI to support docker non versioned paths
 	r.HandleFunc("/containers/{name}/unpause", s.APIHandler(compat.UnpauseContainer)).Methods(http.MethodPost)
+	// swagger:operation POST /containers/{name}/update compat updateContainer
+	// ---
+	// tags:
+	//   - containers (compat)
+	// summary: Update container
+	// description: |
+	//   Change the resource limits and the restart policy of a container. Limits not set are kept. The limits of a
+	//   running container are changed in place.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: body
+	//    name: update
+	//    description: resource limits (Memory, MemorySwap, MemoryReservation, CpuShares, CpuPeriod, CpuQuota, NanoCpus, CpusetCpus, CpusetMems, BlkioWeight) and RestartPolicy
+	//    schema:
+	//      type: object
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/ContainerUpdateResponse"
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/containers/{name}/update"), s.APIHandler(compat.UpdateContainer)).Methods(http.MethodPost)
+	// Added non version path to URI to support docker non versioned paths
+	r.HandleFunc("/containers/{name}/update", s.APIHandler(compat.UpdateContainer)).Methods(http.MethodPost)
 	// swagger:operation POST /containers/{name}/wait compat waitContainer
 	// ---
 	// tags:
@@ -505,9 +563,9 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//     $ref: "#/responses/NoSuchContainer"
 	//   500:
 	//     $ref: "#/responses/InternalError"
-	r.HandleFunc(VersionedPath("/containers/{name}/wait"), s.APIHandler(compat.WaitContainer)).Methods(http.MethodPost)
+	r.HandleFunc(VersionedPath("/containers/{name}/wait"), s.StreamingAPIHandler(compat.WaitContainer)).Methods(http.MethodPost)
 	// Added non version path to URI to support docker non versioned paths
-	r.HandleFunc("/containers/{name}/wait", s.APIHandler(compat.WaitContainer)).Methods(http.MethodPost)
+	r.HandleFunc("/containers/{name}/wait", s.StreamingAPIHandler(compat.WaitContainer)).Methods(http.MethodPost)
 	// swagger:operation POST /containers/{name}/attach compat attachContainer
 	// ---
 	// tags:
@@ -560,11 +618,60 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//     $ref: "#/responses/BadParamError"
 	//   404:
 	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     description: the container is paused or stopping
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/containers/{name}/attach"), s.StreamingAPIHandler(compat.AttachContainer)).Methods(http.MethodPost)
+	// Added non version path to URI to support docker non versioned paths
+	r.HandleFunc("/containers/{name}/attach", s.StreamingAPIHandler(compat.AttachContainer)).Methods(http.MethodPost)
+	// swagger:operation GET /containers/{name}/attach/ws compat attachContainerWebsocket
+	// ---
+	// tags:
+	//   - containers (compat)
+	// summary: Attach to a container over a websocket
+	// description: |
+	//  Upgrades the connection to a WebSocket forwarding the container's standard streams.  The messages of the client are written to the container's STDIN, its STDOUT and STDERR are sent in binary messages.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: query
+	//    name: detachKeys
+	//    required: false
+	//    type: string
+	//    description: keys to use for detaching from the container
+	//  - in: query
+	//    name: stdout
+	//    required: false
+	//    type: boolean
+	//    description: Attach to container STDOUT
+	//  - in: query
+	//    name: stderr
+	//    required: false
+	//    type: boolean
+	//    description: Attach to container STDERR
+	//  - in: query
+	//    name: stdin
+	//    required: false
+	//    type: boolean
+	//    description: Attach to container STDIN
+	// responses:
+	//   101:
+	//     description: No error, the connection was upgraded to a websocket.
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     description: the container is paused or stopping
 	//   500:
 	//     $ref: "#/responses/InternalError"
-	r.HandleFunc(VersionedPath("/containers/{name}/attach"), s.APIHandler(compat.AttachContainer)).Methods(http.MethodPost)
+	r.HandleFunc(VersionedPath("/containers/{name}/attach/ws"), s.StreamingAPIHandler(compat.AttachContainerWebsocket)).Methods(http.MethodGet)
 	// Added non version path to URI to support docker non versioned paths
-	r.HandleFunc("/containers/{name}/attach", s.APIHandler(compat.AttachContainer)).Methods(http.MethodPost)
+	r.HandleFunc("/containers/{name}/attach/ws", s.StreamingAPIHandler(compat.AttachContainerWebsocket)).Methods(http.MethodGet)
 	// swagger:operation POST /containers/{name}/resize compat resizeContainer
 	// ---
 	// tags:
@@ -594,6 +701,8 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//     $ref: "#/responses/ok"
 	//   404:
 	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
 	//   500:
 	//     $ref: "#/responses/InternalError"
 	r.HandleFunc(VersionedPath("/containers/{name}/resize"), s.APIHandler(compat.ResizeTTY)).Methods(http.MethodPost)
@@ -620,8 +729,8 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//     $ref: "#/responses/NoSuchContainer"
 	//   500:
 	//     $ref: "#/responses/InternalError"
-	r.HandleFunc(VersionedPath("/containers/{name}/export"), s.APIHandler(compat.ExportContainer)).Methods(http.MethodGet)
-	r.HandleFunc("/containers/{name}/export", s.APIHandler(compat.ExportContainer)).Methods(http.MethodGet)
+	r.HandleFunc(VersionedPath("/containers/{name}/export"), s.StreamingAPIHandler(compat.ExportContainer)).Methods(http.MethodGet)
+	r.HandleFunc("/containers/{name}/export", s.StreamingAPIHandler(compat.ExportContainer)).Methods(http.MethodGet)
 	// swagger:operation POST /containers/{name}/rename compat renameContainer
 	// ---
 	// tags:
@@ -670,6 +779,13 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//      description: attributes for creating a container
 	//      schema:
 	//        $ref: "#/definitions/SpecGenerator"
+	//    - in: query
+	//      name: port-reservation
+	//      type: string
+	//      description: |
+	//        token of a port reservation (see /libpod/system/ports/reserve) to use; the reserved
+	//        ports are assigned to port mappings without a host port, and stay reserved for the
+	//        container until it is started or removed
 	//   responses:
 	//     201:
 	//       $ref: "#/responses/ContainerCreateResponse"
@@ -678,10 +794,119 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//     404:
 	//       $ref: "#/responses/NoSuchContainer"
 	//     409:
-	//       $ref: "#/responses/ConflictError"
+	//       description: a host port of the container is reserved by another port reservation or for another container
+	//       schema:
+	//         $ref: "#/definitions/ErrorModel"
+	//     500:
+	//       $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/create"), s.APIHandler(s.recordFailures(libpod.CreateContainer))).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/containers/create-batch libpod libpodCreateContainerBatch
+	// ---
+	//   summary: Create several containers
+	//   description: |
+	//     Create count containers from a single spec, naming them from a template.
+	//     If creating any of the containers fails, the containers created so far are removed.
+	//   tags:
+	//    - containers
+	//   produces:
+	//   - application/json
+	//   parameters:
+	//    - in: query
+	//      name: count
+	//      type: integer
+	//      required: true
+	//      description: number of containers to create, at most 1024
+	//    - in: query
+	//      name: name-template
+	//      type: string
+	//      required: true
+	//      description: |
+	//        Go template for the container names, executed with .Index set to the
+	//        1-based index of the container, e.g. worker-{{.Index}}
+	//    - in: body
+	//      name: create
+	//      description: attributes for creating the containers
+	//      schema:
+	//        $ref: "#/definitions/SpecGenerator"
+	//   responses:
+	//     201:
+	//       $ref: "#/responses/ContainerCreateBatchResponse"
+	//     400:
+	//       $ref: "#/responses/BadParamError"
+	//     500:
+	//       $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/create-batch"), s.APIHandler(libpod.CreateContainerBatch)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/spec/validate/stream libpod libpodValidateSpecStream
+	// ---
+	//   summary: Validate a spec as it is edited
+	//   description: |
+	//     Upgrades the connection to a WebSocket. Every message sent by the client is a complete
+	//     SpecGenerator, which is validated the way creating a container from it would, e.g. for
+	//     images not found locally or host ports already bound. Once no newer spec arrived for the
+	//     debounce interval, the issues of the latest spec are sent back as a message.
+	//     GET may be used as well, as WebSocket clients do.
+	//   tags:
+	//    - containers
+	//   produces:
+	//   - application/json
+	//   parameters:
+	//    - in: query
+	//      name: debounce
+	//      type: string
+	//      default: 250ms
+	//      description: time to wait for newer specs before validating, at most 10s
+	//   responses:
+	//     101:
+	//       $ref: "#/responses/SpecValidation"
+	//     400:
+	//       $ref: "#/responses/BadParamError"
+	//     500:
+	//       $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/spec/validate/stream"), s.StreamingAPIHandler(libpod.ValidateSpecStream)).Methods(http.MethodGet, http.MethodPost)
+	// swagger:operation POST /libpod/containers/label-batch libpod libpodLabelContainers
+	// ---
+	//   summary: Change labels of several containers
+	//   description: |
+	//     Add and remove labels of all containers matching the given filters.
+	//     Labels to remove are removed before the labels to add are set.
+	//     Running containers are skipped with an error entry, unless only labels
+	//     podman does not act upon are changed.
+	//   tags:
+	//    - containers
+	//   produces:
+	//   - application/json
+	//   parameters:
+	//    - in: body
+	//      name: labels
+	//      description: containers to change and the label changes
+	//      schema:
+	//        type: object
+	//        properties:
+	//          filters:
+	//            type: object
+	//            description: filters selecting the containers, as for listing containers
+	//            additionalProperties:
+	//              type: array
+	//              items:
+	//                type: string
+	//          add:
+	//            type: object
+	//            description: labels to set
+	//            additionalProperties:
+	//              type: string
+	//          remove:
+	//            type: array
+	//            description: keys of the labels to remove
+	//            items:
+	//              type: string
+	//   responses:
+	//     200:
+	//       $ref: "#/responses/ContainersLabelBatch"
+	//     400:
+	//       $ref: "#/responses/BadParamError"
 	//     500:
 	//       $ref: "#/responses/InternalError"
-	r.HandleFunc(VersionedPath("/libpod/containers/create"), s.APIHandler(libpod.CreateContainer)).Methods(http.MethodPost)
+	r.HandleFunc(VersionedPath("/libpod/containers/label-batch"), s.APIHandler(libpod.LabelContainers)).Methods(http.MethodPost)
 	// swagger:operation GET /libpod/containers/json libpod libpodListContainers
 	// ---
 	// tags:
@@ -689,6 +914,11 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	// summary: List containers
 	// description: Returns a list of containers
 	// parameters:
+	//  - in: header
+	//    name: Accept
+	//    type: string
+	//    enum: ["application/vnd.docker.v1+json", "application/vnd.libpod.v1+json"]
+	//    description: return the Docker compatible or the libpod shape of the response, defaults to the shape of the endpoint
 	//  - in: query
 	//    name: all
 	//    type: boolean
@@ -828,6 +1058,11 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	// summary: Inspect container
 	// description: Return low-level information about a container.
 	// parameters:
+	//  - in: header
+	//    name: Accept
+	//    type: string
+	//    enum: ["application/vnd.docker.v1+json", "application/vnd.libpod.v1+json"]
+	//    description: return the Docker compatible or the libpod shape of the response, defaults to the shape of the endpoint
 	//  - in: path
 	//    name: name
 	//    type: string
@@ -869,6 +1104,8 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	// responses:
 	//   204:
 	//     description: no error
+	//   400:
+	//     $ref: "#/responses/BadParamError"
 	//   404:
 	//     $ref: "#/responses/NoSuchContainer"
 	//   409:
@@ -975,29 +1212,81 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//      $ref: "#/responses/NoSuchContainer"
 	//   500:
 	//      $ref: "#/responses/InternalError"
-	r.HandleFunc(VersionedPath("/libpod/containers/{name}/logs"), s.APIHandler(compat.LogsFromContainer)).Methods(http.MethodGet)
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/logs"), s.StreamingAPIHandler(compat.LogsFromContainer)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/containers/{name}/logs/sse libpod libpodLogsFromContainerSSE
+	// ---
+	// tags:
+	//   - containers
+	// summary: Get container logs as Server-Sent Events
+	// description: |
+	//   Stream the logs of a container as Server-Sent Events, for consumption with an EventSource.
+	//   Each log line is one event, its type is the stream of the line: stdout or stderr.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: query
+	//    name: follow
+	//    type: boolean
+	//    description: Keep connection after returning logs.
+	//  - in: query
+	//    name: since
+	//    type: string
+	//    description: Only return logs since this time, as a UNIX timestamp
+	//  - in: query
+	//    name: tail
+	//    type: string
+	//    description: Only return this number of log lines from the end of the logs
+	//    default: all
+	// produces:
+	// - text/event-stream
+	// responses:
+	//   200:
+	//     description: logs returned as a stream of events in response body.
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/logs/sse"), s.StreamingAPIHandler(libpod.LogsFromContainerSSE)).Methods(http.MethodGet)
+
 	// swagger:operation POST /libpod/containers/{name}/pause libpod libpodPauseContainer
 	// ---
 	// tags:
 	//  - containers
 	// summary: Pause a container
-	// description: Use the cgroups freezer to suspend all processes in a container.
+	// description: |
+	//   Use the cgroups freezer to suspend all processes in a container.
+	//   With a duration, the container is unpaused automatically once it passed, unless it is unpaused before.
 	// parameters:
 	//  - in: path
 	//    name: name
 	//    type: string
 	//    required: true
 	//    description: the name or ID of the container
+	//  - in: query
+	//    name: duration
+	//    type: string
+	//    description: unpause the container after this duration, e.g. 30s
 	// produces:
 	// - application/json
 	// responses:
+	//   200:
+	//     $ref: "#/responses/ContainerPause"
 	//   204:
 	//     description: no error
+	//   400:
+	//     $ref: "#/responses/BadParamError"
 	//   404:
 	//     "$ref": "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
 	//   500:
 	//     "$ref": "#/responses/InternalError"
-	r.HandleFunc(VersionedPath("/libpod/containers/{name}/pause"), s.APIHandler(compat.PauseContainer)).Methods(http.MethodPost)
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/pause"), s.APIHandler(libpod.PauseContainer)).Methods(http.MethodPost)
 	// swagger:operation POST /libpod/containers/{name}/restart libpod libpodRestartContainer
 	// ---
 	// tags:
@@ -1023,40 +1312,38 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/InternalError"
 	r.HandleFunc(VersionedPath("/libpod/containers/{name}/restart"), s.APIHandler(compat.RestartContainer)).Methods(http.MethodPost)
-	// swagger:operation POST /libpod/containers/{name}/start libpod libpodStartContainer
+	// swagger:operation GET /libpod/containers/{name}/restart-backoff libpod libpodGetRestartBackoff
 	// ---
 	// tags:
 	//  - containers
-	// summary: Start a container
+	// summary: Get restart backoff
+	// description: Report the delay of restarts of a container by its restart policy after failures.
 	// parameters:
 	//  - in: path
 	//    name: name
 	//    type: string
 	//    required: true
 	//    description: the name or ID of the container
-	//  - in: query
-	//    name: detachKeys
-	//    type: string
-	//    description: "Override the key sequence for detaching a container. Format is a single character [a-Z] or ctrl-<value> where <value> is one of: a-z, @, ^, [, , or _."
-	//    default: ctrl-p,ctrl-q
 	// produces:
 	// - application/json
 	// responses:
-	//   204:
-	//     description: no error
-	//   304:
-	//     $ref: "#/responses/ContainerAlreadyStartedError"
+	//   200:
+	//     $ref: "#/responses/ContainerRestartBackoff"
 	//   404:
 	//     $ref: "#/responses/NoSuchContainer"
 	//   500:
 	//     $ref: "#/responses/InternalError"
-	r.HandleFunc(VersionedPath("/libpod/containers/{name}/start"), s.APIHandler(compat.StartContainer)).Methods(http.MethodPost)
-	// swagger:operation GET /libpod/containers/{name}/stats libpod libpodStatsContainer
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/restart-backoff"), s.APIHandler(libpod.GetRestartBackoff)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/containers/{name}/restart-backoff libpod libpodSetRestartBackoff
 	// ---
 	// tags:
 	//  - containers
-	// summary: Get stats for a container
-	// description: DEPRECATED. This endpoint will be removed with the next major release. Please use /libpod/containers/stats instead.
+	// summary: Set restart backoff
+	// description: |
+	//   Delay restarts of a container by its restart policy after failures exponentially, so a
+	//   crash-looping container backs off instead of restarting continuously. The first restart
+	//   is delayed by the initial delay, each further one by multiplier times the previous delay,
+	//   up to the maximum delay. The delay is reset when the container is started.
 	// parameters:
 	//  - in: path
 	//    name: name
@@ -1064,84 +1351,260 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//    required: true
 	//    description: the name or ID of the container
 	//  - in: query
-	//    name: stream
-	//    type: boolean
-	//    default: true
-	//    description: Stream the output
+	//    name: initial-delay
+	//    type: string
+	//    required: true
+	//    description: delay of the first restart, e.g. 1s. 0 disables the backoff.
+	//  - in: query
+	//    name: max-delay
+	//    type: string
+	//    default: 5m
+	//    description: maximum delay of restarts
+	//  - in: query
+	//    name: multiplier
+	//    type: number
+	//    default: 2
+	//    description: factor to multiply the delay by on each restart, at least 1
 	// produces:
 	// - application/json
 	// responses:
 	//   200:
-	//     description: no error
+	//     $ref: "#/responses/ContainerRestartBackoff"
+	//   400:
+	//     $ref: "#/responses/BadParamError"
 	//   404:
 	//     $ref: "#/responses/NoSuchContainer"
 	//   500:
 	//     $ref: "#/responses/InternalError"
-	r.HandleFunc(VersionedPath("/libpod/containers/{name}/stats"), s.APIHandler(compat.StatsContainer)).Methods(http.MethodGet)
-	// swagger:operation GET /libpod/containers/stats libpod libpodStatsContainers
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/restart-backoff"), s.APIHandler(libpod.SetRestartBackoff)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/containers/{name}/autoremove libpod libpodSetAutoRemove
 	// ---
 	// tags:
 	//  - containers
-	// summary: Get stats for one or more containers
-	// description: Return a live stream of resource usage statistics of one or more container. If no container is specified, the statistics of all containers are returned.
+	// summary: Set auto removal
+	// description: |
+	//   Enable or disable removing a container when it exits, as with --rm, after it was created.
+	//   Enabling it on an exited container removes the container right away. Disabling it on a
+	//   running container created with --rm takes effect once the container is started again.
 	// parameters:
-	//  - in: query
-	//    name: containers
-	//    description: names or IDs of containers
-	//    type: array
-	//    items:
-	//       type: string
-	//  - in: query
-	//    name: stream
-	//    type: boolean
-	//    default: true
-	//    description: Stream the output
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: body
+	//    name: autoremove
+	//    schema:
+	//      type: object
+	//      required:
+	//        - enabled
+	//      properties:
+	//        enabled:
+	//          type: boolean
+	//          description: remove the container when it exits
 	// produces:
 	// - application/json
 	// responses:
 	//   200:
-	//     description: no error
+	//     $ref: "#/responses/ContainerAutoRemove"
+	//   400:
+	//     $ref: "#/responses/BadParamError"
 	//   404:
 	//     $ref: "#/responses/NoSuchContainer"
 	//   500:
 	//     $ref: "#/responses/InternalError"
-	r.HandleFunc(VersionedPath("/libpod/containers/stats"), s.APIHandler(libpod.StatsContainer)).Methods(http.MethodGet)
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/autoremove"), s.APIHandler(libpod.SetAutoRemove)).Methods(http.MethodPost)
 
-	// swagger:operation GET /libpod/containers/{name}/top libpod libpodTopContainer
+	// swagger:operation POST /libpod/containers/{name}/start libpod libpodStartContainer
 	// ---
 	// tags:
 	//  - containers
-	// summary: List processes
-	// description: List processes running inside a container
+	// summary: Start a container
 	// parameters:
 	//  - in: path
 	//    name: name
 	//    type: string
 	//    required: true
-	//    description: |
-	//      Name of container to query for processes
-	//      (As of version 1.xx)
-	//  - in: query
-	//    name: stream
-	//    type: boolean
-	//    default: true
-	//    description: Stream the output
+	//    description: the name or ID of the container
 	//  - in: query
-	//    name: ps_args
+	//    name: detachKeys
 	//    type: string
-	//    default: -ef
-	//    description: arguments to pass to ps such as aux. Requires ps(1) to be installed in the container if no ps(1) compatible AIX descriptors are used.
+	//    description: "Override the key sequence for detaching a container. Format is a single character [a-Z] or ctrl-<value> where <value> is one of: a-z, @, ^, [, , or _."
+	//    default: ctrl-p,ctrl-q
 	// produces:
 	// - application/json
 	// responses:
-	//   200:
-	//     $ref: "#/responses/DocsContainerTopResponse"
+	//   204:
+	//     description: no error
+	//   304:
+	//     $ref: "#/responses/ContainerAlreadyStartedError"
+	//   400:
+	//     $ref: "#/responses/BadParamError"
 	//   404:
 	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
 	//   500:
 	//     $ref: "#/responses/InternalError"
-	r.HandleFunc(VersionedPath("/libpod/containers/{name}/top"), s.APIHandler(compat.TopContainer)).Methods(http.MethodGet)
-	// swagger:operation POST /libpod/containers/{name}/unpause libpod libpodUnpauseContainer
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/start"), s.APIHandler(s.recordFailures(compat.StartContainer))).Methods(http.MethodPost)
+	// swagger:operation GET /libpod/containers/{name}/start-timing libpod libpodContainerStartTiming
+	// ---
+	// tags:
+	//   - containers
+	// summary: Container start timing
+	// description: |
+	//   Return where the time of the last successful start of a container went: mounting the image, setting
+	//   up the network, preparing the root filesystem, and creating and starting the container in the OCI
+	//   runtime. Mounting the image and setting up the network run concurrently.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/ContainerStartTiming"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/start-timing"), s.APIHandler(libpod.ContainerStartTiming)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/containers/{name}/stats libpod libpodStatsContainer
+	// ---
+	// tags:
+	//  - containers
+	// summary: Get stats for a container
+	// description: DEPRECATED. This endpoint will be removed with the next major release. Please use /libpod/containers/stats instead.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: query
+	//    name: stream
+	//    type: boolean
+	//    default: true
+	//    description: Stream the output
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     description: no error
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/stats"), s.StreamingAPIHandler(compat.StatsContainer)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/containers/stats libpod libpodStatsContainers
+	// ---
+	// tags:
+	//  - containers
+	// summary: Get stats for one or more containers
+	// description: Return a live stream of resource usage statistics of one or more container. If no container is specified, the statistics of all containers are returned.
+	// parameters:
+	//  - in: query
+	//    name: containers
+	//    description: names or IDs of containers
+	//    type: array
+	//    items:
+	//       type: string
+	//  - in: query
+	//    name: stream
+	//    type: boolean
+	//    default: true
+	//    description: Stream the output
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     description: no error
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/stats"), s.StreamingAPIHandler(libpod.StatsContainer)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/containers/{name}/net-stats libpod libpodContainerNetStats
+	// ---
+	// tags:
+	//  - containers
+	// summary: Get network statistics per interface
+	// description: |
+	//   Report the received and transmitted bytes, packets, errors and drops of each network
+	//   interface in the network namespace of a running container.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: query
+	//    name: stream
+	//    type: boolean
+	//    default: false
+	//    description: stream the statistics per interval until the container stops
+	//  - in: query
+	//    name: interval
+	//    type: string
+	//    default: 5s
+	//    description: time between streamed statistics, at least 1s
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/ContainerNetStats"
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/net-stats"), s.StreamingAPIHandler(libpod.ContainerNetStats)).Methods(http.MethodGet)
+
+	// swagger:operation GET /libpod/containers/{name}/top libpod libpodTopContainer
+	// ---
+	// tags:
+	//  - containers
+	// summary: List processes
+	// description: List processes running inside a container
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: |
+	//      Name of container to query for processes
+	//      (As of version 1.xx)
+	//  - in: query
+	//    name: stream
+	//    type: boolean
+	//    default: true
+	//    description: Stream the output
+	//  - in: query
+	//    name: ps_args
+	//    type: string
+	//    default: -ef
+	//    description: arguments to pass to ps such as aux. Requires ps(1) to be installed in the container if no ps(1) compatible AIX descriptors are used.
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/DocsContainerTopResponse"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/top"), s.APIHandler(compat.TopContainer)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/containers/{name}/unpause libpod libpodUnpauseContainer
 	// ---
 	// tags:
 	//  - containers
@@ -1198,7 +1661,7 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//     $ref: "#/responses/NoSuchContainer"
 	//   500:
 	//     $ref: "#/responses/InternalError"
-	r.HandleFunc(VersionedPath("/libpod/containers/{name}/wait"), s.APIHandler(libpod.WaitContainer)).Methods(http.MethodPost)
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/wait"), s.StreamingAPIHandler(libpod.WaitContainer)).Methods(http.MethodPost)
 	// swagger:operation GET /libpod/containers/{name}/exists libpod libpodContainerExists
 	// ---
 	// tags:
@@ -1258,7 +1721,7 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//     $ref: "#/responses/NoSuchContainer"
 	//   500:
 	//     $ref: "#/responses/InternalError"
-	r.HandleFunc(VersionedPath("/libpod/containers/{name}/stop"), s.APIHandler(compat.StopContainer)).Methods(http.MethodPost)
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/stop"), s.APIHandler(s.recordFailures(compat.StopContainer))).Methods(http.MethodPost)
 	// swagger:operation POST /libpod/containers/{name}/attach libpod libpodAttachContainer
 	// ---
 	// tags:
@@ -1311,9 +1774,11 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//     $ref: "#/responses/BadParamError"
 	//   404:
 	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     description: the container is paused or stopping
 	//   500:
 	//     $ref: "#/responses/InternalError"
-	r.HandleFunc(VersionedPath("/libpod/containers/{name}/attach"), s.APIHandler(compat.AttachContainer)).Methods(http.MethodPost)
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/attach"), s.StreamingAPIHandler(compat.AttachContainer)).Methods(http.MethodPost)
 	// swagger:operation POST /libpod/containers/{name}/resize libpod libpodResizeContainer
 	// ---
 	// tags:
@@ -1343,6 +1808,8 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//     $ref: "#/responses/ok"
 	//   404:
 	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
 	//   500:
 	//     $ref: "#/responses/InternalError"
 	r.HandleFunc(VersionedPath("/libpod/containers/{name}/resize"), s.APIHandler(compat.ResizeTTY)).Methods(http.MethodPost)
@@ -1367,7 +1834,7 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//     $ref: "#/responses/NoSuchContainer"
 	//   500:
 	//     $ref: "#/responses/InternalError"
-	r.HandleFunc(VersionedPath("/libpod/containers/{name}/export"), s.APIHandler(compat.ExportContainer)).Methods(http.MethodGet)
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/export"), s.StreamingAPIHandler(compat.ExportContainer)).Methods(http.MethodGet)
 	// swagger:operation POST /libpod/containers/{name}/checkpoint libpod libpodCheckpointContainer
 	// ---
 	// tags:
@@ -1493,9 +1960,9 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//     $ref: "#/responses/NoSuchContainer"
 	//   500:
 	//     $ref: "#/responses/InternalError"
-	r.HandleFunc(VersionedPath("/containers/{name}/changes"), s.APIHandler(compat.Changes)).Methods(http.MethodGet)
-	r.HandleFunc("/containers/{name}/changes", s.APIHandler(compat.Changes)).Methods(http.MethodGet)
-	r.HandleFunc(VersionedPath("/libpod/containers/{name}/changes"), s.APIHandler(compat.Changes)).Methods(http.MethodGet)
+	r.HandleFunc(VersionedPath("/containers/{name}/changes"), s.APIHandler(compat.ContainerChanges)).Methods(http.MethodGet)
+	r.HandleFunc("/containers/{name}/changes", s.APIHandler(compat.ContainerChanges)).Methods(http.MethodGet)
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/changes"), s.APIHandler(compat.ContainerChanges)).Methods(http.MethodGet)
 	// swagger:operation POST /libpod/containers/{name}/init libpod libpodInitContainer
 	// ---
 	// tags:
@@ -1549,5 +2016,802 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/InternalError"
 	r.HandleFunc(VersionedPath("/libpod/containers/{name}/rename"), s.APIHandler(compat.RenameContainer)).Methods(http.MethodPost)
+	// swagger:operation GET /libpod/containers/{name}/dependencies libpod libpodContainerDependencies
+	// ---
+	// tags:
+	//   - containers
+	// summary: Container dependency graph
+	// description: |
+	//   Return the containers the given container depends on through shared namespaces, its pod, or volumes-from,
+	//   and optionally the containers depending on it, as a graph of nodes and edges.
+	//   An edge from A to B means that A depends on B.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: query
+	//    name: recursive
+	//    type: boolean
+	//    default: false
+	//    description: follow dependencies transitively
+	//  - in: query
+	//    name: dependents
+	//    type: boolean
+	//    default: false
+	//    description: also include the containers depending on the container
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/ContainerDependencies"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/dependencies"), s.APIHandler(libpod.ContainerDependencies)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/containers/{name}/seccomp-notify libpod libpodSeccompNotify
+	// ---
+	// tags:
+	//   - containers
+	// summary: Stream seccomp notifications
+	// description: |
+	//   Stream the seccomp user notifications (syscalls flagged by the seccomp profile for notification)
+	//   raised by the container.
+	//   Requires support for seccomp user notification in the OCI runtime; a 501 is returned otherwise.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     description: stream of seccomp notifications
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	//   501:
+	//     description: seccomp user notification is not supported
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/seccomp-notify"), s.APIHandler(libpod.SeccompNotify)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/containers/{name}/dmesg libpod libpodContainerKernelMessages
+	// ---
+	// tags:
+	//   - containers
+	// summary: Kernel messages of a container
+	// description: |
+	//   Return the messages of the kernel ring buffer related to the container, like OOM kills,
+	//   matched by the container's cgroup and PIDs.
+	//   Reading the kernel log requires privileges, a 501 is returned if it can not be read.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: query
+	//    name: stream
+	//    type: boolean
+	//    default: false
+	//    description: keep streaming new messages as they are logged
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     description: stream of kernel messages
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	//   501:
+	//     description: the kernel log is not readable
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/dmesg"), s.StreamingAPIHandler(libpod.ContainerKernelMessages)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/containers/{name}/coredumps libpod libpodListCoreDumps
+	// ---
+	// tags:
+	//  - containers
+	// summary: List core dumps
+	// description: |
+	//   List the core dumps the kernel stored in the container for crashed processes. This requires
+	//   the core pattern of the host to store core dumps in files, relative paths are taken relative
+	//   to the working directory of the container.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/ContainerCoreDumps"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	//   501:
+	//     description: core dumps are piped to a helper and not stored in the container
+	//     schema:
+	//       $ref: "#/definitions/ErrorModel"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/coredumps"), s.APIHandler(libpod.ListCoreDumps)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/containers/{name}/coredumps/{id} libpod libpodGetCoreDump
+	// ---
+	// tags:
+	//  - containers
+	// summary: Get core dump
+	// description: Stream a core dump stored in the container.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: path
+	//    name: id
+	//    type: string
+	//    required: true
+	//    description: the ID of the core dump, as listed
+	// produces:
+	// - application/octet-stream
+	// responses:
+	//   200:
+	//     description: the core dump
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	//   501:
+	//     description: core dumps are piped to a helper and not stored in the container
+	//     schema:
+	//       $ref: "#/definitions/ErrorModel"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/coredumps/{id}"), s.APIHandler(libpod.GetCoreDump)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/containers/{name}/fsfreeze libpod libpodFreezeContainerFS
+	// ---
+	// tags:
+	//  - containers
+	// summary: Freeze container filesystem
+	// description: |
+	//   Freeze the root filesystem of a running container, so external tools can snapshot it
+	//   consistently while the container keeps running. Writes to the filesystem block until it is
+	//   thawed, which happens automatically after the timeout.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: query
+	//    name: timeout
+	//    type: string
+	//    default: 30s
+	//    description: thaw the filesystem automatically after this duration, at most 10m
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/ContainerFSFreeze"
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	//   501:
+	//     description: the root filesystem of the container does not support freezing
+	//     schema:
+	//       $ref: "#/definitions/ErrorModel"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/fsfreeze"), s.APIHandler(libpod.FreezeContainerFS)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/containers/{name}/fsthaw libpod libpodThawContainerFS
+	// ---
+	// tags:
+	//  - containers
+	// summary: Thaw container filesystem
+	// description: Thaw the root filesystem of a container frozen before.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	// produces:
+	// - application/json
+	// responses:
+	//   204:
+	//     description: no error
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/fsthaw"), s.APIHandler(libpod.ThawContainerFS)).Methods(http.MethodPost)
+	// swagger:operation GET /libpod/containers/{name}/holders libpod libpodContainerHolders
+	// ---
+	// tags:
+	//  - containers
+	// summary: List processes holding container resources
+	// description: |
+	//   List the host processes holding resources of a container: conmon and the conmon of exec
+	//   sessions, OCI runtime and podman processes working on it, and the processes in its cgroup.
+	//   Processes of the container are only listed if it has a cgroup of its own.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/ContainerHolders"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/holders"), s.APIHandler(libpod.ContainerHolders)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/containers/{name}/holders/kill libpod libpodKillContainerHolders
+	// ---
+	// tags:
+	//  - containers
+	// summary: Kill processes holding container resources
+	// description: |
+	//   Kill the host processes holding resources of a wedged container, one stuck stopping or
+	//   removing or one whose conmon died. Only conmon, OCI runtime and container processes are
+	//   killed, podman and other processes are listed by the holders endpoint but left alone.
+	//   Container processes are the ones in the cgroup dedicated to the container.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: query
+	//    name: signal
+	//    type: string
+	//    default: KILL
+	//    description: signal to send to the processes
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/ContainerHoldersKill"
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/holders/kill"), s.APIHandler(libpod.KillContainerHolders)).Methods(http.MethodPost)
+
+	// swagger:operation GET /libpod/containers/{name}/namespaces libpod libpodContainerNamespaces
+	// ---
+	// tags:
+	//   - containers
+	// summary: Container namespaces
+	// description: |
+	//   Return the inode numbers of the namespaces (cgroup, ipc, mnt, net, pid, user, uts) of the container's
+	//   init process and the container's cgroup path, to correlate kernel events with the container.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/ContainerNamespaces"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/namespaces"), s.APIHandler(libpod.ContainerNamespaces)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/containers/{name}/userns libpod libpodContainerUserNS
+	// ---
+	// tags:
+	//   - containers
+	// summary: Container user namespace
+	// description: |
+	//   Return the UID and GID mappings (container to host ranges) of the container's user namespace and
+	//   the host IDs root in the container maps to. Enabled is false when no user namespace is in use.
+	//   Mappings of running containers are read from the kernel.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/ContainerUserNS"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/userns"), s.APIHandler(libpod.ContainerUserNS)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/containers/{name}/fs-audit libpod libpodContainerFSAudit
+	// ---
+	// tags:
+	//   - containers
+	// summary: Stream file system changes
+	// description: |
+	//   Stream create, modify, delete, rename and attrib events of files in the root filesystem of a
+	//   running container as a sequence of JSON objects. At most 512 directories are watched,
+	//   directories created beyond that are not followed.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: query
+	//    name: path
+	//    type: string
+	//    default: /
+	//    description: only watch the subtree below this path in the container to limit overhead
+	//  - in: query
+	//    name: stream
+	//    type: boolean
+	//    default: true
+	//    description: stream the events; only streaming is supported
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/ContainerFSAudit"
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
+	//   413:
+	//     description: the subtree has more directories than can be watched
+	//     schema:
+	//       $ref: "#/definitions/ErrorModel"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	//   501:
+	//     description: watching file system events is not permitted
+	//     schema:
+	//       $ref: "#/definitions/ErrorModel"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/fs-audit"), s.StreamingAPIHandler(libpod.ContainerFSAudit)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/containers/{name}/sched libpod libpodContainerSched
+	// ---
+	// tags:
+	//   - containers
+	// summary: Container scheduling
+	// description: |
+	//   Return the CPU scheduling policy and nice value of each process of a running container. The processes
+	//   are the ones in the cgroup of the container, a container without a cgroup of its own is refused.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/ContainerSched"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/sched"), s.APIHandler(libpod.ContainerSched)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/containers/{name}/sched libpod libpodUpdateContainerSched
+	// ---
+	// tags:
+	//   - containers
+	// summary: Update container scheduling
+	// description: |
+	//   Set the CPU scheduling policy and/or the nice value of all processes of a running container, e.g.
+	//   to lower the priority of batch workloads. Processes started later inherit the settings from their
+	//   parent. The processes are the ones in the cgroup of the container, a container without a cgroup of
+	//   its own is refused.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: query
+	//    name: policy
+	//    type: string
+	//    enum: ["other", "batch", "idle"]
+	//    description: the scheduling policy
+	//  - in: query
+	//    name: nice
+	//    type: integer
+	//    description: the nice value, between -20 and 19
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/ContainerSched"
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/sched"), s.APIHandler(libpod.UpdateContainerSched)).Methods(http.MethodPost)
+	// swagger:operation GET /libpod/containers/{name}/shm libpod libpodContainerShm
+	// ---
+	// tags:
+	//   - containers
+	// summary: Container shm
+	// description: Return the size and usage in bytes of the /dev/shm tmpfs of a running container.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/ContainerShm"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/shm"), s.APIHandler(libpod.ContainerShm)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/containers/{name}/shm libpod libpodResizeContainerShm
+	// ---
+	// tags:
+	//   - containers
+	// summary: Resize container shm
+	// description: |
+	//   Resize the /dev/shm tmpfs of a running container without recreating it, the size is kept when the
+	//   container is started again. Only the tmpfs created by Podman can be resized, not one shared with
+	//   another container or mounted by the user. Shrinking it below what is used fails.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: query
+	//    name: size
+	//    type: string
+	//    required: true
+	//    description: the new size, e.g. 256m
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/ContainerShm"
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/shm"), s.APIHandler(libpod.ResizeContainerShm)).Methods(http.MethodPost)
+	// swagger:operation GET /libpod/containers/{name}/provenance libpod libpodContainerProvenance
+	// ---
+	// tags:
+	//   - containers
+	// summary: Container provenance
+	// description: |
+	//   Return the command used to create the container, the image and image digest it was created
+	//   from, and the creation time. For containers not created by the podman CLI, a `podman run`
+	//   command is reconstructed from the container configuration and Reconstructed is set.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/ContainerProvenance"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/provenance"), s.APIHandler(libpod.ContainerProvenance)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/containers/{name}/drift libpod libpodContainerDrift
+	// ---
+	// tags:
+	//  - containers
+	// summary: Compare container against a desired spec
+	// description: |
+	//   Report the settings of a container differing from a desired spec, to detect drift without
+	//   recreating the container. Only settings given in the desired spec are compared. Resource
+	//   limits compare the limits the container was created with.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: body
+	//    name: desired
+	//    description: the desired spec of the container
+	//    schema:
+	//      $ref: "#/definitions/SpecGenerator"
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/ContainerDrift"
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/drift"), s.APIHandler(libpod.ContainerDrift)).Methods(http.MethodPost)
+
+	// swagger:operation GET /libpod/containers/{name}/mounts libpod libpodContainerMounts
+	// ---
+	// tags:
+	//   - containers
+	// summary: Container mounts
+	// description: |
+	//   List the bind mounts, named volumes, image volumes and tmpfs mounts of a container with their
+	//   source, destination, type, options, read-write mode and configured propagation (rprivate when
+	//   none was given). For running containers, ActivePropagation reports the propagation in effect.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/ContainerMounts"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/mounts"), s.APIHandler(libpod.ContainerMounts)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/containers/{name}/dns libpod libpodContainerDNS
+	// ---
+	// tags:
+	//   - containers
+	// summary: Container name resolution
+	// description: |
+	//   Return the nameservers, search domains and options of the container's resolv.conf and the
+	//   extra hosts added with --add-host. For running containers the resolv.conf inside the container
+	//   is read; for containers never initialized only the configured values are returned.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/ContainerDNS"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/dns"), s.APIHandler(libpod.ContainerDNS)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/containers/{name}/bundle libpod libpodContainerBundle
+	// ---
+	// tags:
+	//   - containers
+	// summary: Export an OCI runtime bundle
+	// description: |
+	//   Stream a tar archive of an OCI runtime bundle of the container, which can be run with a bare OCI
+	//   runtime elsewhere. The archive holds the config.json, the rootfs directory and a manifest.json.
+	//   Host specific settings such as bind mounts, hooks, the cgroup path and namespace paths are
+	//   removed from config.json and listed in the manifest.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	// produces:
+	// - application/x-tar
+	// responses:
+	//   200:
+	//     description: tar archive of the bundle
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/bundle"), s.APIHandler(libpod.ContainerBundle)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/containers/{name}/syscalls libpod libpodContainerSyscalls
+	// ---
+	// tags:
+	//   - containers
+	// summary: Stream syscall statistics
+	// description: |
+	//   Stream the number of calls per system call made by the container during each interval, to help
+	//   building tight seccomp profiles. Counting system calls needs seccomp user notification or BPF
+	//   syscall tracing; 501 is returned when neither is available.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: query
+	//    name: stream
+	//    type: boolean
+	//    default: true
+	//    description: stream the statistics of each interval
+	//  - in: query
+	//    name: interval
+	//    type: string
+	//    default: 5s
+	//    description: length of the intervals, at least 1s
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/ContainerSyscalls"
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	//   501:
+	//     description: syscall tracing is not available
+	//     schema:
+	//       $ref: "#/definitions/ErrorModel"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/syscalls"), s.APIHandler(libpod.ContainerSyscalls)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/containers/{name}/migrate-storage libpod libpodMigrateContainerStorage
+	// ---
+	// tags:
+	//   - containers
+	// summary: Migrate container storage
+	// description: |
+	//   Move the writable layer of a stopped container to another graphroot, for balancing storage
+	//   across disks. The layer is placed as in a graphroot, so the target can also serve as an
+	//   additional image store, and stays linked from the storage of the container. The layer store
+	//   is locked during the move, which is rolled back on failure. Migrating to the graphroot of the
+	//   service moves the layer back. Only the overlay and vfs drivers are supported.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: query
+	//    name: graphroot
+	//    type: string
+	//    required: true
+	//    description: absolute path of the target graphroot
+	// produces:
+	// - application/json
+	// responses:
+	//   204:
+	//     description: no error
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	//   501:
+	//     description: the storage driver does not support migrating container storage
+	//     schema:
+	//       $ref: "#/definitions/ErrorModel"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/migrate-storage"), s.APIHandler(libpod.MigrateContainerStorage)).Methods(http.MethodPost)
+	// swagger:operation GET /libpod/containers/{name}/capabilities libpod libpodContainerCapabilities
+	// ---
+	// tags:
+	//   - containers
+	// summary: Container capabilities
+	// description: |
+	//   Return the effective, permitted, inheritable, bounding and ambient capability sets of the
+	//   container's init process as enforced by the kernel, decoded into capability names.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/ContainerCapabilities"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/capabilities"), s.APIHandler(libpod.ContainerCapabilities)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/logs libpod libpodLogsFromContainers
+	// ---
+	// tags:
+	//   - containers
+	// summary: Get logs of a set of containers
+	// description: |
+	//   Interleave the logs of any set of containers into one stream of JSON objects, each tagged with
+	//   the name and ID of its container and a color index, the position of the container in the
+	//   request. When following, containers exiting do not end the stream.
+	// parameters:
+	//  - in: body
+	//    name: request
+	//    description: the containers and lines to stream
+	//    schema:
+	//      $ref: "#/definitions/ContainersLogsRequest"
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/ContainersLogs"
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/logs"), s.StreamingAPIHandler(libpod.LogsFromContainers)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/containers/{name}/check-ports libpod libpodCheckContainerPorts
+	// ---
+	// tags:
+	//   - containers
+	// summary: Check port conflicts
+	// description: |
+	//   For a container which is not running, report the host ports it maps which are used by running
+	//   containers, held by a port reservation, or cannot be bound on the host, e.g. because another
+	//   process listens on them.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/ContainerCheckPorts"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/check-ports"), s.APIHandler(libpod.CheckContainerPorts)).Methods(http.MethodPost)
 	return nil
 }
@@ -34,9 +34,9 @@ func (s *APIServer) registerEventsHandlers(r *mux.Router) error {
 	//     description: returns a string of json data describing an event
 	//   500:
 	//     "$ref": "#/responses/InternalError"
-	r.Handle(VersionedPath("/events"), s.APIHandler(compat.GetEvents)).Methods(http.MethodGet)
+	r.Handle(VersionedPath("/events"), s.StreamingAPIHandler(compat.GetEvents)).Methods(http.MethodGet)
 	// Added non version path to URI to support docker non versioned paths
-	r.Handle("/events", s.APIHandler(compat.GetEvents)).Methods(http.MethodGet)
+	r.Handle("/events", s.StreamingAPIHandler(compat.GetEvents)).Methods(http.MethodGet)
 	// swagger:operation GET /libpod/events system libpodGetEvents
 	// ---
 	// tags:
@@ -68,6 +68,6 @@ func (s *APIServer) registerEventsHandlers(r *mux.Router) error {
 	//     description: returns a string of json data describing an event
 	//   500:
 	//     "$ref": "#/responses/InternalError"
-	r.Handle(VersionedPath("/libpod/events"), s.APIHandler(compat.GetEvents)).Methods(http.MethodGet)
+	r.Handle(VersionedPath("/libpod/events"), s.StreamingAPIHandler(compat.GetEvents)).Methods(http.MethodGet)
 	return nil
 }
@@ -68,10 +68,12 @@ func (s *APIServer) registerExecHandlers(r *mux.Router) error {
 	// responses:
 	//   201:
 	//     description: no error
+	//   400:
+	//     $ref: "#/responses/BadParamError"
 	//   404:
 	//     $ref: "#/responses/NoSuchContainer"
 	//   409:
-	//	   description: container is paused
+	//	   description: container is paused or not running
 	//   500:
 	//     $ref: "#/responses/InternalError"
 	r.Handle(VersionedPath("/containers/{name}/exec"), s.APIHandler(compat.ExecCreateHandler)).Methods(http.MethodPost)
@@ -109,12 +111,12 @@ func (s *APIServer) registerExecHandlers(r *mux.Router) error {
 	//   404:
 	//     $ref: "#/responses/NoSuchExecInstance"
 	//   409:
-	//	   description: container is not running
+	//	   description: container is not running or the exec session was started already
 	//   500:
 	//     $ref: "#/responses/InternalError"
-	r.Handle(VersionedPath("/exec/{id}/start"), s.APIHandler(compat.ExecStartHandler)).Methods(http.MethodPost)
+	r.Handle(VersionedPath("/exec/{id}/start"), s.StreamingAPIHandler(compat.ExecStartHandler)).Methods(http.MethodPost)
 	// Added non version path to URI to support docker non versioned paths
-	r.Handle("/exec/{id}/start", s.APIHandler(compat.ExecStartHandler)).Methods(http.MethodPost)
+	r.Handle("/exec/{id}/start", s.StreamingAPIHandler(compat.ExecStartHandler)).Methods(http.MethodPost)
 	// swagger:operation POST /exec/{id}/resize compat resizeExec
 	// ---
 	// tags:
@@ -139,10 +141,14 @@ func (s *APIServer) registerExecHandlers(r *mux.Router) error {
 	// produces:
 	// - application/json
 	// responses:
-	//   201:
+	//   200:
 	//     description: no error
+	//   400:
+	//     $ref: "#/responses/BadParamError"
 	//   404:
 	//     $ref: "#/responses/NoSuchExecInstance"
+	//   409:
+	//     description: the exec session has no tty or is not running
 	//   500:
 	//     $ref: "#/responses/InternalError"
 	r.Handle(VersionedPath("/exec/{id}/resize"), s.APIHandler(compat.ResizeTTY)).Methods(http.MethodPost)
@@ -279,7 +285,7 @@ func (s *APIServer) registerExecHandlers(r *mux.Router) error {
 	//	   description: container is not running.
 	//   500:
 	//     $ref: "#/responses/InternalError"
-	r.Handle(VersionedPath("/libpod/exec/{id}/start"), s.APIHandler(compat.ExecStartHandler)).Methods(http.MethodPost)
+	r.Handle(VersionedPath("/libpod/exec/{id}/start"), s.StreamingAPIHandler(compat.ExecStartHandler)).Methods(http.MethodPost)
 	// swagger:operation POST /libpod/exec/{id}/resize libpod libpodResizeExec
 	// ---
 	// tags:
@@ -306,8 +312,12 @@ func (s *APIServer) registerExecHandlers(r *mux.Router) error {
 	// responses:
 	//   201:
 	//     description: no error
+	//   400:
+	//     $ref: "#/responses/BadParamError"
 	//   404:
 	//     $ref: "#/responses/NoSuchExecInstance"
+	//   409:
+	//     description: the exec session has no tty or is not running
 	//   500:
 	//     $ref: "#/responses/InternalError"
 	r.Handle(VersionedPath("/libpod/exec/{id}/resize"), s.APIHandler(compat.ResizeTTY)).Methods(http.MethodPost)
@@ -13,24 +13,65 @@ func (s *APIServer) registerHealthCheckHandlers(r *mux.Router) error {
 	// tags:
 	//  - containers
 	// summary: Run a container's healthcheck
-	// description: Execute the defined healthcheck and return information about the results
+	// description: |
+	//   Execute the defined healthcheck and return the result of the probe (start, end, exit code,
+	//   output and resulting status).
+	//   When streaming, the healthcheck is run at its configured interval and the result of
+	//   each probe (start, end, exit code, output and resulting status) is written as a
+	//   separate JSON object until the client disconnects or the container stops.
 	// parameters:
 	//  - in: path
 	//    name: name:.*
 	//    type: string
 	//    required: true
 	//    description: the name or ID of the container
+	//  - in: query
+	//    name: stream
+	//    type: boolean
+	//    default: false
+	//    description: run the healthcheck repeatedly and stream the results
 	// produces:
 	// - application/json
 	// responses:
 	//   200:
 	//     $ref: "#/responses/HealthcheckRun"
+	//   400:
+	//     description: container has no healthcheck
 	//   404:
 	//     $ref: "#/responses/NoSuchContainer"
 	//   409:
-	//     description: container has no healthcheck or is not running
+	//     description: container is not running
 	//   500:
 	//     $ref: '#/responses/InternalError'
-	r.Handle(VersionedPath("/libpod/containers/{name:.*}/healthcheck"), s.APIHandler(libpod.RunHealthCheck)).Methods(http.MethodGet)
+	r.Handle(VersionedPath("/libpod/containers/{name:.*}/healthcheck"), s.StreamingAPIHandler(libpod.RunHealthCheck)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/containers/{name:.*}/health/diagnose libpod libpodDiagnoseHealthCheck
+	// ---
+	// tags:
+	//  - containers
+	// summary: Diagnose a container's healthcheck
+	// description: |
+	//   Run the defined healthcheck once and return its stdout, stderr, exit code and duration along
+	//   with the memory usage and CPU throttling of the container while it ran, and a best-effort list
+	//   of likely reasons for the result. The result is not recorded in the container's health.
+	// parameters:
+	//  - in: path
+	//    name: name:.*
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/HealthCheckDiagnosis"
+	//   400:
+	//     description: container has no healthcheck
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     description: container is not running
+	//   500:
+	//     $ref: '#/responses/InternalError'
+	r.Handle(VersionedPath("/libpod/containers/{name:.*}/health/diagnose"), s.APIHandler(libpod.DiagnoseHealthCheck)).Methods(http.MethodGet)
 	return nil
 }
@@ -10,7 +10,7 @@ import (
 
 // TODO
 //
-// * /images/create is missing the "message" and "platform" parameters
+// * /images/create is missing the "message" parameter
 
 func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	// swagger:operation POST /images/create compat createImage
@@ -34,6 +34,10 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//    name: tag
 	//    type: string
 	//    description: needs description
+	//  - in: query
+	//    name: platform
+	//    type: string
+	//    description: Platform in the format os[/arch[/variant]] to pull the image for
 	//  - in: header
 	//    name: X-Registry-Auth
 	//    type: string
@@ -46,13 +50,15 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	// responses:
 	//   200:
 	//     $ref: "#/responses/ok"
+	//   400:
+	//     $ref: "#/responses/BadParamError"
 	//   404:
 	//     $ref: "#/responses/NoSuchImage"
 	//   500:
 	//     $ref: "#/responses/InternalError"
-	r.Handle(VersionedPath("/images/create"), s.APIHandler(compat.CreateImageFromImage)).Methods(http.MethodPost).Queries("fromImage", "{fromImage}")
+	r.Handle(VersionedPath("/images/create"), s.StreamingAPIHandler(compat.CreateImageFromImage)).Methods(http.MethodPost).Queries("fromImage", "{fromImage}")
 	// Added non version path to URI to support docker non versioned paths
-	r.Handle("/images/create", s.APIHandler(compat.CreateImageFromImage)).Methods(http.MethodPost).Queries("fromImage", "{fromImage}")
+	r.Handle("/images/create", s.StreamingAPIHandler(compat.CreateImageFromImage)).Methods(http.MethodPost).Queries("fromImage", "{fromImage}")
 	r.Handle(VersionedPath("/images/create"), s.APIHandler(compat.CreateImageFromSrc)).Methods(http.MethodPost).Queries("fromSrc", "{fromSrc}")
 	// Added non version path to URI to support docker non versioned paths
 	r.Handle("/images/create", s.APIHandler(compat.CreateImageFromSrc)).Methods(http.MethodPost).Queries("fromSrc", "{fromSrc}")
@@ -103,22 +109,32 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//  - in: query
 	//    name: quiet
 	//    type: boolean
-	//    description: not supported
+	//    description: suppress the progress of the load
 	//  - in: body
 	//    name: request
-	//    description: tarball of container image
+	//    description: tarball of container image, in docker-archive or oci-archive format
 	//    schema:
 	//      type: string
 	// produces:
 	// - application/json
 	// responses:
 	//   200:
-	//     description: no error
+	//     description: progress of the load, ending with the loaded images
+	//     schema:
+	//       type: object
+	//       properties:
+	//         stream:
+	//           type: string
+	//           example: "Loaded image: quay.io/libpod/busybox:latest\n"
+	//         error:
+	//           type: string
+	//   400:
+	//     $ref: "#/responses/BadParamError"
 	//   500:
 	//     $ref: '#/responses/InternalError'
-	r.Handle(VersionedPath("/images/load"), s.APIHandler(compat.LoadImages)).Methods(http.MethodPost)
+	r.Handle(VersionedPath("/images/load"), s.StreamingAPIHandler(compat.LoadImages)).Methods(http.MethodPost)
 	// Added non version path to URI to support docker non versioned paths
-	r.Handle("/images/load", s.APIHandler(compat.LoadImages)).Methods(http.MethodPost)
+	r.Handle("/images/load", s.StreamingAPIHandler(compat.LoadImages)).Methods(http.MethodPost)
 	// swagger:operation POST /images/prune compat pruneImages
 	// ---
 	// tags:
@@ -135,12 +151,14 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//           unused *and* untagged images. When set to `false`
 	//           (or `0`), all unused images are pruned.
 	//        - `until=<string>` Prune images created before this timestamp. The `<timestamp>` can be Unix timestamps, date formatted timestamps, or Go duration strings (e.g. `10m`, `1h30m`) computed relative to the daemon machine’s time.
-	//        - `label` (`label=<key>`, `label=<key>=<value>`, `label!=<key>`, or `label!=<key>=<value>`) Prune images with (or without, in case `label!=...` is used) the specified labels.
+	//        - `label` (`label=<key>`, `label=<key>=<value>`) Prune images with the specified labels.
 	// produces:
 	// - application/json
 	// responses:
 	//   200:
-	//     $ref: "#/responses/DocsImageDeleteResponse"
+	//     $ref: "#/responses/DocsImagePruneResponse"
+	//   400:
+	//     $ref: "#/responses/BadParamError"
 	//   500:
 	//     $ref: '#/responses/InternalError'
 	r.Handle(VersionedPath("/images/prune"), s.APIHandler(compat.PruneImages)).Methods(http.MethodPost)
@@ -160,7 +178,7 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//  - in: query
 	//    name: limit
 	//    type: integer
-	//    description: maximum number of results
+	//    description: maximum number of results per registry
 	//  - in: query
 	//    name: filters
 	//    type: string
@@ -173,11 +191,15 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//    name: listTags
 	//    type: boolean
 	//    description: list the available tags in the repository
+	//  - in: header
+	//    name: X-Registry-Auth
+	//    type: string
+	//    description: A base64-encoded auth configuration.
 	// produces:
 	// - application/json
 	// responses:
 	//   200:
-	//     $ref: "#/responses/DocsSearchResponse"
+	//     $ref: "#/responses/DocsCompatSearchResponse"
 	//   400:
 	//     $ref: "#/responses/BadParamError"
 	//   500:
@@ -200,11 +222,11 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//  - in: query
 	//    name: force
 	//    type: boolean
-	//    description: remove the image even if used by containers or has other tags
+	//    description: remove the image even if it has other tags, only remove its tags if used by containers
 	//  - in: query
 	//    name: noprune
 	//    type: boolean
-	//    description: not supported. will be logged as an invalid parameter if enabled
+	//    description: do not remove parent images left untagged
 	// produces:
 	//  - application/json
 	// responses:
@@ -255,7 +277,9 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	// - application/json
 	// responses:
 	//   200:
-	//     description: no error
+	//     description: |
+	//       progress of the push as JSON messages, ending with the digest of the pushed image in an aux message.
+	//       Authentication and authorization failures carry the status 401 or 403 in their errorDetail.
 	//     schema:
 	//      type: string
 	//      format: binary
@@ -263,9 +287,9 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//     $ref: '#/responses/NoSuchImage'
 	//   500:
 	//     $ref: '#/responses/InternalError'
-	r.Handle(VersionedPath("/images/{name:.*}/push"), s.APIHandler(compat.PushImage)).Methods(http.MethodPost)
+	r.Handle(VersionedPath("/images/{name:.*}/push"), s.StreamingAPIHandler(compat.PushImage)).Methods(http.MethodPost)
 	// Added non version path to URI to support docker non versioned paths
-	r.Handle("/images/{name:.*}/push", s.APIHandler(compat.PushImage)).Methods(http.MethodPost)
+	r.Handle("/images/{name:.*}/push", s.StreamingAPIHandler(compat.PushImage)).Methods(http.MethodPost)
 	// swagger:operation GET /images/{name:.*}/get compat exportImage
 	// ---
 	// tags:
@@ -279,18 +303,20 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//    required: true
 	//    description: the name or ID of the container
 	// produces:
-	//  - application/json
+	//  - application/x-tar
 	// responses:
 	//   200:
 	//     description: no error
 	//     schema:
 	//      type: string
 	//      format: binary
+	//   404:
+	//     $ref: "#/responses/NoSuchImage"
 	//   500:
 	//     $ref: '#/responses/InternalError'
-	r.Handle(VersionedPath("/images/{name:.*}/get"), s.APIHandler(compat.ExportImage)).Methods(http.MethodGet)
+	r.Handle(VersionedPath("/images/{name:.*}/get"), s.StreamingAPIHandler(compat.ExportImage)).Methods(http.MethodGet)
 	// Added non version path to URI to support docker non versioned paths
-	r.Handle("/images/{name:.*}/get", s.APIHandler(compat.ExportImage)).Methods(http.MethodGet)
+	r.Handle("/images/{name:.*}/get", s.StreamingAPIHandler(compat.ExportImage)).Methods(http.MethodGet)
 	// swagger:operation GET /images/get compat get
 	// ---
 	// tags:
@@ -300,22 +326,28 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	// parameters:
 	//  - in:  query
 	//    name:  names
-	//    type: string
+	//    type: array
+	//    items:
+	//      type: string
 	//    required: true
-	//    description: one or more image names or IDs comma separated
+	//    description: one or more image names or IDs, repeated or comma separated
 	// produces:
-	//  - application/json
+	//  - application/x-tar
 	// responses:
 	//   200:
 	//     description: no error
 	//     schema:
 	//      type: string
 	//      format: binary
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   404:
+	//     $ref: "#/responses/NoSuchImage"
 	//   500:
 	//     $ref: '#/responses/InternalError'
-	r.Handle(VersionedPath("/images/get"), s.APIHandler(compat.ExportImages)).Methods(http.MethodGet)
+	r.Handle(VersionedPath("/images/get"), s.StreamingAPIHandler(compat.ExportImages)).Methods(http.MethodGet)
 	// Added non version path to URI to support docker non versioned paths
-	r.Handle("/images/get", s.APIHandler(compat.ExportImages)).Methods(http.MethodGet)
+	r.Handle("/images/get", s.StreamingAPIHandler(compat.ExportImages)).Methods(http.MethodGet)
 	// swagger:operation GET /images/{name:.*}/history compat imageHistory
 	// ---
 	// tags:
@@ -588,6 +620,20 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//      Default is 64MB
 	//      (As of version 1.xx)
 	//  - in: query
+	//    name: secrets
+	//    type: string
+	//    description: |
+	//      JSON array of secrets to expose to RUN --mount=type=secret instructions,
+	//      each of the form id=<id>,src=<path> or id=<id>,env=<variable>.
+	//      Not yet supported, a 501 is returned when set.
+	//  - in: query
+	//    name: ssh
+	//    type: string
+	//    description: |
+	//      JSON array of SSH agent sockets or keys to forward to RUN --mount=type=ssh instructions,
+	//      each of the form default|<id>[=<socket>|<key>[,<key>]].
+	//      Not yet supported, a 501 is returned when set.
+	//  - in: query
 	//    name: squash
 	//    type: boolean
 	//    default: false
@@ -651,14 +697,26 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//           description: output from build process
 	//           example: |
 	//             (build details...)
-	//             Successfully built 8ba084515c724cbf90d447a63600c0a6
+	//             Successfully built 8ba084515c72
+	//         aux:
+	//           type: object
+	//           description: ID of the built image, sent once the build succeeded
+	//           properties:
+	//             ID:
+	//               type: string
+	//               example: sha256:8ba084515c724cbf90d447a63600c0a6ea9c6b5dbc6e1fc9e3c4a5b10c7e1f58
+	//         error:
+	//           type: string
+	//           description: error ending a failed build
 	//   400:
 	//     $ref: "#/responses/BadParamError"
 	//   500:
 	//     $ref: "#/responses/InternalError"
-	r.Handle(VersionedPath("/build"), s.APIHandler(compat.BuildImage)).Methods(http.MethodPost)
+	//   501:
+	//     description: secret or ssh mounts were requested
+	r.Handle(VersionedPath("/build"), s.StreamingAPIHandler(compat.BuildImage)).Methods(http.MethodPost)
 	// Added non version path to URI to support docker non versioned paths
-	r.Handle("/build", s.APIHandler(compat.BuildImage)).Methods(http.MethodPost)
+	r.Handle("/build", s.StreamingAPIHandler(compat.BuildImage)).Methods(http.MethodPost)
 	/*
 		libpod endpoints
 	*/
@@ -700,7 +758,7 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//     $ref: '#/responses/NoSuchImage'
 	//   500:
 	//     $ref: '#/responses/InternalError'
-	r.Handle(VersionedPath("/libpod/images/{name:.*}/push"), s.APIHandler(libpod.PushImage)).Methods(http.MethodPost)
+	r.Handle(VersionedPath("/libpod/images/{name:.*}/push"), s.StreamingAPIHandler(libpod.PushImage)).Methods(http.MethodPost)
 	// swagger:operation GET /libpod/images/{name:.*}/exists libpod libpodImageExists
 	// ---
 	// tags:
@@ -974,7 +1032,7 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//     $ref: "#/responses/BadParamError"
 	//   500:
 	//     $ref: '#/responses/InternalError'
-	r.Handle(VersionedPath("/libpod/images/pull"), s.APIHandler(libpod.ImagesPull)).Methods(http.MethodPost)
+	r.Handle(VersionedPath("/libpod/images/pull"), s.StreamingAPIHandler(libpod.ImagesPull)).Methods(http.MethodPost)
 	// swagger:operation POST /libpod/images/prune libpod libpodPruneImages
 	// ---
 	// tags:
@@ -1035,6 +1093,36 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//   500:
 	//      $ref: '#/responses/InternalError'
 	r.Handle(VersionedPath("/libpod/images/search"), s.APIHandler(compat.SearchImages)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/images/watch libpod libpodWatchImages
+	// ---
+	// tags:
+	//  - images
+	// summary: Watch images
+	// description: |
+	//   Stream the events of images matching a reference pattern being pulled, pushed, tagged, untagged or
+	//   removed, so caching proxies and UIs can react to image changes. The pattern is matched against the
+	//   full names of the images with and without tag, e.g. quay.io/libpod/* or quay.io/libpod/alpine:3.*.
+	// parameters:
+	//  - in: query
+	//    name: reference
+	//    type: string
+	//    required: true
+	//    description: glob pattern of the image names to watch
+	//  - in: query
+	//    name: stream
+	//    type: boolean
+	//    default: true
+	//    description: stream new events, otherwise report the past events
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     description: returns a stream of image events
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.Handle(VersionedPath("/libpod/images/watch"), s.StreamingAPIHandler(libpod.WatchImages)).Methods(http.MethodGet)
 	// swagger:operation GET /libpod/images/{name:.*}/get libpod libpodExportImage
 	// ---
 	// tags:
@@ -1067,7 +1155,7 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//     $ref: '#/responses/NoSuchImage'
 	//   500:
 	//     $ref: '#/responses/InternalError'
-	r.Handle(VersionedPath("/libpod/images/{name:.*}/get"), s.APIHandler(libpod.ExportImage)).Methods(http.MethodGet)
+	r.Handle(VersionedPath("/libpod/images/{name:.*}/get"), s.StreamingAPIHandler(libpod.ExportImage)).Methods(http.MethodGet)
 	// swagger:operation GET /libpod/images/export libpod libpodExportImages
 	// ---
 	// tags:
@@ -1101,7 +1189,7 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//     $ref: '#/responses/NoSuchImage'
 	//   500:
 	//     $ref: '#/responses/InternalError'
-	r.Handle(VersionedPath("/libpod/images/export"), s.APIHandler(libpod.ExportImages)).Methods(http.MethodGet)
+	r.Handle(VersionedPath("/libpod/images/export"), s.StreamingAPIHandler(libpod.ExportImages)).Methods(http.MethodGet)
 	// swagger:operation GET /libpod/images/{name:.*}/json libpod libpodInspectImage
 	// ---
 	// tags:
@@ -1261,7 +1349,7 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//    name: name
 	//    type: string
 	//    required: true
-	//    description: the name or id of the container
+	//    description: the name or id of the image
 	// responses:
 	//   200:
 	//     description: Array of Changes
@@ -1270,10 +1358,10 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//       schema:
 	//         $ref: "#/responses/Changes"
 	//   404:
-	//     $ref: "#/responses/NoSuchContainer"
+	//     $ref: "#/responses/NoSuchImage"
 	//   500:
 	//     $ref: "#/responses/InternalError"
-	r.HandleFunc(VersionedPath("/libpod/images/{name}/changes"), s.APIHandler(compat.Changes)).Methods(http.MethodGet)
+	r.HandleFunc(VersionedPath("/libpod/images/{name}/changes"), s.APIHandler(compat.ImageChanges)).Methods(http.MethodGet)
 
 	// swagger:operation POST /libpod/build libpod libpodBuildImage
 	// ---
@@ -1290,6 +1378,12 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//      Path within the build context to the `Dockerfile`.
 	//      This is ignored if remote is specified and points to an external `Dockerfile`.
 	//  - in: query
+	//    name: contexthandle
+	//    type: string
+	//    description: |
+	//      Build from a context uploaded with /libpod/build/context before, instead of the
+	//      request body.
+	//  - in: query
 	//    name: t
 	//    type: string
 	//    default: latest
@@ -1415,6 +1509,20 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//      Default is 64MB
 	//      (As of version 1.xx)
 	//  - in: query
+	//    name: secrets
+	//    type: string
+	//    description: |
+	//      JSON array of secrets to expose to RUN --mount=type=secret instructions,
+	//      each of the form id=<id>,src=<path> or id=<id>,env=<variable>.
+	//      Not yet supported, a 501 is returned when set.
+	//  - in: query
+	//    name: ssh
+	//    type: string
+	//    description: |
+	//      JSON array of SSH agent sockets or keys to forward to RUN --mount=type=ssh instructions,
+	//      each of the form default|<id>[=<socket>|<key>[,<key>]].
+	//      Not yet supported, a 501 is returned when set.
+	//  - in: query
 	//    name: squash
 	//    type: boolean
 	//    default: false
@@ -1490,6 +1598,63 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//     $ref: "#/responses/BadParamError"
 	//   500:
 	//     $ref: "#/responses/InternalError"
-	r.Handle(VersionedPath("/libpod/build"), s.APIHandler(compat.BuildImage)).Methods(http.MethodPost)
+	//   501:
+	//     description: secret or ssh mounts were requested
+	r.Handle(VersionedPath("/libpod/build"), s.StreamingAPIHandler(compat.BuildImage)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/build/context libpod libpodUploadBuildContext
+	// ---
+	// tags:
+	//  - images
+	// summary: Upload build context
+	// description: |
+	//   Validate a build context and keep it for builds referring to it by handle, so a large
+	//   context can be used by several builds without uploading it again. The context must not
+	//   have entries or symbolic links pointing outside of it and must contain the Dockerfile. It is
+	//   removed when it expires, once no build uses it anymore.
+	//   The service stores at most 16 contexts at a time.
+	//   The tar may be compressed with gzip, bzip2, xz or zstd.
+	// consumes:
+	// - application/x-tar
+	// parameters:
+	//  - in: query
+	//    name: dockerfile
+	//    type: string
+	//    default: Dockerfile
+	//    description: Path within the build context to the `Dockerfile`.
+	//  - in: query
+	//    name: maxsize
+	//    type: integer
+	//    default: 4294967296
+	//    description: maximum size of the files in the context, in bytes, at most the default
+	//  - in: query
+	//    name: ttl
+	//    type: string
+	//    default: 1h
+	//    description: remove the context after this duration, at most 24h
+	//  - in: body
+	//    name: context
+	//    description: the build context as tar archive
+	//    schema:
+	//      type: string
+	//      format: binary
+	// produces:
+	// - application/json
+	// responses:
+	//   201:
+	//     $ref: "#/responses/BuildContext"
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   413:
+	//     description: the build context exceeds the maximum size
+	//     schema:
+	//       $ref: "#/definitions/ErrorModel"
+	//   429:
+	//     description: the service stores the maximum number of build contexts already
+	//     schema:
+	//       $ref: "#/definitions/ErrorModel"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.Handle(VersionedPath("/libpod/build/context"), s.APIHandler(compat.UploadBuildContext)).Methods(http.MethodPost)
+
 	return nil
 }
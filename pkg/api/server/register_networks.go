@@ -28,6 +28,8 @@ func (s *APIServer) registerNetworkHandlers(r *mux.Router) error {
 	//     description: no error
 	//   404:
 	//     $ref: "#/responses/NoSuchNetwork"
+	//   409:
+	//     description: Network is in use and cannot be removed
 	//   500:
 	//     $ref: "#/responses/InternalError"
 	r.HandleFunc(VersionedPath("/networks/{name}"), s.APIHandler(compat.RemoveNetwork)).Methods(http.MethodDelete)
@@ -71,11 +73,15 @@ func (s *APIServer) registerNetworkHandlers(r *mux.Router) error {
 	//        - id=[id] Matches for full or partial ID.
 	//        - driver=[driver] Only bridge is supported.
 	//        - label=[key] or label=[key=value] Matches networks based on the presence of a label alone or a label and a value.
+	//        - type=[custom|builtin] Filters networks by type. The builtin type is the default network.
+	//      Networks have to match every filter given.
 	// produces:
 	// - application/json
 	// responses:
 	//   200:
 	//     $ref: "#/responses/CompatNetworkList"
+	//   400:
+	//     $ref: "#/responses/BadParamError"
 	//   500:
 	//     $ref: "#/responses/InternalError"
 	r.HandleFunc(VersionedPath("/networks"), s.APIHandler(compat.ListNetworks)).Methods(http.MethodGet)
@@ -95,10 +101,12 @@ func (s *APIServer) registerNetworkHandlers(r *mux.Router) error {
 	//    schema:
 	//      $ref: "#/definitions/NetworkCreateRequest"
 	// responses:
-	//   200:
+	//   201:
 	//     $ref: "#/responses/CompatNetworkCreate"
 	//   400:
 	//     $ref: "#/responses/BadParamError"
+	//   409:
+	//     description: A network with the name exists already
 	//   500:
 	//     $ref: "#/responses/InternalError"
 	r.HandleFunc(VersionedPath("/networks/create"), s.APIHandler(compat.CreateNetwork)).Methods(http.MethodPost)
@@ -108,7 +116,7 @@ func (s *APIServer) registerNetworkHandlers(r *mux.Router) error {
 	// tags:
 	//  - networks (compat)
 	// summary: Connect container to network
-	// description: Connect a container to a network.  This endpoint is current a no-op
+	// description: Connect a container to a network.  A running container is attached right away.
 	// produces:
 	// - application/json
 	// parameters:
@@ -127,6 +135,10 @@ func (s *APIServer) registerNetworkHandlers(r *mux.Router) error {
 	//     description: OK
 	//   400:
 	//     $ref: "#/responses/BadParamError"
+	//   404:
+	//     description: No such container or network
+	//   409:
+	//     description: The container is connected to the network already
 	//   500:
 	//     $ref: "#/responses/InternalError"
 	r.HandleFunc(VersionedPath("/networks/{name}/connect"), s.APIHandler(compat.Connect)).Methods(http.MethodPost)
@@ -136,7 +148,7 @@ func (s *APIServer) registerNetworkHandlers(r *mux.Router) error {
 	// tags:
 	//  - networks (compat)
 	// summary: Disconnect container from network
-	// description: Disconnect a container from a network.  This endpoint is current a no-op
+	// description: Disconnect a container from a network.  A running container is detached right away.
 	// produces:
 	// - application/json
 	// parameters:
@@ -155,6 +167,8 @@ func (s *APIServer) registerNetworkHandlers(r *mux.Router) error {
 	//     description: OK
 	//   400:
 	//     $ref: "#/responses/BadParamError"
+	//   404:
+	//     description: No such container or network
 	//   500:
 	//     $ref: "#/responses/InternalError"
 	r.HandleFunc(VersionedPath("/networks/{name}/disconnect"), s.APIHandler(compat.Disconnect)).Methods(http.MethodPost)
@@ -264,6 +278,70 @@ func (s *APIServer) registerNetworkHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: '#/responses/InternalError'
 	r.Handle(VersionedPath("/libpod/networks/{name}/exists"), s.APIHandler(libpod.ExistsNetwork)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/networks/{name}/firewall libpod libpodExportNetworkFirewall
+	// ---
+	// tags:
+	//  - networks
+	// summary: Export network firewall rules
+	// description: |
+	//   Export the iptables rules in effect for a bridge network, as installed by the CNI plugins:
+	//   the rules tagged with the network or matching its subnets, and the chains only those rules jump to.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the network
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: '#/responses/NetworkFirewall'
+	//   404:
+	//     $ref: '#/responses/NoSuchNetwork'
+	//   500:
+	//     $ref: '#/responses/InternalError'
+	//   501:
+	//     description: the network has no firewall rules to export, e.g. it is rootless
+	//     schema:
+	//       $ref: "#/definitions/ErrorModel"
+	r.Handle(VersionedPath("/libpod/networks/{name}/firewall"), s.APIHandler(libpod.ExportNetworkFirewall)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/networks/{name}/firewall libpod libpodRestoreNetworkFirewall
+	// ---
+	// tags:
+	//  - networks
+	// summary: Restore network firewall rules
+	// description: |
+	//   Install firewall rules of a network exported before. Only the rules the CNI bridge, firewall and
+	//   portmap plugins install for containers of the network are accepted, for its subnets and in its
+	//   per container chains. Rules already in effect are skipped.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the network
+	//  - in: body
+	//    name: rules
+	//    description: the rules as exported
+	//    schema:
+	//      $ref: "#/definitions/NetworkFirewallReport"
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: '#/responses/NetworkFirewallRestore'
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   404:
+	//     $ref: '#/responses/NoSuchNetwork'
+	//   500:
+	//     $ref: '#/responses/InternalError'
+	//   501:
+	//     description: the network has no firewall rules, e.g. it is rootless
+	//     schema:
+	//       $ref: "#/definitions/ErrorModel"
+	r.Handle(VersionedPath("/libpod/networks/{name}/firewall"), s.APIHandler(libpod.RestoreNetworkFirewall)).Methods(http.MethodPost)
 	// swagger:operation GET /libpod/networks/json libpod libpodListNetwork
 	// ---
 	// tags:
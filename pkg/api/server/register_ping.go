@@ -46,6 +46,9 @@ func (s *APIServer) registerPingHandlers(r *mux.Router) error {
 	//         Pragma:
 	//           type: string
 	//           description: always no-cache
+	//         OSType:
+	//           type: string
+	//           description: Operating system the server runs on
 	//         Libpod-API-Version:
 	//           type: string
 	//           description: |
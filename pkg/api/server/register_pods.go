@@ -201,6 +201,39 @@ func (s *APIServer) registerPodsHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/InternalError"
 	r.Handle(VersionedPath("/libpod/pods/{name}/restart"), s.APIHandler(libpod.PodRestart)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/pods/{name}/rolling-restart pods RollingRestartPod
+	// ---
+	// tags:
+	//  - pods
+	// summary: Restart the containers of a pod one at a time
+	// description: |
+	//   Restart the containers of a pod, except its infra container, one after the other in creation order.
+	//   After restarting a container with a healthcheck, the healthcheck is run until it passes before
+	//   moving on. The progress is streamed as a sequence of JSON objects; the restart is aborted and a
+	//   report with status "failed" is sent when a container fails to restart or to become healthy.
+	// produces:
+	// - application/json
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the pod
+	//  - in: query
+	//    name: interval
+	//    type: string
+	//    default: 5s
+	//    description: time to wait between restarting two containers
+	// responses:
+	//   200:
+	//     $ref: '#/responses/PodRollingRestartReport'
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   404:
+	//     $ref: "#/responses/NoSuchPod"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.Handle(VersionedPath("/libpod/pods/{name}/rolling-restart"), s.StreamingAPIHandler(libpod.PodRollingRestart)).Methods(http.MethodPost)
 	// swagger:operation POST /libpod/pods/{name}/start pods startPod
 	// ---
 	// summary: Start a pod
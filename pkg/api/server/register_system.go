@@ -9,6 +9,27 @@ import (
 )
 
 func (s *APIServer) registerSystemHandlers(r *mux.Router) error {
+	// swagger:operation GET /system/df compat SystemDataUsage
+	// ---
+	// tags:
+	//   - system (compat)
+	// summary: Show disk usage
+	// description: Return information about disk usage for images, containers, and volumes
+	// parameters:
+	//  - in: query
+	//    name: verbose
+	//    type: boolean
+	//    default: true
+	//    description: list the entries computing the totals, always listed as Docker clients compute the totals from them
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: '#/responses/SystemDiskUseCompat'
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   500:
+	//     $ref: "#/responses/InternalError"
 	r.Handle(VersionedPath("/system/df"), s.APIHandler(compat.GetDiskUsage)).Methods(http.MethodGet)
 	// Added non version path to URI to support docker non versioned paths
 	r.Handle("/system/df", s.APIHandler(compat.GetDiskUsage)).Methods(http.MethodGet)
@@ -41,5 +62,217 @@ func (s *APIServer) registerSystemHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/InternalError"
 	r.Handle(VersionedPath("/libpod/system/df"), s.APIHandler(libpod.DiskUsage)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/system/usage libpod groupUsage
+	// ---
+	// tags:
+	//   - system
+	// summary: Show resource usage of a group of containers
+	// description: |
+	//   Return the summed CPU, memory, network and block I/O usage of the running containers matching
+	//   the filters, e.g. of all containers of an application sharing a label.
+	// parameters:
+	//  - in: query
+	//    name: filters
+	//    type: string
+	//    description: |
+	//      container filters as JSON or as key=value pairs, e.g. label=app=web. The filters of
+	//      listing containers are supported.
+	//  - in: query
+	//    name: stream
+	//    type: boolean
+	//    default: false
+	//    description: report the usage per interval until the connection is closed
+	//  - in: query
+	//    name: interval
+	//    type: string
+	//    default: 5s
+	//    description: interval of the reports when streaming, at least 1s
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: '#/responses/GroupUsage'
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.Handle(VersionedPath("/libpod/system/usage"), s.StreamingAPIHandler(libpod.GroupUsage)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/system/check libpod systemCheck
+	// ---
+	// tags:
+	//   - system
+	// summary: Check storage consistency
+	// description: |
+	//   Check the database and storage for inconsistencies, as left after crashes: containers with missing
+	//   storage or layers, dangling pod members and addresses of the podman networks allocated to containers
+	//   not in the database. With repair, containers with broken storage are removed unless running.
+	//   Allocated addresses are only reported, other podman instances may use the networks as well.
+	// parameters:
+	//  - in: query
+	//    name: repair
+	//    type: boolean
+	//    default: false
+	//    description: attempt safe repairs of the inconsistencies found
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: '#/responses/SystemCheck'
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.Handle(VersionedPath("/libpod/system/check"), s.APIHandler(libpod.SystemCheck)).Methods(http.MethodPost)
+	// swagger:operation GET /libpod/system/failures libpod listFailures
+	// ---
+	// tags:
+	//   - system
+	// summary: List failed requests
+	// description: |
+	//   Return the create, start and stop requests of containers which failed with a server error, oldest
+	//   first, with the request, the error and when it failed. Recording is enabled with the
+	//   --record-failures option of the service, which caps the number of failures kept.
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: '#/responses/SystemFailures'
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.Handle(VersionedPath("/libpod/system/failures"), s.APIHandler(s.listFailures)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/system/failures/{id}/replay libpod replayFailure
+	// ---
+	// tags:
+	//   - system
+	// summary: Replay a failed request
+	// description: |
+	//   Send a recorded failed request again, once its cause is fixed, and answer its response. The
+	//   failure is removed from the list when the request succeeds, otherwise it is updated.
+	// parameters:
+	//  - in: path
+	//    name: id
+	//    type: string
+	//    required: true
+	//    description: the ID of the failure
+	// responses:
+	//   404:
+	//     $ref: "#/responses/NoSuchFailure"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.Handle(VersionedPath("/libpod/system/failures/{id}/replay"), s.APIHandler(s.replayFailure)).Methods(http.MethodPost)
+	// swagger:operation GET /libpod/system/pull-limits libpod getPullLimits
+	// ---
+	// tags:
+	//   - system
+	// summary: Show pull limits
+	// description: Return the limits shared by all image pulls of the service, and the layer downloads in progress.
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: '#/responses/PullLimits'
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.Handle(VersionedPath("/libpod/system/pull-limits"), s.APIHandler(libpod.GetPullLimits)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/system/pull-limits libpod setPullLimits
+	// ---
+	// tags:
+	//   - system
+	// summary: Set pull limits
+	// description: |
+	//   Set the maximum number of concurrent layer downloads and the bandwidth shared by all image pulls
+	//   from registries, so a burst of pulls doesn't saturate the network link. The limits apply to pulls
+	//   in progress too. Limits not given are kept.
+	// parameters:
+	//  - in: query
+	//    name: maxConcurrentDownloads
+	//    type: integer
+	//    description: maximum number of concurrent layer downloads, 0 for no limit
+	//  - in: query
+	//    name: bandwidth
+	//    type: string
+	//    description: bandwidth per second, e.g. 10m, 0 for no limit
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: '#/responses/PullLimits'
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.Handle(VersionedPath("/libpod/system/pull-limits"), s.APIHandler(libpod.SetPullLimits)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/system/ports/reserve libpod reservePorts
+	// ---
+	// tags:
+	//   - system
+	// summary: Reserve host ports
+	// description: |
+	//   Reserve host ports for a container about to be created, to avoid two creates picking the same
+	//   port. The service binds the given ports, or count free ports, until a container is created with
+	//   the returned token as port-reservation, the reservation is released or it expires. A failed
+	//   create leaves the reservation usable. Once the container is created the service unbinds the
+	//   ports for the container to bind them, however it is started, and they stay reserved until it
+	//   is started or removed. Creating a container mapping a reserved host port without the token
+	//   fails with 409.
+	// parameters:
+	//  - in: query
+	//    name: port
+	//    type: array
+	//    items:
+	//      type: integer
+	//    description: host ports to reserve, instead of count free ports
+	//  - in: query
+	//    name: count
+	//    type: integer
+	//    default: 1
+	//    description: number of free host ports to reserve
+	//  - in: query
+	//    name: protocol
+	//    type: string
+	//    enum: ["tcp", "udp"]
+	//    default: tcp
+	//  - in: query
+	//    name: ttl
+	//    type: string
+	//    default: 1m
+	//    description: time after which reservations expire, at most 1h
+	// produces:
+	// - application/json
+	// responses:
+	//   201:
+	//     $ref: '#/responses/PortReservation'
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   409:
+	//     description: a port is already in use
+	//     schema:
+	//       $ref: "#/definitions/ErrorModel"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.Handle(VersionedPath("/libpod/system/ports/reserve"), s.APIHandler(libpod.ReservePorts)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/system/ports/release libpod releasePorts
+	// ---
+	// tags:
+	//   - system
+	// summary: Release reserved host ports
+	// parameters:
+	//  - in: query
+	//    name: token
+	//    type: string
+	//    required: true
+	//    description: token of the port reservation
+	// produces:
+	// - application/json
+	// responses:
+	//   204:
+	//     description: no error
+	//   404:
+	//     description: no such port reservation
+	//     schema:
+	//       $ref: "#/definitions/ErrorModel"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.Handle(VersionedPath("/libpod/system/ports/release"), s.APIHandler(libpod.ReleasePorts)).Methods(http.MethodPost)
 	return nil
 }
@@ -31,5 +31,6 @@ func (s *APIServer) registerVersionHandlers(r *mux.Router) error {
 	//   200:
 	//    $ref: "#/responses/Version"
 	r.Handle(VersionedPath("/libpod/version"), s.APIHandler(compat.VersionHandler)).Methods(http.MethodGet)
+	r.Handle("/libpod/version", s.APIHandler(compat.VersionHandler)).Methods(http.MethodGet)
 	return nil
 }
@@ -135,6 +135,71 @@ func (s *APIServer) registerVolumeHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/InternalError"
 	r.Handle(VersionedPath("/libpod/volumes/{name}"), s.APIHandler(libpod.RemoveVolume)).Methods(http.MethodDelete)
+	// swagger:operation GET /libpod/volumes/{name}/export libpod libpodExportVolume
+	// ---
+	// tags:
+	//  - volumes
+	// summary: Export a volume
+	// description: Stream the contents of a volume as a tar archive
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the volume
+	//  - in: query
+	//    name: compress
+	//    type: boolean
+	//    default: false
+	//    description: gzip compress the archive
+	// produces:
+	// - application/x-tar
+	// - application/gzip
+	// responses:
+	//   200:
+	//     description: tarball of the volume contents
+	//   404:
+	//     $ref: "#/responses/NoSuchVolume"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.Handle(VersionedPath("/libpod/volumes/{name}/export"), s.StreamingAPIHandler(libpod.ExportVolume)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/volumes/{name}/import libpod libpodImportVolume
+	// ---
+	// tags:
+	//  - volumes
+	// summary: Import into a volume
+	// description: Extract a tar archive, optionally compressed, into a volume
+	// consumes:
+	// - application/x-tar
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the volume
+	//  - in: query
+	//    name: force
+	//    type: boolean
+	//    default: false
+	//    description: import even if the volume is in use by a running container
+	//  - in: body
+	//    name: request
+	//    description: tarball of the contents to import
+	//    schema:
+	//      type: string
+	//      format: binary
+	// produces:
+	// - application/json
+	// responses:
+	//   204:
+	//     description: no error
+	//   404:
+	//     $ref: "#/responses/NoSuchVolume"
+	//   409:
+	//     description: volume is in use by a running container
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.Handle(VersionedPath("/libpod/volumes/{name}/import"), s.APIHandler(libpod.ImportVolume)).Methods(http.MethodPost)
 
 	/*
 	 * Docker compatibility endpoints
@@ -154,15 +219,17 @@ func (s *APIServer) registerVolumeHandlers(r *mux.Router) error {
 	//    type: string
 	//    description: |
 	//      JSON encoded value of the filters (a map[string][]string) to process on the volumes list. Available filters:
+	//        - dangling=<boolean> When set to `true` (or `1`), returns all volumes that are not in use by a container. When set to `false` (or `0`), only volumes that are in use by one or more containers are returned.
 	//        - driver=<volume-driver-name> Matches volumes based on their driver.
-	//        - label=<key> or label=<key>:<value> Matches volumes based on the presence of a label alone or a label and a value.
-	//        - name=<volume-name> Matches all of volume name.
+	//        - label=<key> or label=<key>=<value> Matches volumes based on the presence of a label alone or a label and a value.
+	//        - name=<volume-name> Matches all or part of a volume name.
 	//
-	//      Note:
-	//        The boolean `dangling` filter is not yet implemented for this endpoint.
+	//      Volumes must match all filters, and any of the values of a filter but for labels, which must all match.
 	// responses:
 	//   '200':
 	//     "$ref": "#/responses/VolumeListResponse"
+	//   '400':
+	//     "$ref": "#/responses/BadParamError"
 	//   '500':
 	//     "$ref": "#/responses/InternalError"
 	r.Handle(VersionedPath("/volumes"), s.APIHandler(compat.ListVolumes)).Methods(http.MethodGet)
@@ -186,6 +253,10 @@ func (s *APIServer) registerVolumeHandlers(r *mux.Router) error {
 	// responses:
 	//   '201':
 	//     "$ref": "#/responses/DockerVolumeInfoResponse"
+	//   '400':
+	//     "$ref": "#/responses/BadParamError"
+	//   '409':
+	//     description: a volume by the same name exists with a different driver
 	//   '500':
 	//     "$ref": "#/responses/InternalError"
 	r.Handle(VersionedPath("/volumes/create"), s.APIHandler(compat.CreateVolume)).Methods(http.MethodPost)
@@ -258,12 +329,15 @@ func (s *APIServer) registerVolumeHandlers(r *mux.Router) error {
 	//    name: filters
 	//    type: string
 	//    description: |
-	//      JSON encoded value of filters (a map[string][]string) to match volumes against before pruning.
+	//      JSON encoded value of filters (a map[string][]string) to match volumes against before pruning. Available filters:
+	//        - label=<key> or label=<key>=<value> Prune volumes with (all of) the labels.
 	//
-	//      Note: No filters are currently supported and any filters specified will cause an error response.
+	//      Volumes used by any container, running or not, are never pruned.
 	// responses:
 	//   '200':
 	//      "$ref": "#/responses/DockerVolumePruneResponse"
+	//   '400':
+	//      "$ref": "#/responses/BadParamError"
 	//   '500':
 	//      "$ref": "#/responses/InternalError"
 	r.Handle(VersionedPath("/volumes/prune"), s.APIHandler(compat.PruneVolumes)).Methods(http.MethodPost)
@@ -16,6 +16,7 @@ import (
 	"github.com/containers/podman/v3/libpod"
 	"github.com/containers/podman/v3/libpod/shutdown"
 	"github.com/containers/podman/v3/pkg/api/handlers"
+	libpodAPI "github.com/containers/podman/v3/pkg/api/handlers/libpod"
 	"github.com/containers/podman/v3/pkg/api/server/idle"
 	"github.com/coreos/go-systemd/v22/activation"
 	"github.com/coreos/go-systemd/v22/daemon"
@@ -34,6 +35,12 @@ type APIServer struct {
 	context.CancelFunc               // Stop APIServer
 	idleTracker        *idle.Tracker // Track connections to support idle shutdown
 	pprof              *http.Server  // Sidecar http server for providing performance data
+	failures           failureLog    // Failed mutating requests to be replayed
+	writeTimeout       time.Duration // Time allowed to respond to a request, except streaming ones
+
+	hijackedLock sync.Mutex                 // protect hijacked and closing
+	hijacked     map[*hijackedConn]struct{} // Connections managed by handlers, closed on shutdown
+	closing      bool                       // Hijacked connections are being closed
 }
 
 // Number of seconds to wait for next request, if exceeded shutdown server
@@ -45,17 +52,47 @@ const (
 // shutdownOnce ensures Shutdown() may safely be called from several go routines
 var shutdownOnce sync.Once
 
+// Timeouts of the connections to the API server, zero disables a timeout.
+type Timeouts struct {
+	// ReadHeaderTimeout is the time allowed to read the headers of a request
+	ReadHeaderTimeout time.Duration
+	// IdleTimeout is the time a keep-alive connection waits for the next request
+	IdleTimeout time.Duration
+	// WriteTimeout is the time allowed to handle a request and write the
+	// response once its headers are read.  Endpoints registered with
+	// StreamingAPIHandler are exempt.
+	WriteTimeout time.Duration
+}
+
+// DefaultTimeouts returns the timeouts of a server idle after duration.  The
+// write timeout is disabled, operations like pulling or stopping containers
+// may take long.
+func DefaultTimeouts(duration time.Duration) Timeouts {
+	if duration == UnlimitedServiceDuration {
+		duration = DefaultServiceDuration
+	}
+	return Timeouts{
+		ReadHeaderTimeout: 20 * time.Second,
+		IdleTimeout:       duration * 2,
+	}
+}
+
 // NewServer will create and configure a new API server with all defaults
 func NewServer(runtime *libpod.Runtime) (*APIServer, error) {
-	return newServer(runtime, DefaultServiceDuration, nil)
+	return newServer(runtime, DefaultServiceDuration, nil, DefaultTimeouts(DefaultServiceDuration))
 }
 
 // NewServerWithSettings will create and configure a new API server using provided settings
 func NewServerWithSettings(runtime *libpod.Runtime, duration time.Duration, listener *net.Listener) (*APIServer, error) {
-	return newServer(runtime, duration, listener)
+	return newServer(runtime, duration, listener, DefaultTimeouts(duration))
 }
 
-func newServer(runtime *libpod.Runtime, duration time.Duration, listener *net.Listener) (*APIServer, error) {
+// NewServerWithTimeouts will create and configure a new API server using provided settings and timeouts
+func NewServerWithTimeouts(runtime *libpod.Runtime, duration time.Duration, listener *net.Listener, timeouts Timeouts) (*APIServer, error) {
+	return newServer(runtime, duration, listener, timeouts)
+}
+
+func newServer(runtime *libpod.Runtime, duration time.Duration, listener *net.Listener, timeouts Timeouts) (*APIServer, error) {
 	// If listener not provided try socket activation protocol
 	if listener == nil {
 		if _, found := os.LookupEnv("LISTEN_PID"); !found {
@@ -79,17 +116,23 @@ func newServer(runtime *libpod.Runtime, duration time.Duration, listener *net.Li
 	server := APIServer{
 		Server: http.Server{
 			Handler:           router,
-			ReadHeaderTimeout: 20 * time.Second,
-			IdleTimeout:       duration * 2,
+			ReadHeaderTimeout: timeouts.ReadHeaderTimeout,
+			IdleTimeout:       timeouts.IdleTimeout,
 			ConnState:         idle.ConnState,
-			ErrorLog:          log.New(logrus.StandardLogger().Out, "", 0),
+			ConnContext: func(ctx context.Context, c net.Conn) context.Context {
+				return context.WithValue(ctx, "conn", c) // nolint
+			},
+			ErrorLog: log.New(logrus.StandardLogger().Out, "", 0),
 		},
-		Decoder:     handlers.NewAPIDecoder(),
-		idleTracker: idle,
-		Listener:    *listener,
-		Runtime:     runtime,
+		Decoder:      handlers.NewAPIDecoder(),
+		idleTracker:  idle,
+		Listener:     *listener,
+		Runtime:      runtime,
+		writeTimeout: timeouts.WriteTimeout,
 	}
 
+	router.Use(gzipHandler)
+
 	router.NotFoundHandler = http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
 			// We can track user errors...
@@ -176,12 +219,21 @@ func setupSystemd() {
 	}
 }
 
-// Serve starts responding to HTTP requests.
+// Serve starts responding to HTTP requests, until the service is idle or
+// terminated by a signal.
 func (s *APIServer) Serve() error {
 	setupSystemd()
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.CancelFunc = cancel
+
+	// The libpod handler exits once this one returns, wait for the clients.
+	served := make(chan struct{})
 	if err := shutdown.Register("server", func(sig os.Signal) error {
-		return s.Shutdown()
+		cancel()
+		<-served
+		return nil
 	}); err != nil {
 		return err
 	}
@@ -190,7 +242,9 @@ func (s *APIServer) Serve() error {
 		return err
 	}
 
-	errChan := make(chan error, 1)
+	// Containers paused with a deadline by a previous service are still
+	// to be unpaused.
+	libpodAPI.ScheduleDeadlineUnpauses(s.Runtime)
 
 	go func() {
 		<-s.idleTracker.Done()
@@ -199,12 +253,13 @@ func (s *APIServer) Serve() error {
 	}()
 
 	if logrus.IsLevelEnabled(logrus.DebugLevel) {
+		pprofMux := mux.NewRouter()
+		pprofMux.PathPrefix("/debug/pprof").Handler(http.DefaultServeMux)
+		goRuntime.SetMutexProfileFraction(1)
+		goRuntime.SetBlockProfileRate(1)
+		s.pprof = &http.Server{Addr: "localhost:8888", Handler: pprofMux}
+		defer s.pprof.Close()
 		go func() {
-			pprofMux := mux.NewRouter()
-			pprofMux.PathPrefix("/debug/pprof").Handler(http.DefaultServeMux)
-			goRuntime.SetMutexProfileFraction(1)
-			goRuntime.SetBlockProfileRate(1)
-			s.pprof = &http.Server{Addr: "localhost:8888", Handler: pprofMux}
 			err := s.pprof.ListenAndServe()
 			if err != nil && err != http.ErrServerClosed {
 				logrus.Warn("Profiler Service failed: " + err.Error())
@@ -216,19 +271,56 @@ func (s *APIServer) Serve() error {
 	// creation.
 	_ = syscall.Umask(0022)
 
+	err := s.ServeContext(ctx, s.Listener)
+	close(served)
+	return err
+}
+
+// ServeContext responds to HTTP requests on listener until ctx is canceled.
+// New connections are then refused and the requests in flight are given the
+// idle window to complete, their contexts are canceled so streaming handlers
+// end.  Connections hijacked by handlers are closed last.
+func (s *APIServer) ServeContext(ctx context.Context, listener net.Listener) error {
+	s.Server.BaseContext = func(net.Listener) context.Context {
+		return ctx
+	}
+
+	errChan := make(chan error, 1)
 	go func() {
-		err := s.Server.Serve(s.Listener)
-		if err != nil && err != http.ErrServerClosed {
-			errChan <- errors.Wrap(err, "failed to start API server")
-			return
-		}
-		errChan <- nil
+		errChan <- s.Server.Serve(listener)
 	}()
 
-	return <-errChan
+	select {
+	case err := <-errChan:
+		if err == http.ErrServerClosed {
+			return nil
+		}
+		return errors.Wrap(err, "failed to start API server")
+	case <-ctx.Done():
+	}
+
+	logrus.Debugf("APIServer shutting down, %d/%d connection(s)",
+		s.idleTracker.ActiveConnections(), s.idleTracker.TotalConnections())
+	grace := s.idleTracker.Duration
+	if grace == UnlimitedServiceDuration {
+		grace = DefaultServiceDuration
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), grace)
+	defer cancel()
+	if err := s.Server.Shutdown(shutdownCtx); err != nil {
+		logrus.Error(errors.Wrapf(err, "failed to cleanly shutdown APIServer"))
+		_ = s.Server.Close()
+	}
+	s.closeHijacked()
+
+	if err := <-errChan; err != http.ErrServerClosed {
+		return errors.Wrap(err, "failed to start API server")
+	}
+	return nil
 }
 
-// Shutdown is a clean shutdown waiting on existing clients
+// Shutdown stops the server once it is idle, Serve returns when the clients
+// in flight are done.
 func (s *APIServer) Shutdown() error {
 	if s.idleTracker.Duration == UnlimitedServiceDuration {
 		logrus.Debug("APIServer.Shutdown ignored as Duration is UnlimitedService")
@@ -240,32 +332,10 @@ func (s *APIServer) Shutdown() error {
 			_, file, line, _ := goRuntime.Caller(1)
 			logrus.Debugf("APIServer.Shutdown by %s:%d, %d/%d connection(s)",
 				file, line, s.idleTracker.ActiveConnections(), s.idleTracker.TotalConnections())
-
-			go func() {
-				ctx, cancel := context.WithTimeout(context.Background(), s.idleTracker.Duration)
-				go func() {
-					defer cancel()
-					if err := s.pprof.Shutdown(ctx); err != nil {
-						logrus.Warn(
-							errors.Wrapf(err, "failed to cleanly shutdown pprof Server"))
-					}
-				}()
-				<-ctx.Done()
-			}()
 		}
-
-		// Gracefully shutdown server(s), duration of wait same as idle window
-		ctx, cancel := context.WithTimeout(context.Background(), s.idleTracker.Duration)
-		go func() {
-			defer cancel()
-
-			err := s.Server.Shutdown(ctx)
-			if err != nil && err != context.Canceled && err != http.ErrServerClosed {
-				logrus.Error(
-					errors.Wrapf(err, "failed to cleanly shutdown APIServer"))
-			}
-		}()
-		<-ctx.Done()
+		if s.CancelFunc != nil {
+			s.CancelFunc()
+		}
 	})
 
 	return nil
@@ -273,5 +343,57 @@ func (s *APIServer) Shutdown() error {
 
 // Close immediately stops responding to clients and exits
 func (s *APIServer) Close() error {
-	return s.Server.Close()
+	err := s.Server.Close()
+	s.closeHijacked()
+	return err
+}
+
+// hijackedConn is a connection hijacked by a handler, it is forgotten once
+// the handler closes it.
+type hijackedConn struct {
+	net.Conn
+	s    *APIServer
+	once sync.Once
+}
+
+func (c *hijackedConn) Close() error {
+	c.once.Do(func() {
+		c.s.hijackedLock.Lock()
+		delete(c.s.hijacked, c)
+		c.s.hijackedLock.Unlock()
+	})
+	return c.Conn.Close()
+}
+
+// trackHijacked returns conn to be closed on shutdown.
+func (s *APIServer) trackHijacked(conn net.Conn) net.Conn {
+	c := &hijackedConn{Conn: conn, s: s}
+	s.hijackedLock.Lock()
+	defer s.hijackedLock.Unlock()
+	if s.closing {
+		// Hijacked as the server shut down
+		_ = conn.Close()
+		return c
+	}
+	if s.hijacked == nil {
+		s.hijacked = make(map[*hijackedConn]struct{})
+	}
+	s.hijacked[c] = struct{}{}
+	return c
+}
+
+// closeHijacked closes the connections left by handlers.
+func (s *APIServer) closeHijacked() {
+	s.hijackedLock.Lock()
+	s.closing = true
+	conns := make([]*hijackedConn, 0, len(s.hijacked))
+	for c := range s.hijacked {
+		conns = append(conns, c)
+	}
+	s.hijackedLock.Unlock()
+
+	for _, c := range conns {
+		logrus.Debugf("APIServer closing hijacked connection %s", c.RemoteAddr())
+		_ = c.Close()
+	}
 }
@@ -0,0 +1,226 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/containers/podman/v3/pkg/api/server/idle"
+	"github.com/gorilla/mux"
+)
+
+func TestServeContextShutdown(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+
+	tracker := idle.NewTracker(time.Minute)
+	s := &APIServer{idleTracker: tracker}
+	s.Server.ConnState = tracker.ConnState
+	inFlight := make(chan struct{})
+	release := make(chan struct{})
+	streamEnded := make(chan struct{})
+	router := http.NewServeMux()
+	router.HandleFunc("/slow", s.APIHandler(func(w http.ResponseWriter, r *http.Request) {
+		close(inFlight)
+		<-release
+		_, _ = io.WriteString(w, "done")
+	}))
+	router.HandleFunc("/stream", s.APIHandler(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+		close(streamEnded)
+	}))
+	router.HandleFunc("/hijack", s.APIHandler(func(w http.ResponseWriter, r *http.Request) {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		defer r.Context().Value("idletracker").(*idle.Tracker).Close()
+		_, _ = io.WriteString(conn, "HTTP/1.1 101 UPGRADED\r\n\r\n")
+		_, _ = io.Copy(ioutil.Discard, conn)
+	}))
+	s.Server.Handler = router
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	served := make(chan error, 1)
+	go func() {
+		served <- s.ServeContext(ctx, listener)
+	}()
+
+	type result struct {
+		body string
+		err  error
+	}
+	slow := make(chan result, 1)
+	go func() {
+		resp, err := http.Get("http://" + addr + "/slow")
+		if err != nil {
+			slow <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		slow <- result{string(body), err}
+	}()
+	<-inFlight
+
+	stream, err := http.Get("http://" + addr + "/stream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Body.Close()
+
+	hijacked, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer hijacked.Close()
+	if err := hijacked.SetDeadline(time.Now().Add(10 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.WriteString(hijacked, "GET /hijack HTTP/1.1\r\nHost: localhost\r\n\r\n"); err != nil {
+		t.Fatal(err)
+	}
+	hr := bufio.NewReader(hijacked)
+	if status, err := hr.ReadString('\n'); err != nil || !strings.Contains(status, "101") {
+		t.Fatalf("hijacking returned %q: %v", status, err)
+	}
+
+	cancel()
+
+	select {
+	case <-streamEnded:
+	case <-time.After(10 * time.Second):
+		t.Fatal("streaming request not canceled")
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		conn, err := net.Dial("tcp", addr)
+		if err != nil {
+			break
+		}
+		conn.Close()
+		if time.Now().After(deadline) {
+			t.Fatal("server still accepts connections")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	select {
+	case err := <-served:
+		t.Fatalf("server stopped before the request in flight completed: %v", err)
+	default:
+	}
+
+	close(release)
+	if res := <-slow; res.err != nil || res.body != "done" {
+		t.Errorf("request in flight returned %q: %v", res.body, res.err)
+	}
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("ServeContext() error = %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("server did not stop")
+	}
+	// The hijacked connection is closed rather than left to the handler.
+	if _, err := ioutil.ReadAll(hr); err != nil {
+		t.Errorf("hijacked connection not closed: %v", err)
+	}
+}
+
+func TestServerTimeouts(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	s, err := NewServerWithTimeouts(nil, UnlimitedServiceDuration, &listener, Timeouts{
+		ReadHeaderTimeout: 200 * time.Millisecond,
+		WriteTimeout:      200 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	router := s.Server.Handler.(*mux.Router)
+	router.HandleFunc("/slow", s.APIHandler(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+		_, _ = io.WriteString(w, "done")
+	}))
+	router.HandleFunc("/stream", s.StreamingAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+		_, _ = io.WriteString(w, "done")
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- s.ServeContext(ctx, listener)
+	}()
+	defer func() {
+		cancel()
+		<-served
+	}()
+
+	// A client never finishing the headers is disconnected.
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(10 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if _, err := io.WriteString(conn, "GET /_ping HTTP/1.1\r\nHost: localhost\r\n"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ioutil.ReadAll(conn); err != nil {
+		t.Errorf("incomplete request not disconnected: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("incomplete request disconnected after %s", elapsed)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get("http://" + addr + "/_ping")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil || resp.StatusCode != http.StatusOK || string(body) != "OK" {
+		t.Errorf("ping returned %d %q: %v", resp.StatusCode, body, err)
+	}
+
+	// The response of a slow endpoint is cut, streaming ones are exempt.
+	if resp, err := client.Get("http://" + addr + "/slow"); err == nil {
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err == nil {
+			t.Errorf("slow request returned %d %q past the write timeout", resp.StatusCode, body)
+		}
+	}
+	resp, err = client.Get("http://" + addr + "/stream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil || string(body) != "done" {
+		t.Errorf("streaming request returned %q: %v", body, err)
+	}
+}
@@ -2,7 +2,6 @@ package server
 
 import (
 	"github.com/containers/podman/v3/libpod"
-	"github.com/containers/podman/v3/libpod/define"
 	"github.com/containers/podman/v3/pkg/domain/entities"
 	"github.com/containers/podman/v3/pkg/domain/entities/reports"
 	"github.com/containers/podman/v3/pkg/errorhandling"
@@ -71,6 +70,15 @@ type swagErrNoSuchManifest struct {
 	}
 }
 
+// No such failure
+// swagger:response NoSuchFailure
+type swagErrNoSuchFailure struct {
+	// in:body
+	Body struct {
+		errorhandling.ErrorModel
+	}
+}
+
 // Internal server error
 // swagger:response InternalError
 type swagInternalError struct {
@@ -196,7 +204,7 @@ type swagVolumeListResponse struct {
 type swagHealthCheckRunResponse struct {
 	// in:body
 	Body struct {
-		define.HealthCheckResults
+		entities.HealthCheckProbeReport
 	}
 }
 
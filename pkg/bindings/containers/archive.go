@@ -49,12 +49,22 @@ func Stat(ctx context.Context, nameOrID string, path string) (*entities.Containe
 	return statReport, finalErr
 }
 
+// CopyFromArchive copies an archive into a container, changing the ownership
+// of the copied files to the user of the container.
 func CopyFromArchive(ctx context.Context, nameOrID string, path string, reader io.Reader) (entities.ContainerCopyFunc, error) {
+	return CopyFromArchiveWithOptions(ctx, nameOrID, path, reader, new(CopyOptions).WithCopyUIDGID(true))
+}
+
+// CopyFromArchiveWithOptions copies an archive into a container.
+func CopyFromArchiveWithOptions(ctx context.Context, nameOrID string, path string, reader io.Reader, options *CopyOptions) (entities.ContainerCopyFunc, error) {
 	conn, err := bindings.GetClient(ctx)
 	if err != nil {
 		return nil, err
 	}
-	params := url.Values{}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
 	params.Set("path", path)
 
 	return func() error {
@@ -150,6 +150,17 @@ type RestartOptions struct {
 	Timeout *int
 }
 
+//go:generate go run ../generator/generator.go CopyOptions
+// CopyOptions are optional options for copying archives into containers
+type CopyOptions struct {
+	// CopyUIDGID changes the ownership of the copied files to the user
+	// of the container.
+	CopyUIDGID *bool
+	// NoOverwriteDirNonDir fails instead of replacing a directory with a
+	// non-directory.
+	NoOverwriteDirNonDir *bool
+}
+
 //go:generate go run ../generator/generator.go StartOptions
 // StartOptions are optional options for starting containers
 type StartOptions struct {
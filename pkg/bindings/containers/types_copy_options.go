@@ -0,0 +1,53 @@
+package containers
+
+import (
+	"net/url"
+
+	"github.com/containers/podman/v3/pkg/bindings/internal/util"
+)
+
+/*
+This file is generated automatically by go generate.  Do not edit.
+*/
+
+// Changed
+func (o *CopyOptions) Changed(fieldName string) bool {
+	return util.Changed(o, fieldName)
+}
+
+// ToParams
+func (o *CopyOptions) ToParams() (url.Values, error) {
+	return util.ToParams(o)
+}
+
+// WithCopyUIDGID
+func (o *CopyOptions) WithCopyUIDGID(value bool) *CopyOptions {
+	v := &value
+	o.CopyUIDGID = v
+	return o
+}
+
+// GetCopyUIDGID
+func (o *CopyOptions) GetCopyUIDGID() bool {
+	var copyUIDGID bool
+	if o.CopyUIDGID == nil {
+		return copyUIDGID
+	}
+	return *o.CopyUIDGID
+}
+
+// WithNoOverwriteDirNonDir
+func (o *CopyOptions) WithNoOverwriteDirNonDir(value bool) *CopyOptions {
+	v := &value
+	o.NoOverwriteDirNonDir = v
+	return o
+}
+
+// GetNoOverwriteDirNonDir
+func (o *CopyOptions) GetNoOverwriteDirNonDir() bool {
+	var noOverwriteDirNonDir bool
+	if o.NoOverwriteDirNonDir == nil {
+		return noOverwriteDirNonDir
+	}
+	return *o.NoOverwriteDirNonDir
+}
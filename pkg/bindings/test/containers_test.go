@@ -315,13 +315,13 @@ var _ = Describe("Podman containers ", func() {
 		code, _ := bindings.CheckResponseCode(err)
 		Expect(code).To(BeNumerically("==", http.StatusNotFound))
 
-		// a container that has no healthcheck should be a 409
+		// a container that has no healthcheck should be a 400
 		var name = "top"
 		bt.RunTopContainer(&name, nil)
 		_, err = containers.RunHealthCheck(bt.conn, name, nil)
 		Expect(err).ToNot(BeNil())
 		code, _ = bindings.CheckResponseCode(err)
-		Expect(code).To(BeNumerically("==", http.StatusConflict))
+		Expect(code).To(BeNumerically("==", http.StatusBadRequest))
 
 		// TODO for the life of me, i cannot get this to work. maybe another set
 		// of eyes will
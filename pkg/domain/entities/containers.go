@@ -148,6 +148,17 @@ type ContainerStatReport struct {
 	copy.FileInfo
 }
 
+// ContainerCopyFromArchiveOptions describes how an archive is copied into a
+// container.
+type ContainerCopyFromArchiveOptions struct {
+	// Chown sets the ownership of the copied files to the user of the
+	// container instead of the ownership in the archive.
+	Chown bool
+	// NoOverwriteDirNonDir fails instead of replacing a directory with a
+	// non-directory.
+	NoOverwriteDirNonDir bool
+}
+
 type CommitOptions struct {
 	Author         string
 	Changes        []string
@@ -358,7 +369,7 @@ type ContainerInitReport struct {
 	Id  string //nolint
 }
 
-//ContainerMountOptions describes the input values for mounting containers
+// ContainerMountOptions describes the input values for mounting containers
 // in the CLI
 type ContainerMountOptions struct {
 	All        bool
@@ -439,3 +450,410 @@ type ContainerRenameOptions struct {
 	// NewName is the new name that will be given to the container.
 	NewName string
 }
+
+// ContainerDependencyNode is a container in a dependency graph.
+type ContainerDependencyNode struct {
+	ID    string
+	Name  string
+	State string
+}
+
+// ContainerDependencyEdge describes that container From depends on container
+// To, and the kinds of that dependency (shared namespaces, pod, volumes-from).
+type ContainerDependencyEdge struct {
+	From  string
+	To    string
+	Types []string
+}
+
+// ContainerDependencyReport is the dependency graph around a container.
+type ContainerDependencyReport struct {
+	Nodes []ContainerDependencyNode
+	Edges []ContainerDependencyEdge
+}
+
+// ContainerKernelMessage is a record of the kernel ring buffer related to a
+// container.
+type ContainerKernelMessage struct {
+	// Level is the syslog level of the message.
+	Level int
+	// Sequence is the sequence number of the record in the ring buffer.
+	Sequence uint64
+	// Timestamp is the time since boot the message was logged at, in
+	// microseconds.
+	Timestamp uint64
+	// Message is the text of the message.
+	Message string
+}
+
+// ContainerNamespacesReport describes the kernel namespaces and cgroup of a
+// running container.
+type ContainerNamespacesReport struct {
+	// PID of the container's init process.
+	PID int
+	// CgroupPath is the path of the container's cgroup.
+	CgroupPath string
+	// Namespaces maps namespace types (net, pid, ...) to their inode
+	// numbers.
+	Namespaces map[string]uint64
+}
+
+// ContainerIDMapping is a range of IDs mapped from the container to the host.
+type ContainerIDMapping struct {
+	ContainerID int
+	HostID      int
+	Size        int
+}
+
+// ContainerUserNSReport describes the user namespace mappings of a container.
+type ContainerUserNSReport struct {
+	// Enabled is false when the container shares the user namespace of
+	// the host.
+	Enabled bool
+	// UserNSContainer is the ID of the container whose user namespace is
+	// joined, if any.
+	UserNSContainer string `json:",omitempty"`
+	UIDMap          []ContainerIDMapping
+	GIDMap          []ContainerIDMapping
+	// RootUID and RootGID are the host IDs root in the container maps to.
+	RootUID int
+	RootGID int
+}
+
+// ContainerFSAuditEvent is a change to a file in the root filesystem of a
+// container.
+type ContainerFSAuditEvent struct {
+	Time time.Time
+	// Op is one of create, modify, delete, rename or attrib.
+	Op string
+	// Path is the path of the file inside of the container.
+	Path string
+}
+
+// ContainerProcessSched is the scheduling policy and nice value of a process
+// of a container.
+type ContainerProcessSched struct {
+	PID int
+	// Policy is one of other, fifo, rr, batch, idle or deadline.
+	Policy string
+	Nice   int
+}
+
+// ContainerSchedReport describes the scheduling of the processes of a
+// container.
+type ContainerSchedReport struct {
+	Processes []ContainerProcessSched
+}
+
+// ContainerShmReport describes the /dev/shm tmpfs of a container.
+type ContainerShmReport struct {
+	// Size is the size of the tmpfs in bytes.
+	Size int64
+	// Used is the number of bytes used.
+	Used int64
+	// Available is the number of bytes available.
+	Available int64
+}
+
+// ContainerProvenanceReport describes how a container was created.
+type ContainerProvenanceReport struct {
+	// Command is the command used to create the container.
+	Command []string
+	// CommandLine is Command quoted for use in a shell.
+	CommandLine string
+	// Reconstructed is true when the container was not created by the
+	// podman CLI and Command was derived from its configuration.
+	Reconstructed bool
+	ImageID       string `json:",omitempty"`
+	ImageName     string `json:",omitempty"`
+	ImageDigest   string `json:",omitempty"`
+	Created       time.Time
+}
+
+// ContainerMount is a mount of a container, including tmpfs mounts.
+type ContainerMount struct {
+	define.InspectMount
+	// ActivePropagation is the propagation mode of the mount as seen by the
+	// kernel, one of shared, slave, private or unbindable.  It is only
+	// set for running containers.
+	ActivePropagation string `json:",omitempty"`
+}
+
+// ContainerDNSReport describes the name resolution configuration of a
+// container.
+type ContainerDNSReport struct {
+	// Source is "container" when read from the resolv.conf inside of the
+	// running container, "resolv.conf" when read from the file generated
+	// for the container and "config" when the container was never
+	// initialized and only the configured values are known.
+	Source      string
+	ResolvConf  string `json:",omitempty"`
+	Nameservers []string
+	Search      []string
+	Options     []string
+	// ExtraHosts are the entries added to /etc/hosts with --add-host.
+	ExtraHosts []string
+}
+
+// ContainerBundleManifest describes an OCI runtime bundle exported from a
+// container.
+type ContainerBundleManifest struct {
+	ContainerID string
+	Name        string
+	Image       string `json:",omitempty"`
+	Created     time.Time
+	// Stripped lists the host specific settings removed from config.json.
+	Stripped []ContainerBundleStripped
+}
+
+// ContainerBundleStripped is a host specific setting removed from the
+// config.json of an exported bundle.
+type ContainerBundleStripped struct {
+	// Field is the setting in config.json, e.g. mounts or linux.cgroupsPath.
+	Field string
+	// Value is the removed host specific value.
+	Value string
+}
+
+// ContainerSyscallStats are the system calls made by the processes of a
+// container during an interval.
+type ContainerSyscallStats struct {
+	Time     time.Time
+	Interval time.Duration
+	// Syscalls maps system call names to the number of calls.
+	Syscalls map[string]uint64
+}
+
+// ContainerCapabilitiesReport lists the capability sets of the init process
+// of a running container as enforced by the kernel.
+type ContainerCapabilitiesReport struct {
+	PID         int
+	Effective   []string
+	Permitted   []string
+	Inheritable []string
+	Bounding    []string
+	Ambient     []string
+}
+
+// ContainersLogsRequest selects the containers and log lines to stream with
+// POST /libpod/logs.
+type ContainersLogsRequest struct {
+	// Containers are the names or IDs of the containers to stream the logs
+	// of.
+	Containers []string `json:"containers"`
+	Follow     bool     `json:"follow"`
+	// Since only shows lines since the given timestamp or duration.
+	Since string `json:"since"`
+	// Tail only shows the given number of lines per container, -1 for all.
+	Tail *int64 `json:"tail"`
+}
+
+// ContainersLogLine is a log line of one of a set of containers.
+type ContainersLogLine struct {
+	Container string
+	Name      string
+	// Color is the position of the container in the request, for telling
+	// containers apart consistently.
+	Color  int
+	Stream string
+	Time   time.Time
+	Msg    string
+}
+
+// ContainerPortConflict is a host port mapping of a container which cannot
+// be bound.
+type ContainerPortConflict struct {
+	HostIP        string `json:",omitempty"`
+	HostPort      int32
+	ContainerPort int32
+	Protocol      string
+	// Container is the ID of the container already using the host port,
+	// if any.
+	Container string `json:",omitempty"`
+	// Reason describes why the port cannot be bound.
+	Reason string
+}
+
+// ContainerCheckPortsReport lists the port mappings of a container which
+// would make it fail to start.
+type ContainerCheckPortsReport struct {
+	Conflicts []ContainerPortConflict
+}
+
+// ContainersLabelBatchRequest selects containers and the label changes to
+// apply to them with POST /libpod/containers/label-batch.
+type ContainersLabelBatchRequest struct {
+	// Filters selects the containers, as for listing containers.
+	Filters map[string][]string `json:"filters"`
+	Add     map[string]string   `json:"add"`
+	Remove  []string            `json:"remove"`
+}
+
+// ContainerLabelReport is the result of changing the labels of a single
+// container.
+type ContainerLabelReport struct {
+	Id   string //nolint
+	Name string
+	Err  string `json:",omitempty"`
+}
+
+// ContainerRestartBackoffReport describes the delay of restarts of a
+// container after failures.
+type ContainerRestartBackoffReport struct {
+	// Enabled is false if failed containers are restarted immediately.
+	Enabled      bool
+	InitialDelay string  `json:",omitempty"`
+	MaxDelay     string  `json:",omitempty"`
+	Multiplier   float64 `json:",omitempty"`
+	// RestartCount is the number of restarts since the container was
+	// last started.
+	RestartCount uint
+	// NextDelay is the delay of the next restart after a failure.
+	NextDelay string `json:",omitempty"`
+}
+
+// ContainerCoreDump is a core dump stored in a container by the kernel.
+type ContainerCoreDump struct {
+	// ID is the file name of the dump, for fetching it.
+	ID   string
+	Size int64
+	// PID, Signal and Executable of the crashed process are only known
+	// when part of the core pattern of the host.
+	PID        int    `json:",omitempty"`
+	Signal     int    `json:",omitempty"`
+	Executable string `json:",omitempty"`
+	Time       time.Time
+}
+
+// ContainerFSFreezeReport describes a frozen container filesystem.
+type ContainerFSFreezeReport struct {
+	// Thaw is the time the filesystem is thawed at automatically.
+	Thaw time.Time
+}
+
+// ContainerDrift is a setting of a container differing from the desired
+// one.
+type ContainerDrift struct {
+	// Field names the setting, entries of maps are named by key, e.g.
+	// Env.PATH.
+	Field string
+	// Current is unset if the container does not have the setting.
+	Current interface{} `json:",omitempty"`
+	Desired interface{}
+	// Source is where the current value comes from, image or container.
+	Source string
+}
+
+// ContainerDriftReport lists the settings of a container differing from a
+// desired spec.
+type ContainerDriftReport struct {
+	Drifts []ContainerDrift
+}
+
+// ContainerHolder is a host process holding resources of a container.
+type ContainerHolder struct {
+	PID  int
+	PPID int
+	// Kind is conmon, exec for the conmon of an exec session, runtime,
+	// podman, container for processes in the cgroup of the container, or
+	// other.
+	Kind    string
+	Command string
+}
+
+// ContainerHoldersReport lists the host processes holding resources of a
+// container.
+type ContainerHoldersReport struct {
+	State       string
+	ConmonPID   int
+	ConmonAlive bool
+	Holders     []ContainerHolder
+}
+
+// ContainerHolderKill is the result of killing a process holding resources
+// of a container.
+type ContainerHolderKill struct {
+	PID  int
+	Kind string
+	Err  string `json:",omitempty"`
+}
+
+// ContainerAutoRemoveRequest enables or disables removing a container when
+// it exits.
+type ContainerAutoRemoveRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// ContainerAutoRemoveReport is the result of changing the auto removal of a
+// container.
+type ContainerAutoRemoveReport struct {
+	Id      string //nolint
+	Enabled bool
+	// Removed is true if the container was removed right away, as it was
+	// not running.
+	Removed bool
+}
+
+// ContainerPauseReport is the result of pausing a container until a deadline.
+type ContainerPauseReport struct {
+	Id string //nolint
+	// UnpauseAt is the time the container is unpaused at.
+	UnpauseAt time.Time
+}
+
+// ContainerStartPhase is the time spent in a phase of starting a container.
+type ContainerStartPhase struct {
+	// Name is one of image-mount, network-setup, rootfs-prepare,
+	// runtime-create and runtime-start.
+	Name     string
+	Duration time.Duration
+}
+
+// ContainerStartTimingReport describes the phases of the last successful
+// start of a container.  Mounting the image and setting up the network run
+// concurrently.
+type ContainerStartTimingReport struct {
+	Id      string //nolint
+	Started time.Time
+	// Total is the time the whole start took.
+	Total  time.Duration
+	Phases []ContainerStartPhase
+}
+
+// SpecValidationIssue is a problem found validating a spec.
+type SpecValidationIssue struct {
+	// Field is the field of the spec the issue is about, empty if it is
+	// about the spec as a whole.
+	Field   string
+	Message string
+}
+
+// SpecValidationReport holds the issues of a spec sent to the spec
+// validation stream.
+type SpecValidationReport struct {
+	// Revision counts the specs received, the report is for the latest.
+	Revision int
+	Valid    bool
+	Issues   []SpecValidationIssue
+}
+
+// ContainerNetInterfaceStats is the traffic of a network interface of a
+// container.
+type ContainerNetInterfaceStats struct {
+	Name      string
+	RxBytes   uint64
+	RxPackets uint64
+	RxErrors  uint64
+	RxDropped uint64
+	TxBytes   uint64
+	TxPackets uint64
+	TxErrors  uint64
+	TxDropped uint64
+}
+
+// ContainerNetStatsReport is the traffic of the network interfaces of a
+// container at a time.
+type ContainerNetStatsReport struct {
+	Time       time.Time
+	Interfaces []ContainerNetInterfaceStats
+}
@@ -42,17 +42,22 @@ func ConvertToLibpodEvent(e Event) *libpodEvents.Event {
 }
 
 // ConvertToEntitiesEvent converts a libpod event to an entities one.
+// The attributes of the event, as the labels of containers, are attributes
+// of the actor as with Docker.
 func ConvertToEntitiesEvent(e libpodEvents.Event) *Event {
+	attributes := make(map[string]string, len(e.Attributes)+3)
+	for k, v := range e.Attributes {
+		attributes[k] = v
+	}
+	attributes["image"] = e.Image
+	attributes["name"] = e.Name
+	attributes["containerExitCode"] = strconv.Itoa(e.ContainerExitCode)
 	return &Event{dockerEvents.Message{
 		Type:   e.Type.String(),
 		Action: e.Status.String(),
 		Actor: dockerEvents.Actor{
-			ID: e.ID,
-			Attributes: map[string]string{
-				"image":             e.Image,
-				"name":              e.Name,
-				"containerExitCode": strconv.Itoa(e.ContainerExitCode),
-			},
+			ID:         e.ID,
+			Attributes: attributes,
 		},
 		Scope:    "local",
 		Time:     e.Time.Unix(),
@@ -1,3 +1,47 @@
 package entities
 
+import (
+	"time"
+
+	"github.com/containers/podman/v3/libpod/define"
+)
+
 type HealthCheckOptions struct{}
+
+// HealthCheckProbeReport describes the result of a single run of a
+// container's healthcheck.
+type HealthCheckProbeReport struct {
+	define.HealthCheckLog
+	// Status is the health of the container after the probe ran.
+	Status string
+}
+
+// HealthCheckDiagnosis describes a single run of a container's healthcheck
+// together with the resource pressure on the container while it ran.
+type HealthCheckDiagnosis struct {
+	// Status is healthy or unhealthy.
+	Status   string
+	Command  []string
+	ExitCode int
+	Stdout   string
+	Stderr   string
+	Start    time.Time
+	End      time.Time
+	// Duration is the time the healthcheck command took in nanoseconds.
+	Duration time.Duration
+	// Timeout is the configured timeout of the healthcheck in nanoseconds.
+	Timeout  time.Duration
+	TimedOut bool
+	// MemUsage, MemLimit and MemPerc describe the memory usage of the
+	// container after the healthcheck ran.
+	MemUsage uint64
+	MemLimit uint64
+	MemPerc  float64
+	// NearOOM is true if the memory usage is close to the limit.
+	NearOOM bool
+	// CPUThrottled is the number of periods the container was throttled
+	// in while the healthcheck ran.
+	CPUThrottled uint64
+	// Diagnosis lists the likely reasons for the result.
+	Diagnosis []string
+}
@@ -376,3 +376,16 @@ type ImageUnmountReport struct {
 	Err error
 	Id  string // nolint
 }
+
+// BuildContextReport describes a build context uploaded for use by several
+// builds.
+type BuildContextReport struct {
+	// Handle refers to the context in builds.
+	Handle string
+	// Files is the number of regular files in the context.
+	Files      int
+	Size       int64
+	Dockerfile string
+	// Expires is the time the context is removed at.
+	Expires time.Time
+}
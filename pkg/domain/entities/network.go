@@ -93,3 +93,31 @@ type NetworkPruneReport struct {
 // NetworkPruneOptions describes options for pruning
 // unused cni networks
 type NetworkPruneOptions struct{}
+
+// NetworkFirewallTable holds the rules of a network in a table, as
+// iptables-save lines.
+type NetworkFirewallTable struct {
+	Name string
+	// Family is ipv4 or ipv6.
+	Family string
+	// Chains are the chains of the network, only its rules jump to.
+	Chains []string
+	Rules  []string
+}
+
+// NetworkFirewallReport holds the firewall rules in effect for a network.
+type NetworkFirewallReport struct {
+	Network string
+	Backend string
+	Subnets []string
+	Tables  []NetworkFirewallTable
+}
+
+// NetworkFirewallRestoreReport is the result of restoring the firewall
+// rules of a network.
+type NetworkFirewallRestoreReport struct {
+	// Applied is the number of rules installed, Present the number of
+	// rules already in effect.
+	Applied int
+	Present int
+}
@@ -82,6 +82,18 @@ type PodRestartReport struct {
 	Id   string //nolint
 }
 
+// PodRollingRestartReport is the progress of a rolling restart of the
+// containers of a pod.
+type PodRollingRestartReport struct {
+	Time time.Time
+	// Container is the ID of the container the report is about.
+	Container string
+	Name      string
+	// Status is one of restarting, restarted, healthy or failed.
+	Status string
+	Error  string `json:",omitempty"`
+}
+
 type PodStartOptions struct {
 	All    bool
 	Latest bool
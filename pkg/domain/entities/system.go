@@ -14,6 +14,21 @@ type ServiceOptions struct {
 	URI     string         // Path to unix domain socket service should listen on
 	Timeout time.Duration  // duration of inactivity the service should wait before shutting down
 	Command *cobra.Command // CLI command provided. Used in V1 code
+	// PullMaxDownloads is the maximum number of concurrent layer downloads
+	// of all pulls, 0 meaning no limit.
+	PullMaxDownloads int
+	// PullBandwidth is the bandwidth in bytes per second shared by all
+	// pulls, 0 meaning no limit.
+	PullBandwidth int64
+	// RecordFailures is the number of failed create, start and stop
+	// requests of containers kept to be replayed, 0 disabling recording.
+	RecordFailures int
+	// ReadHeaderTimeout, IdleTimeout and WriteTimeout override the
+	// timeouts of the connections to the service if set, 0 disabling a
+	// timeout.
+	ReadHeaderTimeout *time.Duration
+	IdleTimeout       *time.Duration
+	WriteTimeout      *time.Duration
 }
 
 // SystemPruneOptions provides options to prune system.
@@ -107,3 +122,92 @@ type ComponentVersion struct {
 type ListRegistriesReport struct {
 	Registries []string
 }
+
+// PortReservation is a set of host ports held for a container which is
+// about to be created.
+type PortReservation struct {
+	// Token is passed as port-reservation when creating the container.
+	Token    string
+	Protocol string
+	Ports    []uint16
+	// Expires is the time the ports are released if no container was
+	// created with the reservation.
+	Expires time.Time
+}
+
+// GroupUsageReport is the summed resource usage of a group of running
+// containers.
+type GroupUsageReport struct {
+	Time time.Time
+	// Containers is the number of containers in the group.
+	Containers int
+	// CPU is the sum of the CPU percentages of the containers.
+	CPU         float64
+	CPUNano     uint64
+	MemUsage    uint64
+	NetInput    uint64
+	NetOutput   uint64
+	BlockInput  uint64
+	BlockOutput uint64
+	PIDs        uint64
+	// Stats are the stats of the containers the sums are of.
+	Stats []define.ContainerStats
+}
+
+// SystemFailureReport describes a failed request recorded by the service.
+type SystemFailureReport struct {
+	// Id identifies the failure to replay it.
+	Id string //nolint
+	// Time is when the request failed last.
+	Time time.Time
+	// Method is the HTTP method of the request.
+	Method string
+	// URL is the path and query of the request.
+	URL string
+	// Body is the body of the request.
+	Body string
+	// Status is the HTTP status the request failed with last.
+	Status int
+	// Error is the error the request failed with last.
+	Error string
+	// Replays is the number of failed replays of the request.
+	Replays int
+}
+
+// PullLimitsReport describes the limits shared by all pulls of the service.
+type PullLimitsReport struct {
+	// MaxConcurrentDownloads is the maximum number of concurrent layer
+	// downloads, 0 meaning no limit.
+	MaxConcurrentDownloads int
+	// Bandwidth is the bandwidth in bytes per second, 0 meaning no limit.
+	Bandwidth int64
+	// ActiveDownloads is the number of downloads in progress.
+	ActiveDownloads int
+	// WaitingDownloads is the number of downloads waiting for others to
+	// complete.
+	WaitingDownloads int
+}
+
+// SystemCheckFinding is an inconsistency found checking the database and
+// storage.
+type SystemCheckFinding struct {
+	// Severity is error or warning, warnings are reported but not repaired.
+	Severity string
+	// Kind is the kind of inconsistency, e.g. missing-layer.
+	Kind string
+	// ID is the ID of the container, pod or storage container concerned.
+	ID      string
+	Message string
+	// Repaired is true if the inconsistency was repaired.
+	Repaired    bool
+	RepairError string `json:",omitempty"`
+}
+
+// SystemCheckReport describes the result of checking the database and
+// storage.
+type SystemCheckReport struct {
+	Findings []SystemCheckFinding
+	Errors   int
+	Warnings int
+	Repaired int
+}
@@ -89,6 +89,17 @@ type ContainerCreateResponse struct {
 	Warnings []string `json:"Warnings"`
 }
 
+// ContainerCreateBatchResponse is the response struct for creating several
+// containers from a single spec
+type ContainerCreateBatchResponse struct {
+	// IDs of the containers created, in creation order
+	IDs []string `json:"Ids"`
+	// Names of the containers created, in creation order
+	Names []string `json:"Names"`
+	// Warnings during container creation
+	Warnings []string `json:"Warnings"`
+}
+
 // BuildOptions describe the options for building container images.
 type BuildOptions struct {
 	imagebuildah.BuildOptions
@@ -21,7 +21,7 @@ import (
 
 // NOTE: Only the parent directory of the container path must exist.  The path
 // itself may be created while copying.
-func (ic *ContainerEngine) ContainerCopyFromArchive(ctx context.Context, nameOrID string, containerPath string, reader io.Reader) (entities.ContainerCopyFunc, error) {
+func (ic *ContainerEngine) ContainerCopyFromArchive(ctx context.Context, nameOrID string, containerPath string, reader io.Reader, options entities.ContainerCopyFromArchiveOptions) (entities.ContainerCopyFunc, error) {
 	container, err := ic.Libpod.LookupContainer(nameOrID)
 	if err != nil {
 		return nil, err
@@ -62,10 +62,13 @@ func (ic *ContainerEngine) ContainerCopyFromArchive(ctx context.Context, nameOrI
 		defer unmount()
 		defer decompressed.Close()
 		putOptions := buildahCopiah.PutOptions{
-			UIDMap:     idMappings.UIDMap,
-			GIDMap:     idMappings.GIDMap,
-			ChownDirs:  idPair,
-			ChownFiles: idPair,
+			UIDMap:               idMappings.UIDMap,
+			GIDMap:               idMappings.GIDMap,
+			NoOverwriteDirNonDir: options.NoOverwriteDirNonDir,
+		}
+		if options.Chown {
+			putOptions.ChownDirs = idPair
+			putOptions.ChownFiles = idPair
 		}
 		return buildahCopiah.Put(resolvedRoot, resolvedContainerPath, putOptions, decompressed)
 	}, nil
@@ -55,50 +55,79 @@ func ExecCmdWithStdStreams(stdin io.Reader, stdout, stderr io.Writer, env []stri
 var ErrDetach = define.ErrDetach
 
 // CopyDetachable is similar to io.Copy but support a detach key sequence to break out.
+// The keys may arrive in one read or spread over several, the bytes read
+// which may start the sequence are held back until it is known whether the
+// whole sequence follows.
 func CopyDetachable(dst io.Writer, src io.Reader, keys []byte) (written int64, err error) {
 	buf := make([]byte, 32*1024)
+	out := make([]byte, 0, len(buf)+len(keys))
+	fallback := detachFallback(keys)
+	matched := 0
 	for {
 		nr, er := src.Read(buf)
-		if nr > 0 {
-			preservBuf := []byte{}
-			for i, key := range keys {
-				preservBuf = append(preservBuf, buf[0:nr]...)
-				if nr != 1 || buf[0] != key {
+		out = out[:0]
+		detached := false
+		for _, b := range buf[0:nr] {
+			// On a mismatch the held back bytes may still end in a
+			// shorter start of the sequence, only the bytes before it
+			// are passed on.
+			for matched > 0 && b != keys[matched] {
+				next := fallback[matched-1]
+				out = append(out, keys[:matched-next]...)
+				matched = next
+			}
+			if len(keys) > 0 && b == keys[matched] {
+				matched++
+				if matched == len(keys) {
+					detached = true
 					break
 				}
-				if i == len(keys)-1 {
-					return 0, ErrDetach
-				}
-				nr, er = src.Read(buf)
-			}
-			var nw int
-			var ew error
-			if len(preservBuf) > 0 {
-				nw, ew = dst.Write(preservBuf)
-				nr = len(preservBuf)
-			} else {
-				nw, ew = dst.Write(buf[0:nr])
+				continue
 			}
+			out = append(out, b)
+		}
+		if er != nil && !detached {
+			// Nothing follows what was held back
+			out = append(out, keys[:matched]...)
+		}
+		if len(out) > 0 {
+			nw, ew := dst.Write(out)
 			if nw > 0 {
 				written += int64(nw)
 			}
 			if ew != nil {
-				err = ew
-				break
+				return written, ew
 			}
-			if nr != nw {
-				err = io.ErrShortWrite
-				break
+			if nw != len(out) {
+				return written, io.ErrShortWrite
 			}
 		}
+		if detached {
+			return written, ErrDetach
+		}
 		if er != nil {
 			if er != io.EOF {
 				err = er
 			}
-			break
+			return written, err
+		}
+	}
+}
+
+// detachFallback returns, for every length of a start of keys read, the
+// length of the longest start of keys ending it, as in Knuth-Morris-Pratt.
+func detachFallback(keys []byte) []int {
+	fallback := make([]int, len(keys))
+	for i, k := 1, 0; i < len(keys); i++ {
+		for k > 0 && keys[i] != keys[k] {
+			k = fallback[k-1]
+		}
+		if keys[i] == keys[k] {
+			k++
 		}
+		fallback[i] = k
 	}
-	return written, err
+	return fallback
 }
 
 // UntarToFileSystem untars an os.file of a tarball to a destination in the filesystem
@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+// chunkReader returns the given chunks, one per read.
+type chunkReader struct {
+	chunks []string
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	if len(r.chunks) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.chunks[0])
+	r.chunks = r.chunks[1:]
+	return n, nil
+}
+
+func TestCopyDetachable(t *testing.T) {
+	keys := []byte{16, 17}
+	tests := []struct {
+		name   string
+		chunks []string
+		keys   []byte
+		want   string
+		detach bool
+	}{
+		{
+			name:   "no keys",
+			chunks: []string{"hello\n", "\x10\x11"},
+			want:   "hello\n\x10\x11",
+		},
+		{
+			name:   "no detach",
+			chunks: []string{"hello\n", "world\n"},
+			keys:   keys,
+			want:   "hello\nworld\n",
+		},
+		{
+			name:   "keys in separate reads",
+			chunks: []string{"hello\n", "\x10", "\x11", "ignored"},
+			keys:   keys,
+			want:   "hello\n",
+			detach: true,
+		},
+		{
+			name:   "keys within a read",
+			chunks: []string{"hello\n\x10\x11ignored"},
+			keys:   keys,
+			want:   "hello\n",
+			detach: true,
+		},
+		{
+			name:   "keys across reads",
+			chunks: []string{"hello\x10", "\x11"},
+			keys:   keys,
+			want:   "hello",
+			detach: true,
+		},
+		{
+			name:   "partial keys are passed on",
+			chunks: []string{"a\x10", "b\x10\x10", "\x11"},
+			keys:   keys,
+			want:   "a\x10b\x10",
+			detach: true,
+		},
+		{
+			name:   "repeated start of the keys",
+			chunks: []string{"a\x10\x10\x10\x11ignored"},
+			keys:   []byte{16, 16, 17},
+			want:   "a\x10",
+			detach: true,
+		},
+		{
+			name:   "repeated start of the keys across reads",
+			chunks: []string{"a\x10\x10", "\x10", "\x10\x11"},
+			keys:   []byte{16, 16, 17},
+			want:   "a\x10\x10",
+			detach: true,
+		},
+		{
+			name:   "partial keys at the end",
+			chunks: []string{"hello\x10"},
+			keys:   keys,
+			want:   "hello\x10",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var dst bytes.Buffer
+			written, err := CopyDetachable(&dst, &chunkReader{chunks: tt.chunks}, tt.keys)
+			if tt.detach && err != ErrDetach {
+				t.Errorf("CopyDetachable() error = %v, want %v", err, ErrDetach)
+			}
+			if !tt.detach && err != nil {
+				t.Errorf("CopyDetachable() error = %v", err)
+			}
+			if dst.String() != tt.want {
+				t.Errorf("CopyDetachable() copied %q, want %q", dst.String(), tt.want)
+			}
+			if written != int64(len(tt.want)) {
+				t.Errorf("CopyDetachable() = %d, want %d", written, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestCopyDetachableReadError(t *testing.T) {
+	var dst bytes.Buffer
+	_, err := CopyDetachable(&dst, iotest.TimeoutReader(&chunkReader{chunks: []string{"a\x10"}}), []byte{16, 17})
+	if err != iotest.ErrTimeout {
+		t.Errorf("CopyDetachable() error = %v, want %v", err, iotest.ErrTimeout)
+	}
+	if dst.String() != "a\x10" {
+		t.Errorf("CopyDetachable() copied %q, want %q", dst.String(), "a\x10")
+	}
+}